@@ -0,0 +1,312 @@
+package nebula_sirius
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/nebula-contrib/nebula-sirius/statement/role"
+	"slices"
+	"strings"
+)
+
+// Role is a built-in role of a user in a graph space.
+type Role = role.Role
+
+const (
+	RoleGod   = role.RoleGod
+	RoleAdmin = role.RoleAdmin
+	RoleDBA   = role.RoleDBA
+	RoleUser  = role.RoleUser
+	RoleGuest = role.RoleGuest
+)
+
+// UserRole is the role of a user in a graph space.
+type UserRole struct {
+	User  string
+	Space string
+	Role  Role
+}
+
+// Admin administrates the users and their roles through the meta service.
+// The statement/user and statement/role packages build the equivalent nGQL statements
+// for callers only having a graph session.
+type Admin struct {
+	metaClient      meta.MetaService             // required
+	passwordEncoder func(password string) []byte // optional
+}
+
+// AdminOption is a functional option for configuring an Admin.
+type AdminOption func(*Admin)
+
+// NewAdmin creates a new Admin sending its requests to the given meta client.
+func NewAdmin(metaClient meta.MetaService, options ...AdminOption) *Admin {
+	admin := &Admin{
+		metaClient:      metaClient,
+		passwordEncoder: EncodePassword,
+	}
+
+	for _, opt := range options {
+		opt(admin)
+	}
+
+	return admin
+}
+
+// WithPasswordEncoder sets how the passwords are encoded before being sent to the meta service,
+// EncodePassword by default.
+func WithPasswordEncoder(encoder func(password string) []byte) func(*Admin) {
+	return func(admin *Admin) {
+		admin.passwordEncoder = encoder
+	}
+}
+
+// EncodePassword encodes the password the way the graph service does before storing it in the meta service,
+// as the hex encoded SHA-256 digest of the password.
+func EncodePassword(password string) []byte {
+	digest := sha256.Sum256([]byte(password))
+	return []byte(hex.EncodeToString(digest[:]))
+}
+
+// CreateUser creates the user with the given password. It fails if the user already exists, see EnsureUser.
+func (a *Admin) CreateUser(ctx context.Context, name string, password string) error {
+	if name == "" {
+		return fmt.Errorf("user name cannot be empty")
+	}
+
+	resp, err := a.metaClient.CreateUser(ctx, &meta.CreateUserReq{
+		Account:    []byte(name),
+		EncodedPwd: a.passwordEncoder(password),
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("create user %s", name))
+}
+
+// DropUser drops the user along with its roles. It does nothing if the user does not exist.
+func (a *Admin) DropUser(ctx context.Context, name string) error {
+	if name == "" {
+		return fmt.Errorf("user name cannot be empty")
+	}
+
+	resp, err := a.metaClient.DropUser(ctx, &meta.DropUserReq{
+		Account:  []byte(name),
+		IfExists: true,
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("drop user %s", name))
+}
+
+// AlterUser sets the password of the user.
+func (a *Admin) AlterUser(ctx context.Context, name string, password string) error {
+	if name == "" {
+		return fmt.Errorf("user name cannot be empty")
+	}
+
+	resp, err := a.metaClient.AlterUser(ctx, &meta.AlterUserReq{
+		Account:    []byte(name),
+		EncodedPwd: a.passwordEncoder(password),
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("alter user %s", name))
+}
+
+// ChangePassword changes the password of the user, provided its current password is right.
+func (a *Admin) ChangePassword(ctx context.Context, name string, oldPassword string, newPassword string) error {
+	if name == "" {
+		return fmt.Errorf("user name cannot be empty")
+	}
+
+	resp, err := a.metaClient.ChangePassword(ctx, &meta.ChangePasswordReq{
+		Account:        []byte(name),
+		OldEncodedPwd:  a.passwordEncoder(oldPassword),
+		NewEncodedPwd_: a.passwordEncoder(newPassword),
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("change password of user %s", name))
+}
+
+// GrantRole grants the role on the space to the user, replacing the role the user had on the space.
+func (a *Admin) GrantRole(ctx context.Context, name string, spaceName string, r Role) error {
+	roleItem, err := a.roleItem(ctx, name, spaceName, r)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.metaClient.GrantRole(ctx, &meta.GrantRoleReq{RoleItem: roleItem})
+	return checkExecResp(resp, err, fmt.Sprintf("grant role %s on %s to %s", r, spaceName, name))
+}
+
+// RevokeRole revokes the role on the space from the user.
+func (a *Admin) RevokeRole(ctx context.Context, name string, spaceName string, r Role) error {
+	roleItem, err := a.roleItem(ctx, name, spaceName, r)
+	if err != nil {
+		return err
+	}
+
+	resp, err := a.metaClient.RevokeRole(ctx, &meta.RevokeRoleReq{RoleItem: roleItem})
+	return checkExecResp(resp, err, fmt.Sprintf("revoke role %s on %s from %s", r, spaceName, name))
+}
+
+// ListUsers returns the names of the users, sorted.
+func (a *Admin) ListUsers(ctx context.Context) ([]string, error) {
+	users, err := a.listUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(users))
+	for name := range users {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// ListRoles returns the roles of the users in the space, sorted by user.
+func (a *Admin) ListRoles(ctx context.Context, spaceName string) ([]UserRole, error) {
+	spaceID, err := GetSpaceID(ctx, a.metaClient, spaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.metaClient.ListRoles(ctx, &meta.ListRolesReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list roles in space %s, error code: %s", spaceName, resp.GetCode())
+	}
+
+	return userRoles(resp.GetRoles(), map[nebula.GraphSpaceID]string{spaceID: spaceName}), nil
+}
+
+// GetUserRoles returns the roles of the user in all the spaces, sorted by space.
+func (a *Admin) GetUserRoles(ctx context.Context, name string) ([]UserRole, error) {
+	if name == "" {
+		return nil, fmt.Errorf("user name cannot be empty")
+	}
+
+	resp, err := a.metaClient.GetUserRoles(ctx, &meta.GetUserRolesReq{Account: []byte(name)})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to get roles of user %s, error code: %s", name, resp.GetCode())
+	}
+	if len(resp.GetRoles()) == 0 {
+		return []UserRole{}, nil
+	}
+
+	spacesResp, err := a.metaClient.ListSpaces(ctx, &meta.ListSpacesReq{})
+	if err != nil {
+		return nil, err
+	}
+	if spacesResp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list spaces, error code: %s", spacesResp.GetCode())
+	}
+	spaceNames := make(map[nebula.GraphSpaceID]string, len(spacesResp.GetSpaces()))
+	for _, space := range spacesResp.GetSpaces() {
+		spaceNames[space.GetID().GetSpaceID()] = string(space.GetName())
+	}
+
+	return userRoles(resp.GetRoles(), spaceNames), nil
+}
+
+// EnsureUser creates the user if it does not exist, or sets its password if it differs from the given one.
+// It returns whether the user was created or altered.
+func (a *Admin) EnsureUser(ctx context.Context, name string, password string) (bool, error) {
+	if name == "" {
+		return false, fmt.Errorf("user name cannot be empty")
+	}
+
+	users, err := a.listUsers(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	encodedPwd, exists := users[name]
+	if !exists {
+		if err := a.CreateUser(ctx, name, password); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if string(encodedPwd) == string(a.passwordEncoder(password)) {
+		return false, nil
+	}
+	if err := a.AlterUser(ctx, name, password); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// EnsureRole grants the role on the space to the user unless the user already has it.
+// It returns whether the role was granted.
+func (a *Admin) EnsureRole(ctx context.Context, name string, spaceName string, r Role) (bool, error) {
+	roles, err := a.GetUserRoles(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	for _, userRole := range roles {
+		if userRole.Space == spaceName && userRole.Role == r {
+			return false, nil
+		}
+	}
+	if err := a.GrantRole(ctx, name, spaceName, r); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (a *Admin) listUsers(ctx context.Context) (map[string][]byte, error) {
+	resp, err := a.metaClient.ListUsers(ctx, &meta.ListUsersReq{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list users, error code: %s", resp.GetCode())
+	}
+	return resp.GetUsers(), nil
+}
+
+func (a *Admin) roleItem(ctx context.Context, name string, spaceName string, r Role) (*meta.RoleItem, error) {
+	if name == "" {
+		return nil, fmt.Errorf("user name cannot be empty")
+	}
+	if !r.IsGrantable() {
+		return nil, fmt.Errorf("role %s cannot be granted", r)
+	}
+	roleType, err := meta.RoleTypeFromString(string(r))
+	if err != nil {
+		return nil, err
+	}
+
+	spaceID, err := GetSpaceID(ctx, a.metaClient, spaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &meta.RoleItem{
+		UserID:   []byte(name),
+		SpaceID:  spaceID,
+		RoleType: roleType,
+	}, nil
+}
+
+func userRoles(roleItems []*meta.RoleItem, spaceNames map[nebula.GraphSpaceID]string) []UserRole {
+	roles := make([]UserRole, 0, len(roleItems))
+	for _, item := range roleItems {
+		roles = append(roles, UserRole{
+			User:  string(item.GetUserID()),
+			Space: spaceNames[item.GetSpaceID()],
+			Role:  Role(item.GetRoleType().String()),
+		})
+	}
+
+	slices.SortFunc(roles, func(a, b UserRole) int {
+		if c := strings.Compare(a.User, b.User); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Space, b.Space)
+	})
+	return roles
+}
@@ -0,0 +1,137 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodePassword(t *testing.T) {
+	assert.Equal(t, "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8", string(EncodePassword("password")))
+}
+
+func TestAdmin_EnsureUser_Creates(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListUsers", ctx, &meta.ListUsersReq{}).Return(&meta.ListUsersResp{
+		Code:  nebula.ErrorCode_SUCCEEDED,
+		Users: map[string][]byte{"root": EncodePassword("nebula")},
+	}, nil).Once()
+	metaClient.On("CreateUser", ctx, &meta.CreateUserReq{
+		Account:    []byte("tenant_1"),
+		EncodedPwd: EncodePassword("secret"),
+	}).Return(succeededExecResp(), nil).Once()
+
+	changed, err := NewAdmin(metaClient).EnsureUser(ctx, "tenant_1", "secret")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
+
+func TestAdmin_EnsureUser_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListUsers", ctx, &meta.ListUsersReq{}).Return(&meta.ListUsersResp{
+		Code:  nebula.ErrorCode_SUCCEEDED,
+		Users: map[string][]byte{"tenant_1": EncodePassword("secret")},
+	}, nil).Once()
+
+	changed, err := NewAdmin(metaClient).EnsureUser(ctx, "tenant_1", "secret")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestAdmin_EnsureUser_AltersPassword(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	encoder := func(password string) []byte { return []byte("enc:" + password) }
+	metaClient.On("ListUsers", ctx, &meta.ListUsersReq{}).Return(&meta.ListUsersResp{
+		Code:  nebula.ErrorCode_SUCCEEDED,
+		Users: map[string][]byte{"tenant_1": []byte("enc:old")},
+	}, nil).Once()
+	metaClient.On("AlterUser", ctx, &meta.AlterUserReq{
+		Account:    []byte("tenant_1"),
+		EncodedPwd: []byte("enc:new"),
+	}).Return(succeededExecResp(), nil).Once()
+
+	changed, err := NewAdmin(metaClient, WithPasswordEncoder(encoder)).EnsureUser(ctx, "tenant_1", "new")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
+
+func TestAdmin_EnsureRole(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	spaceID := nebula.GraphSpaceID(1)
+	metaClient.On("GetUserRoles", ctx, &meta.GetUserRolesReq{Account: []byte("tenant_1")}).Return(&meta.ListRolesResp{
+		Code:  nebula.ErrorCode_SUCCEEDED,
+		Roles: []*meta.RoleItem{{UserID: []byte("tenant_1"), SpaceID: 1, RoleType: meta.RoleType_GUEST}},
+	}, nil).Twice()
+	metaClient.On("ListSpaces", ctx, &meta.ListSpacesReq{}).Return(&meta.ListSpacesResp{
+		Code:   nebula.ErrorCode_SUCCEEDED,
+		Spaces: []*meta.IdName{{ID: &meta.ID{SpaceID: &spaceID}, Name: []byte("bank")}},
+	}, nil).Twice()
+
+	admin := NewAdmin(metaClient)
+
+	changed, err := admin.EnsureRole(ctx, "tenant_1", "bank", RoleGuest)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("GrantRole", ctx, &meta.GrantRoleReq{
+		RoleItem: &meta.RoleItem{UserID: []byte("tenant_1"), SpaceID: 1, RoleType: meta.RoleType_DBA},
+	}).Return(succeededExecResp(), nil).Once()
+
+	changed, err = admin.EnsureRole(ctx, "tenant_1", "bank", RoleDBA)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
+
+func TestAdmin_GrantRole_God(t *testing.T) {
+	metaClient := mocks.NewMetaService(t)
+
+	err := NewAdmin(metaClient).GrantRole(context.Background(), "tenant_1", "bank", RoleGod)
+	assert.EqualError(t, err, "role GOD cannot be granted")
+}
+
+func TestAdmin_ListRoles(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("ListRoles", ctx, &meta.ListRolesReq{SpaceID: 1}).Return(&meta.ListRolesResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Roles: []*meta.RoleItem{
+			{UserID: []byte("tenant_2"), SpaceID: 1, RoleType: meta.RoleType_USER},
+			{UserID: []byte("tenant_1"), SpaceID: 1, RoleType: meta.RoleType_ADMIN},
+		},
+	}, nil).Once()
+
+	roles, err := NewAdmin(metaClient).ListRoles(ctx, "bank")
+	assert.NoError(t, err)
+	assert.Equal(t, []UserRole{
+		{User: "tenant_1", Space: "bank", Role: RoleAdmin},
+		{User: "tenant_2", Space: "bank", Role: RoleUser},
+	}, roles)
+}
+
+func TestAdmin_CreateUser_Existing(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("CreateUser", ctx, &meta.CreateUserReq{
+		Account:    []byte("tenant_1"),
+		EncodedPwd: EncodePassword("secret"),
+	}).Return(&meta.ExecResp{Code: nebula.ErrorCode_E_EXISTED}, nil).Once()
+
+	err := NewAdmin(metaClient).CreateUser(ctx, "tenant_1", "secret")
+	assert.EqualError(t, err, "failed to create user tenant_1, error code: E_EXISTED")
+}
@@ -0,0 +1,268 @@
+package nebula_sirius
+
+import (
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"slices"
+	"strings"
+	"time"
+)
+
+// snapshotNameLayout is the layout of the creation time in the snapshot names, e.g. SNAPSHOT_2025_02_15_14_30_00
+const snapshotNameLayout = "SNAPSHOT_2006_01_02_15_04_05"
+
+// Snapshot is a snapshot of all the spaces of the cluster.
+type Snapshot struct {
+	Name      string
+	Status    meta.SnapshotStatus
+	Hosts     []string  // storage hosts holding the snapshot, e.g. 192.168.8.100:9779
+	CreatedAt time.Time // parsed from the name in the snapshot location, zero when the name does not hold it
+}
+
+// RetentionPolicy tells which snapshots ApplyRetention keeps. A snapshot is kept when it is one of the
+// KeepLast latest valid snapshots, or when it is younger than MaxAge.
+type RetentionPolicy struct {
+	KeepLast int           // optional
+	MaxAge   time.Duration // optional
+}
+
+// BackupManifest describes a backup created by CreateBackup. The files it lists are to be copied
+// from the meta and storage hosts to the backup storage.
+type BackupManifest struct {
+	Name           string
+	BaseBackupName string // name of the backup the incremental backup is based on, if any
+	Full           bool
+	AllSpaces      bool
+	CreatedAt      time.Time
+	MetaFiles      []string
+	StorageHosts   []HostAddress
+	Spaces         []SpaceBackup    // sorted by space name
+	Meta           *meta.BackupMeta // manifest as returned by the meta service
+}
+
+// SpaceBackup lists the checkpoints of a space taken by a backup.
+type SpaceBackup struct {
+	SpaceID   nebula.GraphSpaceID
+	SpaceName string
+	Hosts     []HostBackup
+}
+
+// HostBackup lists the checkpoint data paths of a storage host.
+type HostBackup struct {
+	Host      HostAddress
+	DataPaths []string
+}
+
+// BackupClient manages the snapshots and the backups of the cluster through the meta service.
+type BackupClient struct {
+	metaClient       meta.MetaService // required
+	snapshotLocation *time.Location   // optional
+	now              func() time.Time // optional
+}
+
+// BackupClientOption is a functional option for configuring a BackupClient.
+type BackupClientOption func(*BackupClient)
+
+// NewBackupClient creates a new BackupClient sending its requests to the given meta client.
+func NewBackupClient(metaClient meta.MetaService, options ...BackupClientOption) *BackupClient {
+	client := &BackupClient{
+		metaClient:       metaClient,
+		snapshotLocation: time.UTC,
+		now:              time.Now,
+	}
+
+	for _, opt := range options {
+		opt(client)
+	}
+
+	return client
+}
+
+// WithSnapshotLocation sets the timezone of the meta service, whose local time the snapshot names hold,
+// UTC by default. It must match the timezone of metad for the creation times of the snapshots, and thus
+// the retention policies, to be right.
+func WithSnapshotLocation(loc *time.Location) func(*BackupClient) {
+	return func(c *BackupClient) {
+		c.snapshotLocation = loc
+	}
+}
+
+// CreateSnapshot creates a snapshot of all the spaces and returns it.
+// The meta service does not tell the name of the snapshot it creates, so the snapshot is found as the one
+// listed after the creation but not before. CreateSnapshot must therefore not run concurrently with another
+// creation of a snapshot of the cluster, and fails rather than guessing if several snapshots appeared.
+func (c *BackupClient) CreateSnapshot(ctx context.Context) (*Snapshot, error) {
+	before, err := c.ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.metaClient.CreateSnapshot(ctx, &meta.CreateSnapshotReq{})
+	if err := checkExecResp(resp, err, "create snapshot"); err != nil {
+		return nil, err
+	}
+
+	after, err := c.ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var created []Snapshot
+	for _, snapshot := range after {
+		if !slices.ContainsFunc(before, func(s Snapshot) bool { return s.Name == snapshot.Name }) {
+			created = append(created, snapshot)
+		}
+	}
+	switch len(created) {
+	case 0:
+		return nil, fmt.Errorf("created snapshot not found")
+	case 1:
+		return &created[0], nil
+	default:
+		names := make([]string, 0, len(created))
+		for _, snapshot := range created {
+			names = append(names, snapshot.Name)
+		}
+		return nil, fmt.Errorf("created snapshot is ambiguous, snapshots %s were created concurrently", strings.Join(names, ", "))
+	}
+}
+
+// ListSnapshots returns the snapshots, the oldest first.
+func (c *BackupClient) ListSnapshots(ctx context.Context) ([]Snapshot, error) {
+	resp, err := c.metaClient.ListSnapshots(ctx, &meta.ListSnapshotsReq{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list snapshots, error code: %s", resp.GetCode())
+	}
+
+	snapshots := make([]Snapshot, 0, len(resp.GetSnapshots()))
+	for _, item := range resp.GetSnapshots() {
+		snapshot := Snapshot{
+			Name:   string(item.GetName()),
+			Status: item.GetStatus(),
+		}
+		if hosts := strings.TrimSpace(string(item.GetHosts())); hosts != "" {
+			snapshot.Hosts = strings.Split(hosts, ",")
+		}
+		// The meta service names the snapshots after its local time
+		if createdAt, err := time.ParseInLocation(snapshotNameLayout, snapshot.Name, c.snapshotLocation); err == nil {
+			snapshot.CreatedAt = createdAt
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	slices.SortStableFunc(snapshots, func(a, b Snapshot) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// DropSnapshots drops the given snapshots.
+func (c *BackupClient) DropSnapshots(ctx context.Context, names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	req := &meta.DropSnapshotReq{}
+	for _, name := range names {
+		req.Names = append(req.Names, []byte(name))
+	}
+
+	resp, err := c.metaClient.DropSnapshot(ctx, req)
+	return checkExecResp(resp, err, fmt.Sprintf("drop snapshots %s", strings.Join(names, ", ")))
+}
+
+// ApplyRetention drops the snapshots the policy does not keep, and returns their names.
+// Invalid snapshots are always dropped, snapshots whose creation time is unknown are always kept.
+func (c *BackupClient) ApplyRetention(ctx context.Context, policy RetentionPolicy) ([]string, error) {
+	if policy.KeepLast <= 0 && policy.MaxAge <= 0 {
+		return nil, fmt.Errorf("retention policy must keep the last snapshots or the ones younger than a max age")
+	}
+
+	snapshots, err := c.ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.now()
+	kept := 0
+	dropped := make([]string, 0)
+	// Newest first, to keep the last ones
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		snapshot := snapshots[i]
+		switch {
+		case snapshot.Status != meta.SnapshotStatus_VALID:
+			dropped = append(dropped, snapshot.Name)
+		case snapshot.CreatedAt.IsZero():
+		case kept < policy.KeepLast:
+			kept++
+		case policy.MaxAge > 0 && now.Sub(snapshot.CreatedAt) < policy.MaxAge:
+		default:
+			dropped = append(dropped, snapshot.Name)
+		}
+	}
+
+	if err := c.DropSnapshots(ctx, dropped...); err != nil {
+		return nil, err
+	}
+	return dropped, nil
+}
+
+// CreateBackup creates a full backup of the given spaces, or of all the spaces when none is given,
+// and returns its manifest.
+func (c *BackupClient) CreateBackup(ctx context.Context, spaceNames ...string) (*BackupManifest, error) {
+	req := &meta.CreateBackupReq{}
+	for _, spaceName := range spaceNames {
+		req.Spaces = append(req.Spaces, []byte(spaceName))
+	}
+
+	resp, err := c.metaClient.CreateBackup(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to create backup, error code: %s", resp.GetCode())
+	}
+
+	return backupManifest(resp.GetMeta()), nil
+}
+
+func backupManifest(backupMeta *meta.BackupMeta) *BackupManifest {
+	manifest := &BackupManifest{
+		Name:           string(backupMeta.GetBackupName()),
+		BaseBackupName: string(backupMeta.GetBaseBackupName()),
+		Full:           backupMeta.GetFull(),
+		AllSpaces:      backupMeta.GetAllSpaces(),
+		CreatedAt:      time.UnixMilli(backupMeta.GetCreateTime()),
+		Meta:           backupMeta,
+	}
+	for _, file := range backupMeta.GetMetaFiles() {
+		manifest.MetaFiles = append(manifest.MetaFiles, string(file))
+	}
+	for _, host := range backupMeta.GetStorageHosts() {
+		manifest.StorageHosts = append(manifest.StorageHosts, hostAddress(host))
+	}
+
+	for spaceID, info := range backupMeta.GetSpaceBackups() {
+		spaceBackup := SpaceBackup{
+			SpaceID:   spaceID,
+			SpaceName: string(info.GetSpace().GetSpaceName()),
+		}
+		for _, hostInfo := range info.GetHostBackups() {
+			hostBackup := HostBackup{Host: hostAddress(hostInfo.GetHost())}
+			for _, checkpoint := range hostInfo.GetCheckpoints() {
+				hostBackup.DataPaths = append(hostBackup.DataPaths, string(checkpoint.GetDataPath()))
+			}
+			spaceBackup.Hosts = append(spaceBackup.Hosts, hostBackup)
+		}
+		manifest.Spaces = append(manifest.Spaces, spaceBackup)
+	}
+	slices.SortFunc(manifest.Spaces, func(a, b SpaceBackup) int {
+		return strings.Compare(a.SpaceName, b.SpaceName)
+	})
+
+	return manifest
+}
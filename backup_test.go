@@ -0,0 +1,259 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func listSnapshotsResp(snapshots ...*meta.Snapshot) *meta.ListSnapshotsResp {
+	return &meta.ListSnapshotsResp{
+		Code:      nebula.ErrorCode_SUCCEEDED,
+		Snapshots: snapshots,
+	}
+}
+
+func snapshot(name string, status meta.SnapshotStatus) *meta.Snapshot {
+	return &meta.Snapshot{
+		Name:   []byte(name),
+		Status: status,
+		Hosts:  []byte("192.168.8.100:9779,192.168.8.101:9779"),
+	}
+}
+
+func TestBackupClient_ListSnapshots(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListSnapshots", ctx, &meta.ListSnapshotsReq{}).Return(listSnapshotsResp(
+		snapshot("SNAPSHOT_2025_02_15_14_30_00", meta.SnapshotStatus_INVALID),
+		snapshot("SNAPSHOT_2025_02_14_14_30_00", meta.SnapshotStatus_VALID),
+	), nil).Once()
+
+	snapshots, err := NewBackupClient(metaClient).ListSnapshots(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Snapshot{
+		{
+			Name:      "SNAPSHOT_2025_02_14_14_30_00",
+			Status:    meta.SnapshotStatus_VALID,
+			Hosts:     []string{"192.168.8.100:9779", "192.168.8.101:9779"},
+			CreatedAt: time.Date(2025, 2, 14, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			Name:      "SNAPSHOT_2025_02_15_14_30_00",
+			Status:    meta.SnapshotStatus_INVALID,
+			Hosts:     []string{"192.168.8.100:9779", "192.168.8.101:9779"},
+			CreatedAt: time.Date(2025, 2, 15, 14, 30, 0, 0, time.UTC),
+		},
+	}, snapshots)
+}
+
+func TestBackupClient_CreateSnapshot(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListSnapshots", ctx, &meta.ListSnapshotsReq{}).Return(listSnapshotsResp(
+		snapshot("SNAPSHOT_2025_02_14_14_30_00", meta.SnapshotStatus_VALID),
+	), nil).Once()
+	metaClient.On("CreateSnapshot", ctx, &meta.CreateSnapshotReq{}).Return(succeededExecResp(), nil).Once()
+	metaClient.On("ListSnapshots", ctx, &meta.ListSnapshotsReq{}).Return(listSnapshotsResp(
+		snapshot("SNAPSHOT_2025_02_14_14_30_00", meta.SnapshotStatus_VALID),
+		snapshot("SNAPSHOT_2025_02_15_14_30_00", meta.SnapshotStatus_VALID),
+	), nil).Once()
+
+	created, err := NewBackupClient(metaClient).CreateSnapshot(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "SNAPSHOT_2025_02_15_14_30_00", created.Name)
+	assert.Equal(t, meta.SnapshotStatus_VALID, created.Status)
+}
+
+func TestBackupClient_CreateSnapshot_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListSnapshots", ctx, &meta.ListSnapshotsReq{}).Return(listSnapshotsResp(), nil).Once()
+	metaClient.On("CreateSnapshot", ctx, &meta.CreateSnapshotReq{}).Return(succeededExecResp(), nil).Once()
+	metaClient.On("ListSnapshots", ctx, &meta.ListSnapshotsReq{}).Return(listSnapshotsResp(
+		snapshot("SNAPSHOT_2025_02_15_14_30_00", meta.SnapshotStatus_VALID),
+		snapshot("SNAPSHOT_2025_02_15_14_30_01", meta.SnapshotStatus_VALID),
+	), nil).Once()
+
+	_, err := NewBackupClient(metaClient).CreateSnapshot(ctx)
+	assert.EqualError(t, err, "created snapshot is ambiguous, snapshots SNAPSHOT_2025_02_15_14_30_00, SNAPSHOT_2025_02_15_14_30_01 were created concurrently")
+}
+
+func TestBackupClient_CreateSnapshot_Failed(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListSnapshots", ctx, &meta.ListSnapshotsReq{}).Return(listSnapshotsResp(), nil).Once()
+	metaClient.On("CreateSnapshot", ctx, &meta.CreateSnapshotReq{}).Return(&meta.ExecResp{
+		Code: nebula.ErrorCode_E_SNAPSHOT_FAILURE,
+	}, nil).Once()
+
+	_, err := NewBackupClient(metaClient).CreateSnapshot(ctx)
+	assert.ErrorContains(t, err, "E_SNAPSHOT_FAILURE")
+}
+
+func TestBackupClient_ApplyRetention(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 2, 20, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		policy  RetentionPolicy
+		dropped []string
+	}{
+		{
+			name:    "keep last",
+			policy:  RetentionPolicy{KeepLast: 2},
+			dropped: []string{"SNAPSHOT_2025_02_19_12_00_00", "SNAPSHOT_2025_02_10_12_00_00"},
+		},
+		{
+			name:    "max age",
+			policy:  RetentionPolicy{MaxAge: 7 * 24 * time.Hour},
+			dropped: []string{"SNAPSHOT_2025_02_19_12_00_00", "SNAPSHOT_2025_02_10_12_00_00"},
+		},
+		{
+			name:    "keep last or max age",
+			policy:  RetentionPolicy{KeepLast: 1, MaxAge: 24 * time.Hour},
+			dropped: []string{"SNAPSHOT_2025_02_19_12_00_00", "SNAPSHOT_2025_02_18_12_00_00", "SNAPSHOT_2025_02_10_12_00_00"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metaClient := mocks.NewMetaService(t)
+			metaClient.On("ListSnapshots", ctx, &meta.ListSnapshotsReq{}).Return(listSnapshotsResp(
+				snapshot("SNAPSHOT_2025_02_10_12_00_00", meta.SnapshotStatus_VALID),
+				snapshot("SNAPSHOT_2025_02_18_12_00_00", meta.SnapshotStatus_VALID),
+				snapshot("SNAPSHOT_2025_02_19_12_00_00", meta.SnapshotStatus_INVALID),
+				snapshot("SNAPSHOT_2025_02_20_11_00_00", meta.SnapshotStatus_VALID),
+				snapshot("manual", meta.SnapshotStatus_VALID),
+			), nil).Once()
+
+			names := make([][]byte, 0, len(tc.dropped))
+			for _, name := range tc.dropped {
+				names = append(names, []byte(name))
+			}
+			metaClient.On("DropSnapshot", ctx, &meta.DropSnapshotReq{Names: names}).Return(succeededExecResp(), nil).Once()
+
+			client := NewBackupClient(metaClient)
+			client.now = func() time.Time { return now }
+			dropped, err := client.ApplyRetention(ctx, tc.policy)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.dropped, dropped)
+		})
+	}
+}
+
+func TestBackupClient_ApplyRetention_SnapshotLocation(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	// metad runs 8 hours behind UTC, its 03:30 snapshot is half an hour old at 12:00 UTC
+	metaClient.On("ListSnapshots", ctx, &meta.ListSnapshotsReq{}).Return(listSnapshotsResp(
+		snapshot("SNAPSHOT_2025_02_20_03_00_00", meta.SnapshotStatus_VALID),
+		snapshot("SNAPSHOT_2025_02_20_03_30_00", meta.SnapshotStatus_VALID),
+	), nil).Once()
+	metaClient.On("DropSnapshot", ctx, &meta.DropSnapshotReq{Names: [][]byte{[]byte("SNAPSHOT_2025_02_20_03_00_00")}}).
+		Return(succeededExecResp(), nil).Once()
+
+	client := NewBackupClient(metaClient, WithSnapshotLocation(time.FixedZone("UTC-8", -8*3600)))
+	client.now = func() time.Time { return time.Date(2025, 2, 20, 12, 0, 0, 0, time.UTC) }
+	dropped, err := client.ApplyRetention(ctx, RetentionPolicy{MaxAge: 45 * time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SNAPSHOT_2025_02_20_03_00_00"}, dropped)
+}
+
+func TestBackupClient_ApplyRetention_EmptyPolicy(t *testing.T) {
+	metaClient := mocks.NewMetaService(t)
+
+	_, err := NewBackupClient(metaClient).ApplyRetention(context.Background(), RetentionPolicy{})
+	assert.ErrorContains(t, err, "retention policy must keep")
+}
+
+func TestBackupClient_CreateBackup(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	storageHost := &nebula.HostAddr{Host: "192.168.8.100", Port: 9779}
+	metaClient.On("CreateBackup", ctx, &meta.CreateBackupReq{
+		Spaces: [][]byte{[]byte("basketballplayer"), []byte("tenant_1")},
+	}).Return(&meta.CreateBackupResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Meta: &meta.BackupMeta{
+			SpaceBackups: map[nebula.GraphSpaceID]*meta.SpaceBackupInfo{
+				2: {
+					Space: &meta.SpaceDesc{SpaceName: []byte("tenant_1")},
+					HostBackups: []*meta.HostBackupInfo{{
+						Host: storageHost,
+						Checkpoints: []*nebula.CheckpointInfo{{
+							SpaceID:  2,
+							DataPath: []byte("/data/storage/nebula/2/checkpoints/BACKUP_2025_02_15_14_30_00"),
+						}},
+					}},
+				},
+				1: {
+					Space: &meta.SpaceDesc{SpaceName: []byte("basketballplayer")},
+					HostBackups: []*meta.HostBackupInfo{{
+						Host: storageHost,
+						Checkpoints: []*nebula.CheckpointInfo{{
+							SpaceID:  1,
+							DataPath: []byte("/data/storage/nebula/1/checkpoints/BACKUP_2025_02_15_14_30_00"),
+						}},
+					}},
+				},
+			},
+			MetaFiles:    [][]byte{[]byte("__edges__.sst"), []byte("__tags__.sst")},
+			BackupName:   []byte("BACKUP_2025_02_15_14_30_00"),
+			Full:         true,
+			CreateTime:   1739629800000,
+			StorageHosts: []*nebula.HostAddr{storageHost},
+		},
+	}, nil).Once()
+
+	manifest, err := NewBackupClient(metaClient).CreateBackup(ctx, "basketballplayer", "tenant_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "BACKUP_2025_02_15_14_30_00", manifest.Name)
+	assert.True(t, manifest.Full)
+	assert.False(t, manifest.AllSpaces)
+	assert.Equal(t, time.UnixMilli(1739629800000), manifest.CreatedAt)
+	assert.Equal(t, []string{"__edges__.sst", "__tags__.sst"}, manifest.MetaFiles)
+	assert.Equal(t, []HostAddress{{Host: "192.168.8.100", Port: 9779}}, manifest.StorageHosts)
+	assert.Equal(t, []SpaceBackup{
+		{
+			SpaceID:   1,
+			SpaceName: "basketballplayer",
+			Hosts: []HostBackup{{
+				Host:      HostAddress{Host: "192.168.8.100", Port: 9779},
+				DataPaths: []string{"/data/storage/nebula/1/checkpoints/BACKUP_2025_02_15_14_30_00"},
+			}},
+		},
+		{
+			SpaceID:   2,
+			SpaceName: "tenant_1",
+			Hosts: []HostBackup{{
+				Host:      HostAddress{Host: "192.168.8.100", Port: 9779},
+				DataPaths: []string{"/data/storage/nebula/2/checkpoints/BACKUP_2025_02_15_14_30_00"},
+			}},
+		},
+	}, manifest.Spaces)
+}
+
+func TestBackupClient_CreateBackup_Failed(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("CreateBackup", ctx, &meta.CreateBackupReq{}).Return(&meta.CreateBackupResp{
+		Code: nebula.ErrorCode_E_BACKUP_FAILED,
+	}, nil).Once()
+
+	_, err := NewBackupClient(metaClient).CreateBackup(ctx)
+	assert.ErrorContains(t, err, "failed to create backup, error code: E_BACKUP_FAILED")
+}
@@ -0,0 +1,364 @@
+package nebula_sirius
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"slices"
+	"strings"
+)
+
+// Host is a host of the cluster as listed by SHOW HOSTS.
+type Host struct {
+	Address     HostAddress
+	Role        meta.HostRole
+	Status      meta.HostStatus
+	GitInfoSha  string
+	Version     string             // optional, empty for old servers
+	Zone        string             // optional, only set for the storage hosts
+	LeaderParts map[string][]int32 // IDs of the partitions the host leads, by space
+	AllParts    map[string][]int32 // IDs of the partitions the host holds, by space
+}
+
+// LeaderCount returns the number of partitions the host leads in all the spaces.
+func (h *Host) LeaderCount() int {
+	count := 0
+	for _, parts := range h.LeaderParts {
+		count += len(parts)
+	}
+	return count
+}
+
+// PartCount returns the number of partitions the host holds in all the spaces.
+func (h *Host) PartCount() int {
+	count := 0
+	for _, parts := range h.AllParts {
+		count += len(parts)
+	}
+	return count
+}
+
+// Zone is a group of storage hosts the replicas of a partition are spread over.
+type Zone struct {
+	Name  string
+	Hosts []HostAddress
+}
+
+// ClusterService is a service of the cluster as listed by SHOW CLUSTER, along with its directories.
+type ClusterService struct {
+	Address  HostAddress
+	Role     meta.HostRole
+	RootDir  string
+	DataDirs []string
+}
+
+// ClusterHealth summarizes the state of the storage hosts of the cluster.
+type ClusterHealth struct {
+	StorageHosts        []Host
+	OfflineStorageHosts []HostAddress     // storage hosts not ONLINE
+	UnbalancedLeaders   []LeaderImbalance // spaces whose leaders are unbalanced, sorted by space
+}
+
+// LeaderImbalance is the leader distribution of a space whose leaders are unbalanced between
+// the online storage hosts holding its partitions.
+type LeaderImbalance struct {
+	Space   string
+	Leaders map[HostAddress]int // number of leaders by storage host
+	Min     int
+	Max     int
+}
+
+// IsHealthy returns whether all the storage hosts are online and the leaders are balanced.
+func (h *ClusterHealth) IsHealthy() bool {
+	return len(h.OfflineStorageHosts) == 0 && len(h.UnbalancedLeaders) == 0
+}
+
+// ClusterHealthOptions configures how ClusterClient.Health judges the cluster.
+type ClusterHealthOptions struct {
+	leaderTolerance int // optional
+}
+
+// ClusterHealthOption is a functional option for configuring ClusterHealthOptions.
+type ClusterHealthOption func(*ClusterHealthOptions)
+
+// WithLeaderTolerance sets the difference between the most and the least leaders of a space on
+// its storage hosts above which the leaders are unbalanced, 1 by default.
+func WithLeaderTolerance(tolerance int) func(*ClusterHealthOptions) {
+	return func(opts *ClusterHealthOptions) {
+		opts.leaderTolerance = tolerance
+	}
+}
+
+// ClusterClient administrates the hosts and zones of the cluster through the meta service.
+type ClusterClient struct {
+	metaClient meta.MetaService // required
+}
+
+// NewClusterClient creates a new ClusterClient sending its requests to the given meta client.
+func NewClusterClient(metaClient meta.MetaService) *ClusterClient {
+	return &ClusterClient{
+		metaClient: metaClient,
+	}
+}
+
+// ListHosts returns the hosts of the given type, sorted by address, e.g. meta.ListHostType_STORAGE.
+func (c *ClusterClient) ListHosts(ctx context.Context, hostType meta.ListHostType) ([]Host, error) {
+	resp, err := c.metaClient.ListHosts(ctx, &meta.ListHostsReq{Type: hostType})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list %s hosts, error code: %s", hostType, resp.GetCode())
+	}
+
+	hosts := make([]Host, 0, len(resp.GetHosts()))
+	for _, item := range resp.GetHosts() {
+		hosts = append(hosts, Host{
+			Address:     hostAddress(item.GetHostAddr()),
+			Role:        item.GetRole(),
+			Status:      item.GetStatus(),
+			GitInfoSha:  string(item.GetGitInfoSha()),
+			Version:     string(item.GetVersion()),
+			Zone:        string(item.GetZoneName()),
+			LeaderParts: spaceParts(item.GetLeaderParts()),
+			AllParts:    spaceParts(item.GetAllParts()),
+		})
+	}
+	slices.SortFunc(hosts, func(a, b Host) int {
+		return compareHostAddress(a.Address, b.Address)
+	})
+	return hosts, nil
+}
+
+// AddHosts adds the storage hosts to the cluster, each one into a new zone.
+func (c *ClusterClient) AddHosts(ctx context.Context, hosts ...HostAddress) error {
+	if len(hosts) == 0 {
+		return fmt.Errorf("at least one host is required")
+	}
+
+	resp, err := c.metaClient.AddHosts(ctx, &meta.AddHostsReq{Hosts: hostAddrs(hosts)})
+	return checkExecResp(resp, err, fmt.Sprintf("add hosts %s", joinHostAddresses(hosts)))
+}
+
+// DropHosts drops the storage hosts from the cluster. They must not hold any partition.
+func (c *ClusterClient) DropHosts(ctx context.Context, hosts ...HostAddress) error {
+	if len(hosts) == 0 {
+		return fmt.Errorf("at least one host is required")
+	}
+
+	resp, err := c.metaClient.DropHosts(ctx, &meta.DropHostsReq{Hosts: hostAddrs(hosts)})
+	return checkExecResp(resp, err, fmt.Sprintf("drop hosts %s", joinHostAddresses(hosts)))
+}
+
+// AddHostsIntoZone adds the storage hosts to the cluster into the given zone.
+// The zone is created when isNew is true, and must exist otherwise.
+func (c *ClusterClient) AddHostsIntoZone(ctx context.Context, zoneName string, isNew bool, hosts ...HostAddress) error {
+	if zoneName == "" {
+		return fmt.Errorf("zone name cannot be empty")
+	}
+	if len(hosts) == 0 {
+		return fmt.Errorf("at least one host is required")
+	}
+
+	resp, err := c.metaClient.AddHostsIntoZone(ctx, &meta.AddHostsIntoZoneReq{
+		Hosts:    hostAddrs(hosts),
+		ZoneName: []byte(zoneName),
+		IsNew:    isNew,
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("add hosts %s into zone %s", joinHostAddresses(hosts), zoneName))
+}
+
+// ListZones returns the zones along with their hosts, sorted by name.
+func (c *ClusterClient) ListZones(ctx context.Context) ([]Zone, error) {
+	resp, err := c.metaClient.ListZones(ctx, &meta.ListZonesReq{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list zones, error code: %s", resp.GetCode())
+	}
+
+	zones := make([]Zone, 0, len(resp.GetZones()))
+	for _, item := range resp.GetZones() {
+		zone := Zone{Name: string(item.GetZoneName())}
+		for _, node := range item.GetNodes() {
+			zone.Hosts = append(zone.Hosts, hostAddress(node))
+		}
+		zones = append(zones, zone)
+	}
+	slices.SortFunc(zones, func(a, b Zone) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return zones, nil
+}
+
+// MergeZone merges the given zones into the zone with the given name, which may be one of them.
+func (c *ClusterClient) MergeZone(ctx context.Context, zoneName string, zones ...string) error {
+	if zoneName == "" {
+		return fmt.Errorf("zone name cannot be empty")
+	}
+	if len(zones) < 2 {
+		return fmt.Errorf("at least two zones are required to be merged")
+	}
+
+	req := &meta.MergeZoneReq{ZoneName: []byte(zoneName)}
+	for _, zone := range zones {
+		req.Zones = append(req.Zones, []byte(zone))
+	}
+
+	resp, err := c.metaClient.MergeZone(ctx, req)
+	return checkExecResp(resp, err, fmt.Sprintf("merge zones %s into %s", strings.Join(zones, ", "), zoneName))
+}
+
+// DivideZone divides the zone into the given zones, by name. All the hosts of the zone
+// must be spread over the new zones.
+func (c *ClusterClient) DivideZone(ctx context.Context, zoneName string, zones map[string][]HostAddress) error {
+	if zoneName == "" {
+		return fmt.Errorf("zone name cannot be empty")
+	}
+	if len(zones) < 2 {
+		return fmt.Errorf("zone %s must be divided into at least two zones", zoneName)
+	}
+
+	zoneItems := make(map[string][]*nebula.HostAddr, len(zones))
+	for name, hosts := range zones {
+		if len(hosts) == 0 {
+			return fmt.Errorf("zone %s must have at least one host", name)
+		}
+		zoneItems[name] = hostAddrs(hosts)
+	}
+
+	resp, err := c.metaClient.DivideZone(ctx, &meta.DivideZoneReq{
+		ZoneName:  []byte(zoneName),
+		ZoneItems: zoneItems,
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("divide zone %s", zoneName))
+}
+
+// RenameZone renames the zone.
+func (c *ClusterClient) RenameZone(ctx context.Context, zoneName string, newZoneName string) error {
+	if zoneName == "" || newZoneName == "" {
+		return fmt.Errorf("zone name cannot be empty")
+	}
+
+	resp, err := c.metaClient.RenameZone(ctx, &meta.RenameZoneReq{
+		OriginalZoneName: []byte(zoneName),
+		ZoneName:         []byte(newZoneName),
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("rename zone %s to %s", zoneName, newZoneName))
+}
+
+// ListCluster returns the services of the cluster, sorted by address and role.
+func (c *ClusterClient) ListCluster(ctx context.Context) ([]ClusterService, error) {
+	resp, err := c.metaClient.ListCluster(ctx, &meta.ListClusterInfoReq{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list cluster, error code: %s", resp.GetCode())
+	}
+
+	services := make([]ClusterService, 0)
+	for _, hostServices := range resp.GetHostServices() {
+		for _, item := range hostServices {
+			service := ClusterService{
+				Address: hostAddress(item.GetAddr()),
+				Role:    item.GetRole(),
+				RootDir: string(item.GetDir().GetRoot()),
+			}
+			for _, dir := range item.GetDir().GetData() {
+				service.DataDirs = append(service.DataDirs, string(dir))
+			}
+			services = append(services, service)
+		}
+	}
+	slices.SortFunc(services, func(a, b ClusterService) int {
+		if c := compareHostAddress(a.Address, b.Address); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Role, b.Role)
+	})
+	return services, nil
+}
+
+// Health lists the storage hosts and flags the offline ones, and the spaces whose
+// leaders are unbalanced between the online storage hosts holding their partitions.
+func (c *ClusterClient) Health(ctx context.Context, options ...ClusterHealthOption) (*ClusterHealth, error) {
+	opts := ClusterHealthOptions{
+		leaderTolerance: 1,
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	hosts, err := c.ListHosts(ctx, meta.ListHostType_STORAGE)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &ClusterHealth{StorageHosts: hosts}
+	leaders := make(map[string]map[HostAddress]int)
+	for _, host := range hosts {
+		if host.Status != meta.HostStatus_ONLINE {
+			health.OfflineStorageHosts = append(health.OfflineStorageHosts, host.Address)
+			continue
+		}
+		for space := range host.AllParts {
+			if leaders[space] == nil {
+				leaders[space] = make(map[HostAddress]int)
+			}
+			leaders[space][host.Address] = len(host.LeaderParts[space])
+		}
+	}
+
+	for space, spaceLeaders := range leaders {
+		imbalance := LeaderImbalance{
+			Space:   space,
+			Leaders: spaceLeaders,
+			Min:     -1,
+		}
+		for _, count := range spaceLeaders {
+			if imbalance.Min < 0 || count < imbalance.Min {
+				imbalance.Min = count
+			}
+			imbalance.Max = max(imbalance.Max, count)
+		}
+		if imbalance.Max-imbalance.Min > opts.leaderTolerance {
+			health.UnbalancedLeaders = append(health.UnbalancedLeaders, imbalance)
+		}
+	}
+	slices.SortFunc(health.UnbalancedLeaders, func(a, b LeaderImbalance) int {
+		return strings.Compare(a.Space, b.Space)
+	})
+	return health, nil
+}
+
+func spaceParts(parts map[string][]nebula.PartitionID) map[string][]int32 {
+	spaces := make(map[string][]int32, len(parts))
+	for space, partIDs := range parts {
+		ids := make([]int32, 0, len(partIDs))
+		for _, partID := range partIDs {
+			ids = append(ids, int32(partID))
+		}
+		slices.Sort(ids)
+		spaces[space] = ids
+	}
+	return spaces
+}
+
+func joinHostAddresses(addresses []HostAddress) string {
+	hosts := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		hosts = append(hosts, fmt.Sprintf("%s:%d", address.Host, address.Port))
+	}
+	return strings.Join(hosts, ", ")
+}
+
+func compareHostAddress(a, b HostAddress) int {
+	if c := strings.Compare(a.Host, b.Host); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Port, b.Port)
+}
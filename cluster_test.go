@@ -0,0 +1,210 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func storageHostItem(host string, status meta.HostStatus, leaderParts, allParts map[string][]nebula.PartitionID) *meta.HostItem {
+	return &meta.HostItem{
+		HostAddr:    &nebula.HostAddr{Host: host, Port: 9779},
+		Status:      status,
+		LeaderParts: leaderParts,
+		AllParts:    allParts,
+		Role:        meta.HostRole_STORAGE,
+		GitInfoSha:  []byte("5ef6a1d"),
+		ZoneName:    []byte("default_zone_" + host),
+		Version:     []byte("3.8.0"),
+	}
+}
+
+func TestClusterClient_ListHosts(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListHosts", ctx, &meta.ListHostsReq{Type: meta.ListHostType_STORAGE}).Return(&meta.ListHostsResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Hosts: []*meta.HostItem{
+			storageHostItem("storaged1", meta.HostStatus_OFFLINE, nil, map[string][]nebula.PartitionID{"basketballplayer": {2}}),
+			storageHostItem("storaged0", meta.HostStatus_ONLINE,
+				map[string][]nebula.PartitionID{"basketballplayer": {3, 1}},
+				map[string][]nebula.PartitionID{"basketballplayer": {3, 1, 2}}),
+		},
+	}, nil).Once()
+
+	hosts, err := NewClusterClient(metaClient).ListHosts(ctx, meta.ListHostType_STORAGE)
+	assert.NoError(t, err)
+	assert.Equal(t, []Host{
+		{
+			Address:     HostAddress{Host: "storaged0", Port: 9779},
+			Role:        meta.HostRole_STORAGE,
+			Status:      meta.HostStatus_ONLINE,
+			GitInfoSha:  "5ef6a1d",
+			Version:     "3.8.0",
+			Zone:        "default_zone_storaged0",
+			LeaderParts: map[string][]int32{"basketballplayer": {1, 3}},
+			AllParts:    map[string][]int32{"basketballplayer": {1, 2, 3}},
+		},
+		{
+			Address:     HostAddress{Host: "storaged1", Port: 9779},
+			Role:        meta.HostRole_STORAGE,
+			Status:      meta.HostStatus_OFFLINE,
+			GitInfoSha:  "5ef6a1d",
+			Version:     "3.8.0",
+			Zone:        "default_zone_storaged1",
+			LeaderParts: map[string][]int32{},
+			AllParts:    map[string][]int32{"basketballplayer": {2}},
+		},
+	}, hosts)
+	assert.Equal(t, 2, hosts[0].LeaderCount())
+	assert.Equal(t, 3, hosts[0].PartCount())
+}
+
+func TestClusterClient_AddHostsIntoZone(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("AddHostsIntoZone", ctx, &meta.AddHostsIntoZoneReq{
+		Hosts:    []*nebula.HostAddr{{Host: "storaged3", Port: 9779}},
+		ZoneName: []byte("zone_1"),
+		IsNew:    true,
+	}).Return(&meta.ExecResp{Code: nebula.ErrorCode_E_EXISTED}, nil).Once()
+
+	err := NewClusterClient(metaClient).AddHostsIntoZone(ctx, "zone_1", true, HostAddress{Host: "storaged3", Port: 9779})
+	assert.ErrorContains(t, err, "add hosts storaged3:9779 into zone zone_1")
+	assert.ErrorContains(t, err, "E_EXISTED")
+}
+
+func TestClusterClient_ListZones(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListZones", ctx, &meta.ListZonesReq{}).Return(&meta.ListZonesResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Zones: []*meta.Zone{
+			{ZoneName: []byte("zone_2"), Nodes: []*nebula.HostAddr{{Host: "storaged2", Port: 9779}}},
+			{ZoneName: []byte("zone_1"), Nodes: []*nebula.HostAddr{{Host: "storaged0", Port: 9779}, {Host: "storaged1", Port: 9779}}},
+		},
+	}, nil).Once()
+
+	zones, err := NewClusterClient(metaClient).ListZones(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Zone{
+		{Name: "zone_1", Hosts: []HostAddress{{Host: "storaged0", Port: 9779}, {Host: "storaged1", Port: 9779}}},
+		{Name: "zone_2", Hosts: []HostAddress{{Host: "storaged2", Port: 9779}}},
+	}, zones)
+}
+
+func TestClusterClient_MergeZone(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("MergeZone", ctx, &meta.MergeZoneReq{
+		Zones:    [][]byte{[]byte("zone_1"), []byte("zone_2")},
+		ZoneName: []byte("zone_1"),
+	}).Return(succeededExecResp(), nil).Once()
+
+	assert.NoError(t, NewClusterClient(metaClient).MergeZone(ctx, "zone_1", "zone_1", "zone_2"))
+	assert.ErrorContains(t, NewClusterClient(metaClient).MergeZone(ctx, "zone_1", "zone_2"), "at least two zones")
+}
+
+func TestClusterClient_DivideZone(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("DivideZone", ctx, &meta.DivideZoneReq{
+		ZoneName: []byte("zone_1"),
+		ZoneItems: map[string][]*nebula.HostAddr{
+			"zone_1a": {{Host: "storaged0", Port: 9779}},
+			"zone_1b": {{Host: "storaged1", Port: 9779}},
+		},
+	}).Return(succeededExecResp(), nil).Once()
+
+	err := NewClusterClient(metaClient).DivideZone(ctx, "zone_1", map[string][]HostAddress{
+		"zone_1a": {{Host: "storaged0", Port: 9779}},
+		"zone_1b": {{Host: "storaged1", Port: 9779}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestClusterClient_ListCluster(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListCluster", ctx, &meta.ListClusterInfoReq{}).Return(&meta.ListClusterInfoResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		HostServices: map[string][]*meta.ServiceInfo{
+			"192.168.8.100": {
+				{
+					Dir:  &nebula.DirInfo{Root: []byte("/usr/local/nebula"), Data: [][]byte{[]byte("/data/storage")}},
+					Addr: &nebula.HostAddr{Host: "192.168.8.100", Port: 9779},
+					Role: meta.HostRole_STORAGE,
+				},
+				{
+					Dir:  &nebula.DirInfo{Root: []byte("/usr/local/nebula")},
+					Addr: &nebula.HostAddr{Host: "192.168.8.100", Port: 9669},
+					Role: meta.HostRole_GRAPH,
+				},
+			},
+		},
+	}, nil).Once()
+
+	services, err := NewClusterClient(metaClient).ListCluster(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []ClusterService{
+		{
+			Address: HostAddress{Host: "192.168.8.100", Port: 9669},
+			Role:    meta.HostRole_GRAPH,
+			RootDir: "/usr/local/nebula",
+		},
+		{
+			Address:  HostAddress{Host: "192.168.8.100", Port: 9779},
+			Role:     meta.HostRole_STORAGE,
+			RootDir:  "/usr/local/nebula",
+			DataDirs: []string{"/data/storage"},
+		},
+	}, services)
+}
+
+func TestClusterClient_Health(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListHosts", ctx, &meta.ListHostsReq{Type: meta.ListHostType_STORAGE}).Return(&meta.ListHostsResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Hosts: []*meta.HostItem{
+			storageHostItem("storaged0", meta.HostStatus_ONLINE,
+				map[string][]nebula.PartitionID{"basketballplayer": {1, 2, 3, 4}, "tenant_1": {1}},
+				map[string][]nebula.PartitionID{"basketballplayer": {1, 2, 3, 4}, "tenant_1": {1, 2}}),
+			storageHostItem("storaged1", meta.HostStatus_ONLINE,
+				map[string][]nebula.PartitionID{"tenant_1": {2}},
+				map[string][]nebula.PartitionID{"basketballplayer": {1, 2, 3, 4}, "tenant_1": {1, 2}}),
+			storageHostItem("storaged2", meta.HostStatus_OFFLINE, nil,
+				map[string][]nebula.PartitionID{"basketballplayer": {1, 2, 3, 4}}),
+		},
+	}, nil).Times(2)
+
+	health, err := NewClusterClient(metaClient).Health(ctx)
+	assert.NoError(t, err)
+	assert.False(t, health.IsHealthy())
+	assert.Len(t, health.StorageHosts, 3)
+	assert.Equal(t, []HostAddress{{Host: "storaged2", Port: 9779}}, health.OfflineStorageHosts)
+	assert.Equal(t, []LeaderImbalance{{
+		Space: "basketballplayer",
+		Leaders: map[HostAddress]int{
+			{Host: "storaged0", Port: 9779}: 4,
+			{Host: "storaged1", Port: 9779}: 0,
+		},
+		Min: 0,
+		Max: 4,
+	}}, health.UnbalancedLeaders)
+
+	health, err = NewClusterClient(metaClient).Health(ctx, WithLeaderTolerance(4))
+	assert.NoError(t, err)
+	assert.Empty(t, health.UnbalancedLeaders)
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	nebula_sirius "github.com/nebula-contrib/nebula-sirius"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/peterh/liner"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var paramNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// lineReader reads the input line by line, it is implemented by *liner.State for the terminal.
+type lineReader interface {
+	// Prompt returns the next line without its line ending, io.EOF at the end of the input
+	// and liner.ErrPromptAborted when the line is discarded with Ctrl-C.
+	Prompt(prompt string) (string, error)
+	// AppendHistory makes the statement recallable with the up and down keys.
+	AppendHistory(item string)
+}
+
+// scriptReader reads the lines of a script, without prompts nor recall.
+type scriptReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r scriptReader) Prompt(prompt string) (string, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+func (r scriptReader) AppendHistory(item string) {}
+
+// Console reads statements and console commands, executes them in the session and renders their results.
+type Console struct {
+	session     *nebula_sirius.Session
+	username    string
+	out         io.Writer
+	space       string
+	params      map[string]*nebula.Value
+	paramValues map[string]string // the parameters as printed by :params
+	history     []string
+	historyFile *os.File
+	lines       lineReader
+	last        *nebula_sirius.ResultSet
+	exit        bool
+}
+
+// NewConsole creates a console writing the results of the statements executed in the session to out.
+func NewConsole(session *nebula_sirius.Session, username string, out io.Writer) *Console {
+	return &Console{
+		session:     session,
+		username:    username,
+		out:         out,
+		params:      map[string]*nebula.Value{},
+		paramValues: map[string]string{},
+	}
+}
+
+// LoadHistory loads the statements of the history file and appends the next ones to it.
+func (c *Console) LoadHistory(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			c.history = append(c.history, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return err
+	}
+	c.historyFile = f
+	return nil
+}
+
+// Run executes the statements and console commands read from in, e.g. a script, until its end or :exit.
+// A statement ends with the semicolon ending a line, so that it may span several lines; the console commands
+// take a single line.
+func (c *Console) Run(ctx context.Context, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64<<10), 16<<20)
+	return c.loop(ctx, scriptReader{scanner}, false)
+}
+
+// RunInteractive reads the statements and console commands from the terminal like Run, with prompts and
+// line editing. The up and down keys recall the statements of the history, Ctrl-C discards the statement
+// being typed and Ctrl-D exits.
+func (c *Console) RunInteractive(ctx context.Context) error {
+	state := liner.NewLiner()
+	defer state.Close()
+	state.SetCtrlCAborts(true)
+	return c.loop(ctx, state, true)
+}
+
+func (c *Console) loop(ctx context.Context, lines lineReader, interactive bool) error {
+	if c.historyFile != nil {
+		defer c.historyFile.Close()
+	}
+	c.lines = lines
+	for _, stmt := range c.history {
+		lines.AppendHistory(stmt)
+	}
+
+	var stmt strings.Builder
+	for !c.exit {
+		input, err := lines.Prompt(c.prompt(stmt.Len() > 0, interactive))
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, liner.ErrPromptAborted) {
+			stmt.Reset()
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		line := strings.TrimSpace(input)
+
+		if stmt.Len() == 0 {
+			if line == "" {
+				continue
+			}
+			if strings.HasPrefix(line, ":") || strings.HasPrefix(line, "!") {
+				// !n is recorded as the statement it executes
+				if !strings.HasPrefix(line, "!") {
+					c.addHistory(line)
+				}
+				c.command(ctx, line)
+				continue
+			}
+		}
+
+		if stmt.Len() > 0 {
+			stmt.WriteString("\n")
+		}
+		stmt.WriteString(line)
+		if strings.HasSuffix(line, ";") {
+			c.addHistory(strings.Join(strings.Fields(stmt.String()), " "))
+			c.execute(ctx, stmt.String())
+			stmt.Reset()
+		}
+	}
+
+	// The last statement of the scripts may miss its semicolon
+	if !c.exit && strings.TrimSpace(stmt.String()) != "" {
+		c.execute(ctx, stmt.String())
+	}
+	if interactive {
+		fmt.Fprintln(c.out, "\nBye!")
+	}
+	return nil
+}
+
+// prompt returns the prompt of the next line, which is empty when not interactive.
+func (c *Console) prompt(continuation, interactive bool) string {
+	if !interactive {
+		return ""
+	}
+	space := c.space
+	if space == "" {
+		space = "(none)"
+	}
+	prompt := fmt.Sprintf("(%s@nebula) [%s]> ", c.username, space)
+	if continuation {
+		prompt = strings.Repeat(" ", len(prompt)-3) + "-> "
+	}
+	return prompt
+}
+
+func (c *Console) addHistory(line string) {
+	c.history = append(c.history, line)
+	if c.lines != nil {
+		c.lines.AppendHistory(line)
+	}
+	if c.historyFile != nil {
+		fmt.Fprintln(c.historyFile, line)
+	}
+}
+
+// execute executes the statement with the parameters and renders its result.
+func (c *Console) execute(ctx context.Context, stmt string) {
+	resultSet, err := c.run(ctx, stmt)
+	if resultSet == nil {
+		c.printError(err)
+		return
+	}
+	if resultSet.IsSucceed() {
+		c.last = resultSet
+	}
+	if err := resultSet.Render(c.out); err != nil {
+		c.printError(err)
+	}
+	fmt.Fprintln(c.out)
+}
+
+func (c *Console) run(ctx context.Context, stmt string) (*nebula_sirius.ResultSet, error) {
+	var params map[string]*nebula.Value
+	if len(c.params) > 0 {
+		params = c.params
+	}
+	resultSet, err := c.session.ExecuteWithParameter(ctx, stmt, params)
+	if resultSet != nil && resultSet.IsSucceed() && resultSet.GetSpaceName() != "" {
+		c.space = resultSet.GetSpaceName()
+	}
+	return resultSet, err
+}
+
+func (c *Console) printError(err error) {
+	fmt.Fprintf(c.out, "[ERROR]: %v\n\n", err)
+}
+
+// command executes the console command of the line.
+func (c *Console) command(ctx context.Context, line string) {
+	if strings.HasPrefix(line, "!") {
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 1 || n > len(c.history) {
+			c.printError(fmt.Errorf("no statement %s in the history", line[1:]))
+			return
+		}
+		stmt := c.history[n-1]
+		fmt.Fprintln(c.out, stmt)
+		c.addHistory(stmt)
+		if strings.HasPrefix(stmt, ":") {
+			c.command(ctx, stmt)
+		} else {
+			c.execute(ctx, stmt)
+		}
+		return
+	}
+
+	name, args, _ := strings.Cut(line[1:], " ")
+	args = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(args), ";"))
+	var err error
+	switch strings.ToLower(name) {
+	case "exit", "quit":
+		c.exit = true
+	case "use":
+		if args == "" {
+			err = errors.New("usage: :use <space>")
+			break
+		}
+		c.execute(ctx, "USE "+args+";")
+	case "param":
+		err = c.setParam(ctx, args)
+	case "params":
+		err = c.listParams(args)
+	case "explain":
+		err = c.explain(ctx, args)
+	case "export":
+		err = c.export(ctx, args)
+	case "history":
+		for i, stmt := range c.history {
+			fmt.Fprintf(c.out, "%5d  %s\n", i+1, stmt)
+		}
+	default:
+		err = fmt.Errorf("unknown command :%s", name)
+	}
+	if err != nil {
+		c.printError(err)
+	}
+}
+
+// setParam sets the parameter of `name => expr` to the value of the expression, evaluated by the graph service.
+func (c *Console) setParam(ctx context.Context, args string) error {
+	name, expr, ok := strings.Cut(args, "=>")
+	name, expr = strings.TrimSpace(name), strings.TrimSpace(expr)
+	if !ok || !paramNameRegexp.MatchString(name) || expr == "" {
+		return errors.New("usage: :param <name> => <expr>")
+	}
+
+	resultSet, err := c.run(ctx, "YIELD "+expr+" AS "+name+";")
+	if err != nil {
+		return err
+	}
+	if resultSet.GetRowSize() != 1 || resultSet.GetColSize() != 1 {
+		return fmt.Errorf("expression %s has no single value", expr)
+	}
+	record, err := resultSet.GetRowValuesByIndex(0)
+	if err != nil {
+		return err
+	}
+	value, err := record.GetValueByIndex(0)
+	if err != nil {
+		return err
+	}
+	c.params[name] = resultSet.GetRows()[0].GetValues()[0]
+	c.paramValues[name] = value.String()
+	return nil
+}
+
+// listParams lists the parameters, or removes them with clear.
+func (c *Console) listParams(args string) error {
+	switch args {
+	case "":
+		names := make([]string, 0, len(c.params))
+		for name := range c.params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(c.out, ">>> $%s => %s\n", name, c.paramValues[name])
+		}
+		return nil
+	case "clear":
+		c.params = map[string]*nebula.Value{}
+		c.paramValues = map[string]string{}
+		return nil
+	default:
+		return errors.New("usage: :params [clear]")
+	}
+}
+
+// explain prints the execution plan of the statement as a DOT graph.
+func (c *Console) explain(ctx context.Context, stmt string) error {
+	if stmt == "" {
+		return errors.New("usage: :explain <stmt>")
+	}
+	resultSet, err := c.run(ctx, `EXPLAIN FORMAT="dot" `+stmt+";")
+	if err != nil {
+		return err
+	}
+	if !resultSet.IsSetPlanDesc() {
+		return errors.New("statement has no execution plan")
+	}
+	fmt.Fprintln(c.out, resultSet.MakeDotGraph())
+	fmt.Fprintln(c.out)
+	return nil
+}
+
+// export writes the result of the statement, or of the last statement, to a CSV file.
+func (c *Console) export(ctx context.Context, args string) error {
+	fields := strings.Fields(args)
+	if len(fields) < 2 || !strings.EqualFold(fields[0], "csv") {
+		return errors.New("usage: :export csv <file> [stmt]")
+	}
+	path := fields[1]
+
+	resultSet := c.last
+	if stmt := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(args, fields[0])), path)); stmt != "" {
+		var err error
+		if resultSet, err = c.run(ctx, stmt+";"); err != nil {
+			return err
+		}
+	}
+	if resultSet == nil {
+		return errors.New("no result to export")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := resultSet.WriteCSV(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "Exported %d rows to %s\n\n", resultSet.GetRowSize(), path)
+	return nil
+}
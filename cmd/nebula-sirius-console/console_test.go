@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	nebula_sirius "github.com/nebula-contrib/nebula-sirius"
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/peterh/liner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConsole(t *testing.T) (*Console, *mocks.GraphService, *bytes.Buffer) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	sessionID := int64(1)
+	graphClient.On("Authenticate", ctx, []byte("root"), []byte("nebula")).
+		Return(&graph.AuthResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED, SessionID: &sessionID}, nil).Once()
+	session, err := nebula_sirius.NewSession(ctx, graphClient, "root", "nebula")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	return NewConsole(session, "root", &out), graphClient, &out
+}
+
+func dataSetResponse(columns []string, values ...*nebula.Value) *graph.ExecutionResponse {
+	colNames := make([][]byte, 0, len(columns))
+	for _, column := range columns {
+		colNames = append(colNames, []byte(column))
+	}
+	return &graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data:      &nebula.DataSet{ColumnNames: colNames, Rows: []*nebula.Row{{Values: values}}},
+	}
+}
+
+func TestConsole_Run(t *testing.T) {
+	ctx := context.Background()
+	console, graphClient, out := newTestConsole(t)
+
+	one := int64(1)
+	graphClient.On("Execute", ctx, int64(1), []byte("USE nba;")).
+		Return(&graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED, SpaceName: []byte("nba")}, nil).Once()
+	graphClient.On("Execute", ctx, int64(1), []byte("YIELD 1 AS p;")).
+		Return(dataSetResponse([]string{"p"}, &nebula.Value{IVal: &one}), nil).Once()
+	for _, stmt := range []string{"YIELD $p AS a,\n\"b\" AS b;", "YIELD $p AS a, \"b\" AS b;"} {
+		graphClient.On("ExecuteWithParameter", ctx, int64(1), []byte(stmt), mock.Anything).
+			Return(dataSetResponse([]string{"a", "b"}, &nebula.Value{IVal: &one}, &nebula.Value{SVal: []byte("b")}), nil).Once()
+	}
+	graphClient.On("ExecuteWithParameter", ctx, int64(1), []byte("YIEL 1;"), mock.Anything).
+		Return(&graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_E_SYNTAX_ERROR, ErrorMsg: []byte("SyntaxError: syntax error near `YIEL'")}, nil).Once()
+
+	input := strings.Join([]string{
+		":use nba",
+		":param p => 1",
+		":params",
+		"YIELD $p AS a,",
+		"\"b\" AS b;",
+		"YIEL 1;",
+		":unknown",
+		"!4",
+		":history",
+		":exit",
+		"YIELD 2;",
+	}, "\n")
+	require.NoError(t, console.Run(ctx, strings.NewReader(input)))
+
+	assert.Equal(t, "nba", console.space)
+	assert.Equal(t, map[string]*nebula.Value{"p": {IVal: &one}}, console.params)
+	got := out.String()
+	assert.Contains(t, got, ">>> $p => 1\n")
+	assert.Contains(t, got, "| a | b   |\n")
+	assert.Contains(t, got, "[ERROR (-1004)]: SyntaxError: syntax error near `YIEL'\n")
+	assert.Contains(t, got, "[ERROR]: unknown command :unknown\n")
+	assert.Contains(t, got, "    4  YIELD $p AS a, \"b\" AS b;\n")
+	assert.Contains(t, got, "    7  YIELD $p AS a, \"b\" AS b;\n")
+}
+
+func TestConsole_Explain(t *testing.T) {
+	ctx := context.Background()
+	console, graphClient, out := newTestConsole(t)
+
+	resp := dataSetResponse(nil)
+	resp.Data = nil
+	resp.PlanDesc = &graph.PlanDescription{
+		PlanNodeDescs: []*graph.PlanNodeDescription{
+			{Name: []byte("Start"), ID: 0, OutputVar: []byte("__Start_0"), Dependencies: []int64{}},
+		},
+		NodeIndexMap: map[int64]int64{0: 0},
+		Format:       []byte("dot"),
+	}
+	graphClient.On("Execute", ctx, int64(1), []byte(`EXPLAIN FORMAT="dot" YIELD 1;`)).
+		Return(resp, nil).Once()
+
+	require.NoError(t, console.Run(ctx, strings.NewReader(":explain YIELD 1;")))
+	assert.Equal(t, "digraph exec_plan {\n\trankdir=BT;\n\t\"Start_0\"[label=\"{Start_0|outputVar: __Start_0|inputVar: }\", shape=Mrecord];\n}\n\n",
+		out.String())
+}
+
+func TestConsole_ExportCSV(t *testing.T) {
+	ctx := context.Background()
+	console, graphClient, out := newTestConsole(t)
+
+	one := int64(1)
+	graphClient.On("Execute", ctx, int64(1), []byte("YIELD 1 AS a;")).
+		Return(dataSetResponse([]string{"a"}, &nebula.Value{IVal: &one}), nil).Twice()
+
+	dir := t.TempDir()
+	input := strings.Join([]string{
+		":export csv " + filepath.Join(dir, "none.csv"),
+		"YIELD 1 AS a;",
+		":export csv " + filepath.Join(dir, "last.csv"),
+		":export csv " + filepath.Join(dir, "stmt.csv") + " YIELD 1 AS a",
+	}, "\n")
+	require.NoError(t, console.Run(ctx, strings.NewReader(input)))
+
+	assert.Contains(t, out.String(), "[ERROR]: no result to export\n")
+	for _, name := range []string{"last.csv", "stmt.csv"} {
+		exported, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, "a\n1\n", string(exported))
+	}
+}
+
+func TestConsole_LoadHistory(t *testing.T) {
+	ctx := context.Background()
+	console, _, _ := newTestConsole(t)
+
+	path := filepath.Join(t.TempDir(), "history")
+	require.NoError(t, os.WriteFile(path, []byte(":params\n"), 0o600))
+	require.NoError(t, console.LoadHistory(path))
+	require.NoError(t, console.Run(ctx, strings.NewReader(":history\n")))
+
+	history, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, ":params\n:history\n", string(history))
+	assert.Equal(t, []string{":params", ":history"}, console.history)
+}
+
+// terminalLines replays the lines typed on the terminal, ^C being Ctrl-C.
+type terminalLines struct {
+	lines   []string
+	history []string
+}
+
+func (r *terminalLines) Prompt(prompt string) (string, error) {
+	if len(r.lines) == 0 {
+		return "", io.EOF
+	}
+	line := r.lines[0]
+	r.lines = r.lines[1:]
+	if line == "^C" {
+		return "", liner.ErrPromptAborted
+	}
+	return line, nil
+}
+
+func (r *terminalLines) AppendHistory(item string) {
+	r.history = append(r.history, item)
+}
+
+func TestConsole_LineEditing(t *testing.T) {
+	ctx := context.Background()
+	console, graphClient, out := newTestConsole(t)
+
+	graphClient.On("Execute", ctx, int64(1), []byte("YIELD 1;")).
+		Return(dataSetResponse([]string{"1"}), nil).Once()
+
+	path := filepath.Join(t.TempDir(), "history")
+	require.NoError(t, os.WriteFile(path, []byte(":params\n"), 0o600))
+	require.NoError(t, console.LoadHistory(path))
+
+	// The statement aborted with Ctrl-C is neither executed nor recorded
+	lines := &terminalLines{lines: []string{"YIELD 2", "^C", "YIELD 1;"}}
+	require.NoError(t, console.loop(ctx, lines, true))
+
+	assert.Equal(t, []string{":params", "YIELD 1;"}, lines.history)
+	assert.Contains(t, out.String(), "\nBye!\n")
+}
@@ -0,0 +1,162 @@
+// Command nebula-sirius-console is an interactive nGQL console built on the nebula-sirius clients, to debug
+// the connection settings of the applications using them, e.g. TLS and HTTP2, without nebula-console.
+//
+// Usage:
+//
+//	nebula-sirius-console -addr 127.0.0.1 -port 9669 -u root -p nebula [-e stmt | -f file]
+//
+// Statements end with a semicolon and may span several lines. The console commands are:
+//
+//	:use <space>                   switches to the graph space
+//	:param <name> => <expr>        sets the parameter to the value of the expression
+//	:params                        lists the parameters, `:params clear` removes them
+//	:explain <stmt>                prints the execution plan of the statement as a DOT graph
+//	:export csv <file> [stmt]      writes the result of the statement, or of the last one, as CSV
+//	:history                       lists the statements, `!<n>` executes the n-th one again
+//	:exit, :quit                   exits the console
+//
+// The lines are edited like in a shell, the up and down keys recalling the statements of the history file.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	nebula_sirius "github.com/nebula-contrib/nebula-sirius"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// headerFlags collects the repeated -http_header flags.
+type headerFlags []string
+
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ",")
+}
+
+func (h *headerFlags) Set(value string) error {
+	*h = append(*h, value)
+	return nil
+}
+
+func main() {
+	var (
+		address      = flag.String("addr", "127.0.0.1", "address of the graph service")
+		port         = flag.Int("port", 9669, "port of the graph service")
+		username     = flag.String("u", "root", "username")
+		password     = flag.String("p", "nebula", "password")
+		eval         = flag.String("e", "", "statements to execute instead of starting the console")
+		file         = flag.String("f", "", "file of statements to execute instead of starting the console")
+		timeout      = flag.Duration("timeout", 120*time.Second, "connection and socket timeout, 0 for none")
+		handshakeKey = flag.String("handshake_key", "", "handshake key checked against client_white_list of the server")
+		enableHTTP2  = flag.Bool("enable_http2", false, "connect with HTTP2")
+		enableSSL    = flag.Bool("enable_ssl", false, "connect with TLS")
+		rootCAPath   = flag.String("ssl_root_ca_path", "", "path of the root CA certificate")
+		certPath     = flag.String("ssl_cert_path", "", "path of the client certificate")
+		keyPath      = flag.String("ssl_private_key_path", "", "path of the private key of the client certificate")
+		insecure     = flag.Bool("ssl_insecure_skip_verify", false, "skip the verification of the server certificate")
+		historyPath  = flag.String("history", defaultHistoryPath(), "file of the history, empty to disable it")
+		headers      headerFlags
+	)
+	flag.Var(&headers, "http_header", "HTTP2 header as Name:Value, may be repeated")
+	flag.Parse()
+
+	conf := &nebula_sirius.NebulaClientConfig{
+		UseHTTP2:     *enableHTTP2,
+		HandshakeKey: *handshakeKey,
+		HostAddress:  nebula_sirius.HostAddress{Host: *address, Port: *port},
+		Timeout:      *timeout,
+	}
+	if len(headers) > 0 {
+		conf.HttpHeader = http.Header{}
+		for _, header := range headers {
+			name, value, ok := strings.Cut(header, ":")
+			if !ok {
+				exitf("invalid HTTP header %q, expected Name:Value", header)
+			}
+			conf.HttpHeader.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+	}
+	if *enableSSL {
+		sslConfig := &tls.Config{}
+		if *rootCAPath != "" || *certPath != "" || *keyPath != "" {
+			var err error
+			sslConfig, err = nebula_sirius.GetDefaultSSLConfig(*rootCAPath, *certPath, *keyPath)
+			if err != nil {
+				exitf("failed to load the TLS configuration: %v", err)
+			}
+		}
+		sslConfig.InsecureSkipVerify = *insecure
+		conf.SslConfig = sslConfig
+	}
+
+	ctx := context.Background()
+	factory := nebula_sirius.NewNebulaClientFactory(conf, quietLogger{}, nebula_sirius.DefaultClientNameGenerator)
+	object, err := factory.MakeObject(ctx)
+	if err != nil {
+		exitf("failed to create the client: %v", err)
+	}
+	defer factory.DestroyObject(ctx, object)
+	if err := factory.ActivateObject(ctx, object); err != nil {
+		exitf("failed to connect to %s:%d: %v", *address, *port, err)
+	}
+
+	graphClient, err := object.Object.(*nebula_sirius.WrappedNebulaClient).GraphClient()
+	if err != nil {
+		exitf("failed to open the graph client: %v", err)
+	}
+	session, err := nebula_sirius.NewSession(ctx, graphClient, *username, *password)
+	if err != nil {
+		exitf("%v", err)
+	}
+	defer session.Release(ctx)
+
+	console := NewConsole(session, *username, os.Stdout)
+	switch {
+	case *eval != "":
+		err = console.Run(ctx, strings.NewReader(*eval))
+	case *file != "":
+		f, openErr := os.Open(*file)
+		if openErr != nil {
+			exitf("%v", openErr)
+		}
+		defer f.Close()
+		err = console.Run(ctx, f)
+	default:
+		if *historyPath != "" {
+			if err := console.LoadHistory(*historyPath); err != nil {
+				fmt.Fprintf(os.Stderr, "failed to load the history: %v\n", err)
+			}
+		}
+		fmt.Fprintf(os.Stdout, "Welcome to nebula-sirius-console, connected to %s:%d\n\n", *address, *port)
+		err = console.RunInteractive(ctx)
+	}
+	if err != nil {
+		exitf("%v", err)
+	}
+}
+
+func defaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".nebula_sirius_history")
+}
+
+func exitf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+// quietLogger drops the debug and info logs of the clients, which would be mixed with the output of the console.
+type quietLogger struct {
+	nebula_sirius.DefaultLogger
+}
+
+func (quietLogger) Info(msg string)  {}
+func (quietLogger) Debug(msg string) {}
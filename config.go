@@ -0,0 +1,315 @@
+package nebula_sirius
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Config is a runtime flag of a module, e.g. heartbeat_interval_secs of the META module.
+// Its value is a bool, an int64, a float64, a string or a map[string]any of them,
+// e.g. rocksdb_column_family_options of the STORAGE module.
+type Config struct {
+	Module meta.ConfigModule
+	Name   string
+	Mode   meta.ConfigMode
+	Value  any
+}
+
+// IsMutable returns whether the config can be changed at runtime with ConfigClient.SetConfig.
+func (c *Config) IsMutable() bool {
+	return c.Mode == meta.ConfigMode_MUTABLE
+}
+
+// ConfigDiff is a config whose live value differs from the desired one.
+type ConfigDiff struct {
+	Module  meta.ConfigModule
+	Name    string
+	Mode    meta.ConfigMode // mode of the live config
+	Desired any
+	Live    any  // nil when the config is not found
+	Missing bool // whether the config is not found in the module
+}
+
+// ConfigClient reads and changes the runtime configs of the modules through the meta service.
+type ConfigClient struct {
+	metaClient meta.MetaService // required
+}
+
+// NewConfigClient creates a new ConfigClient sending its requests to the given meta client.
+func NewConfigClient(metaClient meta.MetaService) *ConfigClient {
+	return &ConfigClient{
+		metaClient: metaClient,
+	}
+}
+
+// GetConfig returns the config of the module, which must be GRAPH, META or STORAGE.
+func (c *ConfigClient) GetConfig(ctx context.Context, module meta.ConfigModule, name string) (*Config, error) {
+	if err := checkConfigModule(module); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.metaClient.GetConfig(ctx, &meta.GetConfigReq{Item: &meta.ConfigItem{
+		Module: module,
+		Name:   []byte(name),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() == nebula.ErrorCode_E_CONFIG_NOT_FOUND || (resp.GetCode() == nebula.ErrorCode_SUCCEEDED && len(resp.GetItems()) == 0) {
+		return nil, fmt.Errorf("config %s:%s not found", module, name)
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to get config %s:%s, error code: %s", module, name, resp.GetCode())
+	}
+
+	config, err := configFromItem(resp.GetItems()[0])
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// SetConfig sets the value of the mutable config of the module, which must be GRAPH, META or STORAGE.
+// The value is a bool, an int, a float, a string or a map of them.
+func (c *ConfigClient) SetConfig(ctx context.Context, module meta.ConfigModule, name string, value any) error {
+	if err := checkConfigModule(module); err != nil {
+		return err
+	}
+	nebulaValue, err := configValueToNebula(value)
+	if err != nil {
+		return fmt.Errorf("invalid value of config %s:%s: %w", module, name, err)
+	}
+
+	resp, err := c.metaClient.SetConfig(ctx, &meta.SetConfigReq{Item: &meta.ConfigItem{
+		Module: module,
+		Name:   []byte(name),
+		Mode:   meta.ConfigMode_MUTABLE,
+		Value:  nebulaValue,
+	}})
+	if err == nil && resp.GetCode() == nebula.ErrorCode_E_CONFIG_IMMUTABLE {
+		return fmt.Errorf("config %s:%s is immutable", module, name)
+	}
+	return checkExecResp(resp, err, fmt.Sprintf("set config %s:%s", module, name))
+}
+
+// ListConfigs returns the configs of the module, or of all the modules with meta.ConfigModule_ALL,
+// sorted by module and name.
+func (c *ConfigClient) ListConfigs(ctx context.Context, module meta.ConfigModule) ([]Config, error) {
+	if module != meta.ConfigModule_ALL {
+		if err := checkConfigModule(module); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.metaClient.ListConfigs(ctx, &meta.ListConfigsReq{Module: module})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list configs of %s, error code: %s", module, resp.GetCode())
+	}
+
+	configs := make([]Config, 0, len(resp.GetItems()))
+	for _, item := range resp.GetItems() {
+		config, err := configFromItem(item)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	slices.SortFunc(configs, func(a, b Config) int {
+		if order := cmp.Compare(a.Module, b.Module); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return configs, nil
+}
+
+// RegisterConfigs registers the configs along with their default values. The services register
+// their own configs when they start, so this is only needed for custom configs.
+func (c *ConfigClient) RegisterConfigs(ctx context.Context, configs ...Config) error {
+	if len(configs) == 0 {
+		return fmt.Errorf("at least one config is required")
+	}
+
+	items := make([]*meta.ConfigItem, 0, len(configs))
+	for _, config := range configs {
+		if err := checkConfigModule(config.Module); err != nil {
+			return err
+		}
+		value, err := configValueToNebula(config.Value)
+		if err != nil {
+			return fmt.Errorf("invalid value of config %s:%s: %w", config.Module, config.Name, err)
+		}
+		items = append(items, &meta.ConfigItem{
+			Module: config.Module,
+			Name:   []byte(config.Name),
+			Mode:   config.Mode,
+			Value:  value,
+		})
+	}
+
+	resp, err := c.metaClient.RegConfig(ctx, &meta.RegConfigReq{Items: items})
+	return checkExecResp(resp, err, "register configs")
+}
+
+// DiffConfigs compares the desired values of the configs of the module, by name, with their live values,
+// and returns the configs which differ, sorted by name. The module must be GRAPH, META or STORAGE.
+func (c *ConfigClient) DiffConfigs(ctx context.Context, module meta.ConfigModule, desired map[string]any) ([]ConfigDiff, error) {
+	if err := checkConfigModule(module); err != nil {
+		return nil, err
+	}
+
+	live, err := c.ListConfigs(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+	liveByName := make(map[string]Config, len(live))
+	for _, config := range live {
+		liveByName[config.Name] = config
+	}
+
+	diffs := make([]ConfigDiff, 0)
+	for name, value := range desired {
+		// Round trip the desired value, e.g. to compare an int with the int64 of the live value
+		nebulaValue, err := configValueToNebula(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid desired value of config %s:%s: %w", module, name, err)
+		}
+		desiredValue, err := configValueFromNebula(nebulaValue)
+		if err != nil {
+			return nil, err
+		}
+
+		config, ok := liveByName[name]
+		if !ok {
+			diffs = append(diffs, ConfigDiff{Module: module, Name: name, Desired: desiredValue, Missing: true})
+			continue
+		}
+		if !reflect.DeepEqual(desiredValue, config.Value) {
+			diffs = append(diffs, ConfigDiff{
+				Module:  module,
+				Name:    name,
+				Mode:    config.Mode,
+				Desired: desiredValue,
+				Live:    config.Value,
+			})
+		}
+	}
+	slices.SortFunc(diffs, func(a, b ConfigDiff) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return diffs, nil
+}
+
+func checkConfigModule(module meta.ConfigModule) error {
+	switch module {
+	case meta.ConfigModule_GRAPH, meta.ConfigModule_META, meta.ConfigModule_STORAGE:
+		return nil
+	default:
+		return fmt.Errorf("config module must be GRAPH, META or STORAGE, got %s", module)
+	}
+}
+
+func configFromItem(item *meta.ConfigItem) (Config, error) {
+	value, err := configValueFromNebula(item.GetValue())
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid value of config %s:%s: %w", item.GetModule(), item.GetName(), err)
+	}
+	return Config{
+		Module: item.GetModule(),
+		Name:   string(item.GetName()),
+		Mode:   item.GetMode(),
+		Value:  value,
+	}, nil
+}
+
+// configValueFromNebula converts the value of a config into a bool, an int64, a float64, a string,
+// a map[string]any of them, or nil.
+func configValueFromNebula(value *nebula.Value) (any, error) {
+	switch {
+	case value == nil || value.IsSetNVal():
+		return nil, nil
+	case value.IsSetBVal():
+		return value.GetBVal(), nil
+	case value.IsSetIVal():
+		return value.GetIVal(), nil
+	case value.IsSetFVal():
+		return value.GetFVal(), nil
+	case value.IsSetSVal():
+		return string(value.GetSVal()), nil
+	case value.IsSetMVal():
+		values := make(map[string]any, len(value.GetMVal().GetKvs()))
+		for key, item := range value.GetMVal().GetKvs() {
+			v, err := configValueFromNebula(item)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = v
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported config value type %s", ValueWrapper{value: value}.GetType())
+	}
+}
+
+// configValueToNebula converts a bool, an integer, a float, a string, or a map of them into
+// the value of a config.
+func configValueToNebula(value any) (*nebula.Value, error) {
+	switch v := value.(type) {
+	case bool:
+		return &nebula.Value{BVal: &v}, nil
+	case int:
+		return configIntValue(int64(v)), nil
+	case int8:
+		return configIntValue(int64(v)), nil
+	case int16:
+		return configIntValue(int64(v)), nil
+	case int32:
+		return configIntValue(int64(v)), nil
+	case int64:
+		return configIntValue(v), nil
+	case uint8:
+		return configIntValue(int64(v)), nil
+	case uint16:
+		return configIntValue(int64(v)), nil
+	case uint32:
+		return configIntValue(int64(v)), nil
+	case float32:
+		f := float64(v)
+		return &nebula.Value{FVal: &f}, nil
+	case float64:
+		return &nebula.Value{FVal: &v}, nil
+	case string:
+		return &nebula.Value{SVal: []byte(v)}, nil
+	case map[string]string:
+		kvs := make(map[string]*nebula.Value, len(v))
+		for key, item := range v {
+			kvs[key] = &nebula.Value{SVal: []byte(item)}
+		}
+		return &nebula.Value{MVal: &nebula.NMap{Kvs: kvs}}, nil
+	case map[string]any:
+		kvs := make(map[string]*nebula.Value, len(v))
+		for key, item := range v {
+			itemValue, err := configValueToNebula(item)
+			if err != nil {
+				return nil, err
+			}
+			kvs[key] = itemValue
+		}
+		return &nebula.Value{MVal: &nebula.NMap{Kvs: kvs}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported config value type %T", value)
+	}
+}
+
+func configIntValue(i int64) *nebula.Value {
+	return &nebula.Value{IVal: &i}
+}
@@ -0,0 +1,167 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func listConfigsResp() *meta.ListConfigsResp {
+	heartbeat := int64(10)
+	return &meta.ListConfigsResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Items: []*meta.ConfigItem{
+			{
+				Module: meta.ConfigModule_STORAGE,
+				Name:   []byte("rocksdb_column_family_options"),
+				Mode:   meta.ConfigMode_MUTABLE,
+				Value: &nebula.Value{MVal: &nebula.NMap{Kvs: map[string]*nebula.Value{
+					"write_buffer_size":          {SVal: []byte("67108864")},
+					"max_write_buffer_number":    {SVal: []byte("4")},
+					"disable_auto_compactions":   {SVal: []byte("false")},
+					"max_bytes_for_level_base":   {SVal: []byte("268435456")},
+					"level0_file_num_compaction": {SVal: []byte("4")},
+				}}},
+			},
+			{
+				Module: meta.ConfigModule_STORAGE,
+				Name:   []byte("heartbeat_interval_secs"),
+				Mode:   meta.ConfigMode_MUTABLE,
+				Value:  &nebula.Value{IVal: &heartbeat},
+			},
+			{
+				Module: meta.ConfigModule_STORAGE,
+				Name:   []byte("data_path"),
+				Mode:   meta.ConfigMode_IMMUTABLE,
+				Value:  &nebula.Value{SVal: []byte("/data/storage")},
+			},
+		},
+	}
+}
+
+func TestConfigClient_GetConfig(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	heartbeat := int64(10)
+	metaClient.On("GetConfig", ctx, &meta.GetConfigReq{Item: &meta.ConfigItem{
+		Module: meta.ConfigModule_META,
+		Name:   []byte("heartbeat_interval_secs"),
+	}}).Return(&meta.GetConfigResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Items: []*meta.ConfigItem{{
+			Module: meta.ConfigModule_META,
+			Name:   []byte("heartbeat_interval_secs"),
+			Mode:   meta.ConfigMode_MUTABLE,
+			Value:  &nebula.Value{IVal: &heartbeat},
+		}},
+	}, nil).Once()
+
+	config, err := NewConfigClient(metaClient).GetConfig(ctx, meta.ConfigModule_META, "heartbeat_interval_secs")
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{
+		Module: meta.ConfigModule_META,
+		Name:   "heartbeat_interval_secs",
+		Mode:   meta.ConfigMode_MUTABLE,
+		Value:  int64(10),
+	}, config)
+	assert.True(t, config.IsMutable())
+}
+
+func TestConfigClient_GetConfig_InvalidModule(t *testing.T) {
+	metaClient := mocks.NewMetaService(t)
+
+	_, err := NewConfigClient(metaClient).GetConfig(context.Background(), meta.ConfigModule_ALL, "heartbeat_interval_secs")
+	assert.ErrorContains(t, err, "config module must be GRAPH, META or STORAGE, got ALL")
+}
+
+func TestConfigClient_SetConfig(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("SetConfig", ctx, &meta.SetConfigReq{Item: &meta.ConfigItem{
+		Module: meta.ConfigModule_STORAGE,
+		Name:   []byte("rocksdb_column_family_options"),
+		Mode:   meta.ConfigMode_MUTABLE,
+		Value: &nebula.Value{MVal: &nebula.NMap{Kvs: map[string]*nebula.Value{
+			"disable_auto_compactions": {SVal: []byte("true")},
+		}}},
+	}}).Return(succeededExecResp(), nil).Once()
+
+	err := NewConfigClient(metaClient).SetConfig(ctx, meta.ConfigModule_STORAGE, "rocksdb_column_family_options", map[string]string{
+		"disable_auto_compactions": "true",
+	})
+	assert.NoError(t, err)
+}
+
+func TestConfigClient_SetConfig_Immutable(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("SetConfig", ctx, &meta.SetConfigReq{Item: &meta.ConfigItem{
+		Module: meta.ConfigModule_STORAGE,
+		Name:   []byte("data_path"),
+		Mode:   meta.ConfigMode_MUTABLE,
+		Value:  &nebula.Value{SVal: []byte("/data")},
+	}}).Return(&meta.ExecResp{Code: nebula.ErrorCode_E_CONFIG_IMMUTABLE}, nil).Once()
+
+	err := NewConfigClient(metaClient).SetConfig(ctx, meta.ConfigModule_STORAGE, "data_path", "/data")
+	assert.EqualError(t, err, "config STORAGE:data_path is immutable")
+}
+
+func TestConfigClient_SetConfig_UnsupportedValue(t *testing.T) {
+	metaClient := mocks.NewMetaService(t)
+
+	err := NewConfigClient(metaClient).SetConfig(context.Background(), meta.ConfigModule_GRAPH, "session_idle_timeout_secs", []int{1})
+	assert.ErrorContains(t, err, "unsupported config value type []int")
+}
+
+func TestConfigClient_ListConfigs(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListConfigs", ctx, &meta.ListConfigsReq{Module: meta.ConfigModule_STORAGE}).Return(listConfigsResp(), nil).Once()
+
+	configs, err := NewConfigClient(metaClient).ListConfigs(ctx, meta.ConfigModule_STORAGE)
+	assert.NoError(t, err)
+	assert.Len(t, configs, 3)
+	assert.Equal(t, "data_path", configs[0].Name)
+	assert.False(t, configs[0].IsMutable())
+	assert.Equal(t, "heartbeat_interval_secs", configs[1].Name)
+	assert.Equal(t, int64(10), configs[1].Value)
+	assert.Equal(t, "rocksdb_column_family_options", configs[2].Name)
+	assert.Equal(t, "67108864", configs[2].Value.(map[string]any)["write_buffer_size"])
+}
+
+func TestConfigClient_DiffConfigs(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListConfigs", ctx, &meta.ListConfigsReq{Module: meta.ConfigModule_STORAGE}).Return(listConfigsResp(), nil).Once()
+
+	diffs, err := NewConfigClient(metaClient).DiffConfigs(ctx, meta.ConfigModule_STORAGE, map[string]any{
+		"heartbeat_interval_secs": 10,
+		"data_path":               "/data",
+		"wal_ttl":                 14400,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []ConfigDiff{
+		{
+			Module:  meta.ConfigModule_STORAGE,
+			Name:    "data_path",
+			Mode:    meta.ConfigMode_IMMUTABLE,
+			Desired: "/data",
+			Live:    "/data/storage",
+		},
+		{
+			Module:  meta.ConfigModule_STORAGE,
+			Name:    "wal_ttl",
+			Desired: int64(14400),
+			Missing: true,
+		},
+	}, diffs)
+}
@@ -7,6 +7,8 @@ import (
 	nebula_sirius "github.com/nebula-contrib/nebula-sirius"
 	"github.com/nebula-contrib/nebula-sirius/nebula"
 	nebulagraph_light_deployment "github.com/nebula-contrib/nebula-sirius/nebulagraph-light-deployment"
+	"github.com/nebula-contrib/nebula-sirius/statement/space_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/space_drop"
 	"log"
 	"strings"
 	"time"
@@ -75,14 +77,24 @@ func main() {
 
 	log.Println(fmt.Sprintf("SessionId: %d, ErrorCode: %s, ErrorMessage: %s", a.GetSessionID(), a.GetErrorCode(), a.GetErrorMsg()))
 
+	// Open a session used to wait for the schema changes to be visible on the graph service
+	session, err := nebula_sirius.NewSession(ctx, g, nebulagraph_light_deployment.USERNAME, nebulagraph_light_deployment.PASSWORD)
+	if err != nil {
+		log.Fatalf("Error opening session: %v", err)
+	}
+	defer session.Release(ctx)
+	waitExecutors := []nebula_sirius.IExecutor{session}
+
 	log.Println(" - - - - - - - - - - - - - - - - - - - - - - - - ")
 
 	spaceName := "person_pool_test_4"
 	tagName := "Persona"
 	{
-		ngl := `DROP SPACE IF EXISTS %s;
-	`
-		nglQuery := fmt.Sprintf(ngl, spaceName)
+		nglQuery, err := space_drop.GenerateDropSpaceStatement(
+			space_drop.NewDropSpaceStatement(spaceName, space_drop.WithIfExists(true)))
+		if err != nil {
+			log.Fatalf("Error generating drop space statement: %v", err)
+		}
 		log.Println(nglQuery)
 		a1, err := g.Execute(ctx, *a.SessionID, []byte(nglQuery))
 		if err != nil || a1.GetErrorCode() != nebula.ErrorCode_SUCCEEDED {
@@ -91,9 +103,14 @@ func main() {
 
 	}
 
-	time.Sleep(5 * time.Second)
 	{
-		nglQuery := fmt.Sprintf(`CREATE SPACE %s(partition_num=1, replica_factor=1, vid_type = FIXED_STRING(30));`, spaceName)
+		nglQuery, err := space_create.GenerateCreateSpaceStatement(
+			space_create.NewCreateSpaceStatement(spaceName, space_create.VidTypeFixedString(30),
+				space_create.WithPartitionNum(1),
+				space_create.WithReplicaFactor(1)))
+		if err != nil {
+			log.Fatalf("Error generating create space statement: %v", err)
+		}
 		log.Println(nglQuery)
 		a1, err := g.Execute(ctx, *a.SessionID, []byte(nglQuery))
 		if err != nil || a1.GetErrorCode() != nebula.ErrorCode_SUCCEEDED {
@@ -103,7 +120,11 @@ func main() {
 		log.Printf("Space: %s successfully created", spaceName)
 	}
 
-	time.Sleep(10 * time.Second)
+	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if err := nebula_sirius.WaitForSpace(waitCtx, waitExecutors, spaceName); err != nil {
+		log.Fatalf("Error waiting for space: %v", err)
+	}
 	{
 		nglQuery := fmt.Sprintf(`USE %s; CREATE TAG %s();`, spaceName, tagName)
 		log.Println(nglQuery)
@@ -115,7 +136,9 @@ func main() {
 		log.Println("Person tag successfully created")
 	}
 
-	time.Sleep(10 * time.Second)
+	if err := nebula_sirius.WaitForTag(waitCtx, waitExecutors, spaceName, tagName); err != nil {
+		log.Fatalf("Error waiting for tag: %v", err)
+	}
 
 	{
 		var persons []string
@@ -134,8 +157,6 @@ func main() {
 		log.Println("Persons are successfully inserted")
 	}
 
-	time.Sleep(10 * time.Second)
-
 	{
 		nglTemplate := `USE %s; MATCH (v) RETURN count(v) as col1;`
 		nglQuery := fmt.Sprintf(nglTemplate, spaceName)
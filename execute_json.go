@@ -0,0 +1,164 @@
+package nebula_sirius
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"reflect"
+)
+
+// JSONResponse is the response of the graph service to a statement executed with ExecuteJSON.
+type JSONResponse struct {
+	Errors  []JSONError  `json:"errors"`
+	Results []JSONResult `json:"results"`
+}
+
+// JSONError is the error code and message of a JSON response, or of one of its results.
+type JSONError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message,omitempty"`
+}
+
+// JSONResult is the result of a statement in a JSON response.
+type JSONResult struct {
+	SpaceName   string          `json:"spaceName"`
+	LatencyInUs int64           `json:"latencyInUs"`
+	Columns     []string        `json:"columns"`
+	Data        []JSONRow       `json:"data"`
+	Errors      *JSONError      `json:"errors,omitempty"`
+	PlanDesc    json.RawMessage `json:"planDesc,omitempty"`
+	Comment     string          `json:"comment,omitempty"`
+}
+
+// JSONRow is a row of a JSON result. Row holds the values of the row by column, e.g. the properties of
+// a vertex as an object keyed by tag.property. Meta holds, by column, the ID of the vertex or edge
+// of the value, or null for the other values.
+type JSONRow struct {
+	Row  []json.RawMessage `json:"row"`
+	Meta []*JSONMeta       `json:"meta"`
+}
+
+// JSONMeta is the type and ID of a vertex or edge value of a JSON row.
+type JSONMeta struct {
+	Type string          `json:"type"` // vertex or edge
+	ID   json.RawMessage `json:"id"`   // the vertex ID, a string or an integer, or a JSONEdgeID
+}
+
+// JSONEdgeID is the ID of an edge value of a JSON row.
+type JSONEdgeID struct {
+	Name    string          `json:"name"`
+	Src     json.RawMessage `json:"src"`
+	Dst     json.RawMessage `json:"dst"`
+	Type    int32           `json:"type"`
+	Ranking int64           `json:"ranking"`
+}
+
+// EdgeID decodes the ID of an edge.
+func (m *JSONMeta) EdgeID() (*JSONEdgeID, error) {
+	if m.Type != "edge" {
+		return nil, fmt.Errorf("meta of type %s is not an edge", m.Type)
+	}
+	edgeID := &JSONEdgeID{}
+	if err := json.Unmarshal(m.ID, edgeID); err != nil {
+		return nil, err
+	}
+	return edgeID, nil
+}
+
+// Error returns the error of the response, nil when it succeeded.
+func (resp *JSONResponse) Error() *JSONError {
+	for i := range resp.Errors {
+		if resp.Errors[i].Code != ErrorCode_SUCCEEDED {
+			return &resp.Errors[i]
+		}
+	}
+	for _, result := range resp.Results {
+		if result.Errors != nil && result.Errors.Code != ErrorCode_SUCCEEDED {
+			return result.Errors
+		}
+	}
+	return nil
+}
+
+// Scan decodes the rows of the result into the given pointer to a slice. Each row is decoded
+// as a JSON object keyed by column, so the fields of the elements are matched with their json tags.
+func (r *JSONResult) Scan(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("scan: expected a non-nil pointer to a slice, got %T", v)
+	}
+
+	rows := make([]map[string]json.RawMessage, 0, len(r.Data))
+	for i, data := range r.Data {
+		if len(data.Row) != len(r.Columns) {
+			return fmt.Errorf("scan: row %d has %d values for %d columns", i, len(data.Row), len(r.Columns))
+		}
+		row := make(map[string]json.RawMessage, len(r.Columns))
+		for j, column := range r.Columns {
+			row[column] = data.Row[j]
+		}
+		rows = append(rows, row)
+	}
+
+	raw, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// ExecuteJSON executes the given statement in the session and decodes the JSON response of the graph service.
+//
+// If the response holds an error, it is returned along with an *ExecutionError.
+func (s *Session) ExecuteJSON(ctx context.Context, stmt string) (*JSONResponse, error) {
+	return s.ExecuteJSONWithParameter(ctx, stmt, nil)
+}
+
+// ExecuteJSONWithParameter executes the given parameterized statement in the session and decodes
+// the JSON response of the graph service.
+//
+// If the response holds an error, it is returned along with an *ExecutionError.
+func (s *Session) ExecuteJSONWithParameter(ctx context.Context, stmt string, params map[string]*nebula.Value) (*JSONResponse, error) {
+	raw, err := s.ExecuteJSONRaw(ctx, stmt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &JSONResponse{}
+	if err := json.Unmarshal(raw, resp); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON response: %w", err)
+	}
+
+	if respErr := resp.Error(); respErr != nil {
+		return resp, &ExecutionError{
+			Stmt:      stmt,
+			ErrorCode: respErr.Code,
+			ErrorMsg:  respErr.Message,
+		}
+	}
+	return resp, nil
+}
+
+// ExecuteJSONRaw executes the given statement, parameterized when params is not nil, and returns
+// the JSON response of the graph service as is, e.g. for HTTP gateways proxying it to browsers.
+// The errors it holds are left to the caller.
+func (s *Session) ExecuteJSONRaw(ctx context.Context, stmt string, params map[string]*nebula.Value) (json.RawMessage, error) {
+	var (
+		raw []byte
+		err error
+	)
+	if params == nil {
+		raw, err = s.graphClient.ExecuteJson(ctx, s.sessionID, []byte(stmt))
+	} else {
+		raw, err = s.graphClient.ExecuteJsonWithParameter(ctx, s.sessionID, []byte(stmt), params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(raw) {
+		return nil, fmt.Errorf("invalid JSON response of the graph service")
+	}
+	return raw, nil
+}
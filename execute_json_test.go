@@ -0,0 +1,159 @@
+package nebula_sirius
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the tests")
+
+// assertGolden compares got with the content of the golden file, or writes it with -update.
+func assertGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestSession_ExecuteJSON_Golden(t *testing.T) {
+	testCases := []struct {
+		name string
+		stmt string
+	}{
+		{name: "yield", stmt: "GO FROM 'player100' OVER follow YIELD $$.player.name AS name, $$.player.age AS age, tags($$) AS tags;"},
+		{name: "vertex_edge", stmt: "MATCH (v:player)-[e:follow]->() WHERE id(v) == 'player100' RETURN v, e;"},
+		{name: "error", stmt: "YIEL 1;"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			graphClient := mocks.NewGraphService(t)
+			session := &Session{graphClient: graphClient, sessionID: 1}
+
+			raw, err := os.ReadFile(filepath.Join("testdata", "execute_json", tc.name+".json"))
+			require.NoError(t, err)
+			graphClient.On("ExecuteJson", ctx, int64(1), []byte(tc.stmt)).Return(raw, nil).Once()
+
+			resp, err := session.ExecuteJSON(ctx, tc.stmt)
+			var execErr *ExecutionError
+			if tc.name == "error" {
+				assert.True(t, errors.As(err, &execErr))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			got, err := json.MarshalIndent(resp, "", "  ")
+			require.NoError(t, err)
+			assertGolden(t, filepath.Join("testdata", "execute_json", tc.name+".golden"), append(got, '\n'))
+		})
+	}
+}
+
+func TestSession_ExecuteJSON_Error(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	raw, err := os.ReadFile(filepath.Join("testdata", "execute_json", "error.json"))
+	require.NoError(t, err)
+	graphClient.On("ExecuteJson", ctx, int64(1), []byte("YIEL 1;")).Return(raw, nil).Once()
+
+	resp, err := session.ExecuteJSON(ctx, "YIEL 1;")
+	assert.NotNil(t, resp)
+
+	var execErr *ExecutionError
+	assert.True(t, errors.As(err, &execErr))
+	assert.Equal(t, ErrorCode_E_SYNTAX_ERROR, execErr.ErrorCode)
+	assert.Equal(t, "SyntaxError: syntax error near `YIEL'", execErr.ErrorMsg)
+	assert.Equal(t, "YIEL 1;", execErr.Stmt)
+}
+
+func TestSession_ExecuteJSONWithParameter(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	params := map[string]*nebula.Value{"p": setIVal(1)}
+	graphClient.On("ExecuteJsonWithParameter", ctx, int64(1), []byte("YIELD $p AS p;"), params).Return(
+		[]byte(`{"errors":[{"code":0}],"results":[{"columns":["p"],"data":[{"row":[1],"meta":[null]}]}]}`), nil).Once()
+
+	resp, err := session.ExecuteJSONWithParameter(ctx, "YIELD $p AS p;", params)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p"}, resp.Results[0].Columns)
+	assert.Equal(t, json.RawMessage("1"), resp.Results[0].Data[0].Row[0])
+}
+
+func TestSession_ExecuteJSONRaw(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	raw, err := os.ReadFile(filepath.Join("testdata", "execute_json", "error.json"))
+	require.NoError(t, err)
+	graphClient.On("ExecuteJson", ctx, int64(1), []byte("YIEL 1;")).Return(raw, nil).Once()
+	graphClient.On("ExecuteJson", ctx, int64(1), []byte("YIELD 1;")).Return([]byte(`{"errors":`), nil).Once()
+
+	got, err := session.ExecuteJSONRaw(ctx, "YIEL 1;", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, json.RawMessage(raw), got)
+
+	_, err = session.ExecuteJSONRaw(ctx, "YIELD 1;", nil)
+	assert.EqualError(t, err, "invalid JSON response of the graph service")
+}
+
+func TestJSONResult_Scan(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "execute_json", "yield.json"))
+	require.NoError(t, err)
+	resp := &JSONResponse{}
+	require.NoError(t, json.Unmarshal(raw, resp))
+
+	type player struct {
+		Name string   `json:"name"`
+		Age  int      `json:"age"`
+		Tags []string `json:"tags"`
+	}
+	var players []player
+	assert.NoError(t, resp.Results[0].Scan(&players))
+	assert.Equal(t, []player{
+		{Name: "Tim Duncan", Age: 42, Tags: []string{"player", "bachelor"}},
+		{Name: "Tony Parker", Age: 36, Tags: []string{"player"}},
+	}, players)
+
+	assert.ErrorContains(t, resp.Results[0].Scan(players), "expected a non-nil pointer to a slice")
+}
+
+func TestJSONMeta_EdgeID(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "execute_json", "vertex_edge.json"))
+	require.NoError(t, err)
+	resp := &JSONResponse{}
+	require.NoError(t, json.Unmarshal(raw, resp))
+
+	meta := resp.Results[0].Data[0].Meta
+	edgeID, err := meta[1].EdgeID()
+	assert.NoError(t, err)
+	assert.Equal(t, &JSONEdgeID{
+		Name:    "follow",
+		Src:     json.RawMessage(`"player100"`),
+		Dst:     json.RawMessage(`"player101"`),
+		Type:    1,
+		Ranking: 0,
+	}, edgeID)
+
+	_, err = meta[0].EdgeID()
+	assert.EqualError(t, err, "meta of type vertex is not an edge")
+}
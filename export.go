@@ -0,0 +1,343 @@
+package nebula_sirius
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"io"
+	"math"
+	"strconv"
+)
+
+const (
+	isoDateLayout     = "2006-01-02"
+	isoTimeLayout     = "15:04:05.999999Z07:00"
+	isoDateTimeLayout = "2006-01-02T15:04:05.999999Z07:00"
+)
+
+// MarshalJSON encodes the result set as an object with its columns, and its rows as arrays of values.
+//
+// Vertices are encoded as {"vid", "tags": {tag: {prop: value}}}, edges as {"name", "src", "dst",
+// "ranking", "properties"}, paths as {"nodes", "relationships"}, lists and sets as arrays, maps as
+// objects, geographies as GeoJSON geometries, dates, times and datetimes as ISO-8601 strings in the
+// timezone of the server, and durations as ISO-8601 durations.
+func (res ResultSet) MarshalJSON() ([]byte, error) {
+	rows := make([][]any, 0, res.GetRowSize())
+	for _, row := range res.GetRows() {
+		values := make([]any, 0, len(row.GetValues()))
+		for _, value := range row.GetValues() {
+			values = append(values, jsonValue(value, res.timezoneInfo))
+		}
+		rows = append(rows, values)
+	}
+
+	return json.Marshal(struct {
+		Columns []string `json:"columns"`
+		Rows    [][]any  `json:"rows"`
+	}{
+		Columns: res.GetColNames(),
+		Rows:    rows,
+	})
+}
+
+// WriteNDJSON writes the rows of the result set as newline delimited JSON, one object keyed by column per row,
+// with the values encoded as by MarshalJSON.
+func (res ResultSet) WriteNDJSON(w io.Writer) error {
+	colNames := res.GetColNames()
+	keys := make([][]byte, 0, len(colNames))
+	for _, colName := range colNames {
+		key, err := json.Marshal(colName)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	var buf bytes.Buffer
+	for rowIndex, row := range res.GetRows() {
+		if len(row.GetValues()) != len(keys) {
+			return fmt.Errorf("invalid row %d of %d values for %d columns", rowIndex, len(row.GetValues()), len(keys))
+		}
+		buf.Reset()
+		// The object is written by hand to keep the order of the columns
+		buf.WriteByte('{')
+		for i, value := range row.GetValues() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(keys[i])
+			buf.WriteByte(':')
+			encoded, err := json.Marshal(jsonValue(value, res.timezoneInfo))
+			if err != nil {
+				return err
+			}
+			buf.Write(encoded)
+		}
+		buf.WriteString("}\n")
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CSVOptions configures how WriteCSV writes a result set.
+type CSVOptions struct {
+	delimiter rune   // optional
+	header    bool   // optional
+	null      string // optional
+	useCRLF   bool   // optional
+}
+
+// CSVOption is a functional option for configuring CSVOptions.
+type CSVOption func(*CSVOptions)
+
+// WithCSVDelimiter sets the field delimiter, a comma by default.
+func WithCSVDelimiter(delimiter rune) func(*CSVOptions) {
+	return func(opts *CSVOptions) {
+		opts.delimiter = delimiter
+	}
+}
+
+// WithCSVHeader sets whether the column names are written as the first record, true by default.
+func WithCSVHeader(header bool) func(*CSVOptions) {
+	return func(opts *CSVOptions) {
+		opts.header = header
+	}
+}
+
+// WithCSVNull sets the field written for the null and empty values, an empty field by default.
+func WithCSVNull(null string) func(*CSVOptions) {
+	return func(opts *CSVOptions) {
+		opts.null = null
+	}
+}
+
+// WithCSVCRLF sets whether the records end with \r\n instead of \n.
+func WithCSVCRLF(useCRLF bool) func(*CSVOptions) {
+	return func(opts *CSVOptions) {
+		opts.useCRLF = useCRLF
+	}
+}
+
+// WriteCSV writes the result set as CSV, row by row, quoting the fields when needed.
+//
+// Strings are written as is, dates, times, datetimes and durations as ISO-8601 strings, geographies as WKT,
+// and vertices, edges, paths, lists, sets and maps as JSON, as encoded by MarshalJSON.
+func (res ResultSet) WriteCSV(w io.Writer, options ...CSVOption) error {
+	opts := CSVOptions{
+		delimiter: ',',
+		header:    true,
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = opts.delimiter
+	writer.UseCRLF = opts.useCRLF
+
+	if opts.header {
+		if err := writer.Write(res.GetColNames()); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, res.GetColSize())
+	for _, row := range res.GetRows() {
+		record = record[:0]
+		for _, value := range row.GetValues() {
+			field, err := csvField(value, res.timezoneInfo, opts.null)
+			if err != nil {
+				return err
+			}
+			record = append(record, field)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvField(value *nebula.Value, tzInfo timezoneInfo, null string) (string, error) {
+	switch {
+	case value == nil || value.IsSetNVal():
+		return null, nil
+	case value.IsSetSVal():
+		return string(value.GetSVal()), nil
+	case value.IsSetGgVal():
+		return toWKT(value.GetGgVal()), nil
+	}
+
+	switch v := jsonValue(value, tzInfo).(type) {
+	case nil:
+		return null, nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
+
+// jsonValue converts the value into a value encoded by encoding/json as documented by ResultSet.MarshalJSON.
+func jsonValue(value *nebula.Value, tzInfo timezoneInfo) any {
+	switch {
+	case value == nil || value.IsSetNVal():
+		return nil
+	case value.IsSetBVal():
+		return value.GetBVal()
+	case value.IsSetIVal():
+		return value.GetIVal()
+	case value.IsSetFVal():
+		f := value.GetFVal()
+		// NaN and infinities have no JSON number
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return f
+	case value.IsSetSVal():
+		return string(value.GetSVal())
+	case value.IsSetDVal():
+		return DateWrapper{value.GetDVal()}.ToTime().Format(isoDateLayout)
+	case value.IsSetTVal():
+		return TimeWrapper{value.GetTVal(), tzInfo}.ToTime().Format(isoTimeLayout)
+	case value.IsSetDtVal():
+		return DateTimeWrapper{value.GetDtVal(), tzInfo}.ToTime().Format(isoDateTimeLayout)
+	case value.IsSetDuVal():
+		return isoDuration(value.GetDuVal())
+	case value.IsSetVVal():
+		return jsonVertex(value.GetVVal(), tzInfo)
+	case value.IsSetEVal():
+		return jsonEdge(value.GetEVal(), tzInfo)
+	case value.IsSetPVal():
+		pathWrap, err := genPathWrapper(value.GetPVal(), tzInfo)
+		if err != nil {
+			return nil
+		}
+		nodes := make([]any, 0, len(pathWrap.GetNodes()))
+		for _, node := range pathWrap.GetNodes() {
+			nodes = append(nodes, jsonVertex(node.vertex, tzInfo))
+		}
+		relationships := make([]any, 0, len(pathWrap.GetRelationships()))
+		for _, relationship := range pathWrap.GetRelationships() {
+			relationships = append(relationships, jsonEdge(relationship.edge, tzInfo))
+		}
+		return map[string]any{
+			"nodes":         nodes,
+			"relationships": relationships,
+		}
+	case value.IsSetLVal():
+		return jsonValues(value.GetLVal().GetValues(), tzInfo)
+	case value.IsSetUVal():
+		return jsonValues(value.GetUVal().GetValues(), tzInfo)
+	case value.IsSetMVal():
+		return jsonProps(value.GetMVal().GetKvs(), tzInfo)
+	case value.IsSetGgVal():
+		return geoJSON(value.GetGgVal())
+	default:
+		return nil
+	}
+}
+
+func jsonValues(values []*nebula.Value, tzInfo timezoneInfo) []any {
+	items := make([]any, 0, len(values))
+	for _, value := range values {
+		items = append(items, jsonValue(value, tzInfo))
+	}
+	return items
+}
+
+func jsonProps(props map[string]*nebula.Value, tzInfo timezoneInfo) map[string]any {
+	items := make(map[string]any, len(props))
+	for key, value := range props {
+		items[key] = jsonValue(value, tzInfo)
+	}
+	return items
+}
+
+func jsonVertex(vertex *nebula.Vertex, tzInfo timezoneInfo) map[string]any {
+	tags := make(map[string]any, len(vertex.GetTags()))
+	for _, tag := range vertex.GetTags() {
+		tags[string(tag.GetName())] = jsonProps(tag.GetProps(), tzInfo)
+	}
+	return map[string]any{
+		"vid":  jsonValue(vertex.GetVid(), tzInfo),
+		"tags": tags,
+	}
+}
+
+func jsonEdge(edge *nebula.Edge, tzInfo timezoneInfo) map[string]any {
+	src, dst := edge.GetSrc(), edge.GetDst()
+	// A negative type is the reverse of the edge
+	if edge.GetType() < 0 {
+		src, dst = dst, src
+	}
+	return map[string]any{
+		"name":       string(edge.GetName()),
+		"src":        jsonValue(src, tzInfo),
+		"dst":        jsonValue(dst, tzInfo),
+		"ranking":    int64(edge.GetRanking()),
+		"properties": jsonProps(edge.GetProps(), tzInfo),
+	}
+}
+
+// geoJSON converts the geography into a GeoJSON geometry.
+func geoJSON(geo *nebula.Geography) map[string]any {
+	coordinates := func(coords []*nebula.Coordinate) [][]float64 {
+		points := make([][]float64, 0, len(coords))
+		for _, coord := range coords {
+			points = append(points, []float64{coord.GetX(), coord.GetY()})
+		}
+		return points
+	}
+
+	switch {
+	case geo.IsSetPtVal():
+		coord := geo.GetPtVal().GetCoord()
+		return map[string]any{"type": "Point", "coordinates": []float64{coord.GetX(), coord.GetY()}}
+	case geo.IsSetLsVal():
+		return map[string]any{"type": "LineString", "coordinates": coordinates(geo.GetLsVal().GetCoordList())}
+	case geo.IsSetPgVal():
+		rings := make([][][]float64, 0, len(geo.GetPgVal().GetCoordListList()))
+		for _, ring := range geo.GetPgVal().GetCoordListList() {
+			rings = append(rings, coordinates(ring))
+		}
+		return map[string]any{"type": "Polygon", "coordinates": rings}
+	default:
+		return nil
+	}
+}
+
+// isoDuration formats the duration as an ISO-8601 duration, e.g. P1MT3661.5S.
+func isoDuration(duration *nebula.Duration) string {
+	micros := duration.GetSeconds()*1000000 + int64(duration.GetMicroseconds())
+	sign := ""
+	if micros < 0 {
+		sign = "-"
+		micros = -micros
+	}
+	seconds := strconv.FormatInt(micros/1000000, 10)
+	if fraction := micros % 1000000; fraction != 0 {
+		seconds += fmt.Sprintf(".%06d", fraction)
+		for seconds[len(seconds)-1] == '0' {
+			seconds = seconds[:len(seconds)-1]
+		}
+	}
+	return fmt.Sprintf("P%dMT%s%sS", duration.GetMonths(), sign, seconds)
+}
@@ -0,0 +1,168 @@
+package nebula_sirius
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getExportResultSet(t *testing.T) *ResultSet {
+	f := 1.5
+	b := true
+	nullVal := nebula.NullType___NULL__
+	resp := &graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{
+				[]byte("id"), []byte("name"), []byte("score"), []byte("active"), []byte("note"),
+				[]byte("birthday"), []byte("wake_up"), []byte("created_at"), []byte("stay"),
+				[]byte("tags"), []byte("extra"), []byte("location"), []byte("v"), []byte("e"),
+			},
+			Rows: []*nebula.Row{{Values: []*nebula.Value{
+				setIVal(1),
+				{SVal: []byte(`Tim "The Big Fundamental", Duncan`)},
+				{FVal: &f},
+				{BVal: &b},
+				{NVal: &nullVal},
+				{DVal: &nebula.Date{Year: 1976, Month: 4, Day: 25}},
+				{TVal: &nebula.Time{Hour: 23, Minute: 30, Sec: 0, Microsec: 500000}},
+				{DtVal: &nebula.DateTime{Year: 2025, Month: 2, Day: 15, Hour: 6, Minute: 30, Sec: 5, Microsec: 123}},
+				{DuVal: &nebula.Duration{Seconds: 3661, Microseconds: 500000, Months: 1}},
+				{LVal: &nebula.NList{Values: []*nebula.Value{{SVal: []byte("player")}, setIVal(2)}}},
+				{MVal: &nebula.NMap{Kvs: map[string]*nebula.Value{"team": {SVal: []byte("Spurs")}}}},
+				{GgVal: &nebula.Geography{PtVal: &nebula.Point{Coord: &nebula.Coordinate{X: 29.5, Y: -98.4}}}},
+				{VVal: getVertex("player100", 1, 1)},
+				{EVal: getEdge("player100", "player101", 1)},
+			}}},
+		},
+	}
+	resultSet, err := genResultSet(resp, timezoneInfo{28800, []byte("Asia/Shanghai")})
+	require.NoError(t, err)
+	return resultSet
+}
+
+func TestResultSet_MarshalJSON(t *testing.T) {
+	encoded, err := getExportResultSet(t).MarshalJSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"columns": ["id", "name", "score", "active", "note", "birthday", "wake_up", "created_at", "stay",
+			"tags", "extra", "location", "v", "e"],
+		"rows": [[
+			1,
+			"Tim \"The Big Fundamental\", Duncan",
+			1.5,
+			true,
+			null,
+			"1976-04-25",
+			"07:30:00.5+08:00",
+			"2025-02-15T14:30:05.000123+08:00",
+			"P1MT3661.5S",
+			["player", 2],
+			{"team": "Spurs"},
+			{"type": "Point", "coordinates": [29.5, -98.4]},
+			{"vid": "player100", "tags": {"tag0": {"prop0": 0}}},
+			{"name": "classmate", "src": "player100", "dst": "player101", "ranking": 100, "properties": {"prop0": 0}}
+		]]
+	}`, string(encoded))
+}
+
+func TestResultSet_MarshalJSON_Path(t *testing.T) {
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("p")},
+			Rows:        []*nebula.Row{{Values: []*nebula.Value{{PVal: getPath("Tom", 2)}}}},
+		},
+	}, testTimezone)
+	require.NoError(t, err)
+
+	encoded, err := resultSet.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"relationships":[{"dst":"vertex0","name":"classmate"`)
+	assert.Contains(t, string(encoded), `{"dst":"vertex0","name":"classmate","properties":{"prop0":0,"prop1":1,"prop2":2,"prop3":3,"prop4":4},"ranking":100,"src":"vertex1"}`)
+}
+
+func TestResultSet_MarshalJSON_NaN(t *testing.T) {
+	nan := math.NaN()
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("f")},
+			Rows:        []*nebula.Row{{Values: []*nebula.Value{{FVal: &nan}}}},
+		},
+	}, testTimezone)
+	require.NoError(t, err)
+
+	encoded, err := resultSet.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"columns":["f"],"rows":[["NaN"]]}`, string(encoded))
+}
+
+func TestResultSet_WriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, getExportResultSet(t).WriteCSV(&buf))
+	assert.Equal(t,
+		"id,name,score,active,note,birthday,wake_up,created_at,stay,tags,extra,location,v,e\n"+
+			`1,"Tim ""The Big Fundamental"", Duncan",1.5,true,,1976-04-25,07:30:00.5+08:00,2025-02-15T14:30:05.000123+08:00,P1MT3661.5S,`+
+			`"[""player"",2]","{""team"":""Spurs""}",POINT(29.5 -98.4),`+
+			`"{""tags"":{""tag0"":{""prop0"":0}},""vid"":""player100""}",`+
+			`"{""dst"":""player101"",""name"":""classmate"",""properties"":{""prop0"":0},""ranking"":100,""src"":""player100""}"`+"\n",
+		buf.String())
+}
+
+func TestResultSet_WriteCSV_Options(t *testing.T) {
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("name"), []byte("age")},
+			Rows: []*nebula.Row{
+				{Values: []*nebula.Value{{SVal: []byte("Tim;Duncan")}, setIVal(42)}},
+				{Values: []*nebula.Value{{SVal: []byte("Tony")}, {}}},
+			},
+		},
+	}, testTimezone)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, resultSet.WriteCSV(&buf, WithCSVDelimiter(';'), WithCSVHeader(false), WithCSVNull(`\N`), WithCSVCRLF(true)))
+	assert.Equal(t, "\"Tim;Duncan\";42\r\nTony;\\N\r\n", buf.String())
+}
+
+func TestResultSet_WriteNDJSON(t *testing.T) {
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("name"), []byte("age")},
+			Rows: []*nebula.Row{
+				{Values: []*nebula.Value{{SVal: []byte("Tim")}, setIVal(42)}},
+				{Values: []*nebula.Value{{SVal: []byte("Tony")}, {}}},
+			},
+		},
+	}, testTimezone)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, resultSet.WriteNDJSON(&buf))
+	assert.Equal(t, "{\"name\":\"Tim\",\"age\":42}\n{\"name\":\"Tony\",\"age\":null}\n", buf.String())
+}
+
+func TestResultSet_WriteNDJSON_InvalidRow(t *testing.T) {
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("name")},
+			Rows: []*nebula.Row{
+				{Values: []*nebula.Value{{SVal: []byte("Tim")}, setIVal(42)}},
+			},
+		},
+	}, testTimezone)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.EqualError(t, resultSet.WriteNDJSON(&buf), "invalid row 0 of 2 values for 1 columns")
+}
@@ -0,0 +1,347 @@
+package nebula_sirius
+
+import (
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"slices"
+	"strings"
+)
+
+// TextSearchClient is an Elasticsearch client of the text search service used by the full-text indexes.
+type TextSearchClient struct {
+	Address  HostAddress
+	ConnType string // optional, http or https, http when empty
+	User     string // optional
+	Password string // optional
+}
+
+// FullTextIndex is a full-text index on string properties of a tag or edge type.
+type FullTextIndex struct {
+	Name       string
+	SchemaType IndexType
+	SchemaName string
+	Fields     []string
+	Analyzer   string // optional
+}
+
+// Listener is an Elasticsearch listener of a partition of a space.
+type Listener struct {
+	Address HostAddress
+	PartID  int32
+	Status  string // ONLINE, OFFLINE or UNKNOWN
+}
+
+// SignInTextService registers the Elasticsearch clients of the text search service,
+// replacing the ones registered before.
+func SignInTextService(ctx context.Context, metaClient meta.MetaService, clients ...TextSearchClient) error {
+	if len(clients) == 0 {
+		return fmt.Errorf("at least one text search client is required")
+	}
+
+	serviceClients := make([]*meta.ServiceClient, 0, len(clients))
+	for _, client := range clients {
+		if client.Address.Host == "" {
+			return fmt.Errorf("text search client host cannot be empty")
+		}
+		connType := strings.ToLower(client.ConnType)
+		if connType == "" {
+			connType = "http"
+		}
+		if connType != "http" && connType != "https" {
+			return fmt.Errorf("unsupported connection type %s of text search client %s", client.ConnType, client.Address.Host)
+		}
+
+		serviceClient := &meta.ServiceClient{
+			Host:     hostAddr(client.Address),
+			ConnType: []byte(connType),
+		}
+		if client.User != "" {
+			serviceClient.User = []byte(client.User)
+			serviceClient.Pwd = []byte(client.Password)
+		}
+		serviceClients = append(serviceClients, serviceClient)
+	}
+
+	resp, err := metaClient.SignInService(ctx, &meta.SignInServiceReq{
+		Type:    meta.ExternalServiceType_ELASTICSEARCH,
+		Clients: serviceClients,
+	})
+	return checkExecResp(resp, err, "sign in text service")
+}
+
+// SignOutTextService unregisters all the Elasticsearch clients of the text search service.
+func SignOutTextService(ctx context.Context, metaClient meta.MetaService) error {
+	resp, err := metaClient.SignOutService(ctx, &meta.SignOutServiceReq{Type: meta.ExternalServiceType_ELASTICSEARCH})
+	return checkExecResp(resp, err, "sign out text service")
+}
+
+// ListTextSearchClients returns the Elasticsearch clients of the text search service.
+func ListTextSearchClients(ctx context.Context, metaClient meta.MetaService) ([]TextSearchClient, error) {
+	resp, err := metaClient.ListServiceClients(ctx, &meta.ListServiceClientsReq{Type: meta.ExternalServiceType_ELASTICSEARCH})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list text search clients, error code: %s", resp.GetCode())
+	}
+
+	serviceClients := resp.GetClients()[meta.ExternalServiceType_ELASTICSEARCH]
+	clients := make([]TextSearchClient, 0, len(serviceClients))
+	for _, serviceClient := range serviceClients {
+		clients = append(clients, TextSearchClient{
+			Address:  hostAddress(serviceClient.GetHost()),
+			ConnType: string(serviceClient.GetConnType()),
+			User:     string(serviceClient.GetUser()),
+			Password: string(serviceClient.GetPwd()),
+		})
+	}
+	return clients, nil
+}
+
+// AddListener adds Elasticsearch listeners to the space, replicating its data to the text search service.
+func AddListener(ctx context.Context, metaClient meta.MetaService, spaceName string, hosts ...HostAddress) error {
+	if len(hosts) == 0 {
+		return fmt.Errorf("at least one listener host is required")
+	}
+
+	spaceID, err := GetSpaceID(ctx, metaClient, spaceName)
+	if err != nil {
+		return err
+	}
+
+	addrs := make([]*nebula.HostAddr, 0, len(hosts))
+	for _, host := range hosts {
+		if host.Host == "" {
+			return fmt.Errorf("listener host cannot be empty")
+		}
+		addrs = append(addrs, hostAddr(host))
+	}
+
+	resp, err := metaClient.AddListener(ctx, &meta.AddListenerReq{
+		SpaceID: spaceID,
+		Type:    meta.ListenerType_ELASTICSEARCH,
+		Hosts:   addrs,
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("add listener to space %s", spaceName))
+}
+
+// RemoveListener removes all the Elasticsearch listeners of the space.
+func RemoveListener(ctx context.Context, metaClient meta.MetaService, spaceName string) error {
+	spaceID, err := GetSpaceID(ctx, metaClient, spaceName)
+	if err != nil {
+		return err
+	}
+
+	resp, err := metaClient.RemoveListener(ctx, &meta.RemoveListenerReq{
+		SpaceID: spaceID,
+		Type:    meta.ListenerType_ELASTICSEARCH,
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("remove listener of space %s", spaceName))
+}
+
+// ListListeners returns the Elasticsearch listeners of the space along with their status, by partition.
+func ListListeners(ctx context.Context, metaClient meta.MetaService, spaceName string) ([]Listener, error) {
+	spaceID, err := GetSpaceID(ctx, metaClient, spaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := metaClient.ListListener(ctx, &meta.ListListenerReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list listeners of space %s, error code: %s", spaceName, resp.GetCode())
+	}
+
+	listeners := make([]Listener, 0, len(resp.GetListeners()))
+	for _, info := range resp.GetListeners() {
+		if info.GetType() != meta.ListenerType_ELASTICSEARCH {
+			continue
+		}
+		listeners = append(listeners, Listener{
+			Address: hostAddress(info.GetHost()),
+			PartID:  int32(info.GetPartID()),
+			Status:  info.GetStatus().String(),
+		})
+	}
+	return listeners, nil
+}
+
+// CreateFullTextIndex creates the full-text index in the space. Nebula requires the index name to start with
+// nebula_, and the space to have listeners and a text search service.
+func CreateFullTextIndex(ctx context.Context, metaClient meta.MetaService, spaceName string, index FullTextIndex) error {
+	if index.Name == "" {
+		return fmt.Errorf("index name cannot be empty")
+	}
+	if len(index.Fields) == 0 {
+		return fmt.Errorf("at least one property is required")
+	}
+
+	spaceID, err := GetSpaceID(ctx, metaClient, spaceName)
+	if err != nil {
+		return err
+	}
+	schemas, err := getSchemaIDs(ctx, metaClient, spaceID)
+	if err != nil {
+		return err
+	}
+
+	schemaID, err := schemas.idOf(index.SchemaType, index.SchemaName)
+	if err != nil {
+		return err
+	}
+
+	fields := make([][]byte, 0, len(index.Fields))
+	for _, field := range index.Fields {
+		fields = append(fields, []byte(field))
+	}
+
+	resp, err := metaClient.CreateFTIndex(ctx, &meta.CreateFTIndexReq{
+		FulltextIndexName: []byte(index.Name),
+		Index: &meta.FTIndex{
+			SpaceID:      spaceID,
+			DependSchema: schemaID,
+			Fields:       fields,
+			Analyzer:     []byte(index.Analyzer),
+		},
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("create full-text index %s", index.Name))
+}
+
+// DropFullTextIndex drops the full-text index of the space.
+func DropFullTextIndex(ctx context.Context, metaClient meta.MetaService, spaceName string, indexName string) error {
+	if indexName == "" {
+		return fmt.Errorf("index name cannot be empty")
+	}
+
+	spaceID, err := GetSpaceID(ctx, metaClient, spaceName)
+	if err != nil {
+		return err
+	}
+
+	resp, err := metaClient.DropFTIndex(ctx, &meta.DropFTIndexReq{
+		SpaceID:           spaceID,
+		FulltextIndexName: []byte(indexName),
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("drop full-text index %s", indexName))
+}
+
+// ListFullTextIndexes returns the full-text indexes of the space, sorted by name.
+func ListFullTextIndexes(ctx context.Context, metaClient meta.MetaService, spaceName string) ([]FullTextIndex, error) {
+	spaceID, err := GetSpaceID(ctx, metaClient, spaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := metaClient.ListFTIndexes(ctx, &meta.ListFTIndexesReq{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list full-text indexes, error code: %s", resp.GetCode())
+	}
+
+	// The meta service returns the full-text indexes of every space
+	var schemas *schemaIDs
+	indexes := make([]FullTextIndex, 0)
+	for name, ftIndex := range resp.GetIndexes() {
+		if ftIndex.GetSpaceID() != spaceID {
+			continue
+		}
+		if schemas == nil {
+			if schemas, err = getSchemaIDs(ctx, metaClient, spaceID); err != nil {
+				return nil, err
+			}
+		}
+
+		index := FullTextIndex{
+			Name:     name,
+			Analyzer: string(ftIndex.GetAnalyzer()),
+		}
+		index.SchemaType, index.SchemaName = schemas.nameOf(ftIndex.GetDependSchema())
+		for _, field := range ftIndex.GetFields() {
+			index.Fields = append(index.Fields, string(field))
+		}
+		indexes = append(indexes, index)
+	}
+
+	slices.SortFunc(indexes, func(a, b FullTextIndex) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return indexes, nil
+}
+
+// schemaIDs maps the tag and edge type names of a space to their IDs.
+type schemaIDs struct {
+	tags  map[string]nebula.TagID
+	edges map[string]nebula.EdgeType
+}
+
+func getSchemaIDs(ctx context.Context, metaClient meta.MetaService, spaceID nebula.GraphSpaceID) (*schemaIDs, error) {
+	tagsResp, err := metaClient.ListTags(ctx, &meta.ListTagsReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+	if tagsResp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list tags, error code: %s", tagsResp.GetCode())
+	}
+
+	edgesResp, err := metaClient.ListEdges(ctx, &meta.ListEdgesReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+	if edgesResp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list edges, error code: %s", edgesResp.GetCode())
+	}
+
+	schemas := &schemaIDs{
+		tags:  make(map[string]nebula.TagID, len(tagsResp.GetTags())),
+		edges: make(map[string]nebula.EdgeType, len(edgesResp.GetEdges())),
+	}
+	for _, tag := range tagsResp.GetTags() {
+		schemas.tags[string(tag.GetTagName())] = tag.GetTagID()
+	}
+	for _, edge := range edgesResp.GetEdges() {
+		schemas.edges[string(edge.GetEdgeName())] = edge.GetEdgeType()
+	}
+	return schemas, nil
+}
+
+func (s *schemaIDs) idOf(schemaType IndexType, name string) (*nebula.SchemaID, error) {
+	switch schemaType {
+	case IndexTypeTag:
+		id, ok := s.tags[name]
+		if !ok {
+			return nil, fmt.Errorf("tag %s not found", name)
+		}
+		return &nebula.SchemaID{TagID: &id}, nil
+	case IndexTypeEdge:
+		edgeType, ok := s.edges[name]
+		if !ok {
+			return nil, fmt.Errorf("edge %s not found", name)
+		}
+		return &nebula.SchemaID{EdgeType: &edgeType}, nil
+	default:
+		return nil, fmt.Errorf("invalid index type %s", schemaType)
+	}
+}
+
+func (s *schemaIDs) nameOf(schemaID *nebula.SchemaID) (IndexType, string) {
+	if schemaID.IsSetTagID() {
+		for name, id := range s.tags {
+			if id == schemaID.GetTagID() {
+				return IndexTypeTag, name
+			}
+		}
+		return IndexTypeTag, ""
+	}
+	for name, edgeType := range s.edges {
+		if edgeType == schemaID.GetEdgeType() {
+			return IndexTypeEdge, name
+		}
+	}
+	return IndexTypeEdge, ""
+}
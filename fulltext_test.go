@@ -0,0 +1,186 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func succeededExecResp() *meta.ExecResp {
+	return &meta.ExecResp{Code: nebula.ErrorCode_SUCCEEDED}
+}
+
+func expectSchemaIDs(metaClient *mocks.MetaService, ctx context.Context, spaceID nebula.GraphSpaceID) {
+	metaClient.On("ListTags", ctx, &meta.ListTagsReq{SpaceID: spaceID}).Return(&meta.ListTagsResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Tags: []*meta.TagItem{{TagID: 2, TagName: []byte("account")}},
+	}, nil).Once()
+	metaClient.On("ListEdges", ctx, &meta.ListEdgesReq{SpaceID: spaceID}).Return(&meta.ListEdgesResp{
+		Code:  nebula.ErrorCode_SUCCEEDED,
+		Edges: []*meta.EdgeItem{{EdgeType: 3, EdgeName: []byte("transfer")}},
+	}, nil).Once()
+}
+
+func TestSignInTextService(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("SignInService", ctx, &meta.SignInServiceReq{
+		Type: meta.ExternalServiceType_ELASTICSEARCH,
+		Clients: []*meta.ServiceClient{
+			{Host: &nebula.HostAddr{Host: "192.168.8.100", Port: 9200}, ConnType: []byte("http")},
+			{Host: &nebula.HostAddr{Host: "192.168.8.101", Port: 9200}, ConnType: []byte("https"), User: []byte("elastic"), Pwd: []byte("secret")},
+		},
+	}).Return(succeededExecResp(), nil).Once()
+
+	err := SignInTextService(ctx, metaClient,
+		TextSearchClient{Address: HostAddress{Host: "192.168.8.100", Port: 9200}},
+		TextSearchClient{Address: HostAddress{Host: "192.168.8.101", Port: 9200}, ConnType: "HTTPS", User: "elastic", Password: "secret"})
+	assert.NoError(t, err)
+}
+
+func TestSignInTextService_UnsupportedConnType(t *testing.T) {
+	metaClient := mocks.NewMetaService(t)
+
+	err := SignInTextService(context.Background(), metaClient,
+		TextSearchClient{Address: HostAddress{Host: "192.168.8.100", Port: 9200}, ConnType: "ftp"})
+	assert.EqualError(t, err, "unsupported connection type ftp of text search client 192.168.8.100")
+}
+
+func TestListTextSearchClients(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListServiceClients", ctx, &meta.ListServiceClientsReq{Type: meta.ExternalServiceType_ELASTICSEARCH}).
+		Return(&meta.ListServiceClientsResp{
+			Code: nebula.ErrorCode_SUCCEEDED,
+			Clients: map[meta.ExternalServiceType][]*meta.ServiceClient{
+				meta.ExternalServiceType_ELASTICSEARCH: {
+					{Host: &nebula.HostAddr{Host: "192.168.8.100", Port: 9200}, ConnType: []byte("http")},
+				},
+			},
+		}, nil).Once()
+
+	clients, err := ListTextSearchClients(ctx, metaClient)
+	assert.NoError(t, err)
+	assert.Equal(t, []TextSearchClient{{Address: HostAddress{Host: "192.168.8.100", Port: 9200}, ConnType: "http"}}, clients)
+}
+
+func TestAddListener(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("AddListener", ctx, &meta.AddListenerReq{
+		SpaceID: 1,
+		Type:    meta.ListenerType_ELASTICSEARCH,
+		Hosts:   []*nebula.HostAddr{{Host: "192.168.8.100", Port: 9789}},
+	}).Return(&meta.ExecResp{Code: nebula.ErrorCode_E_EXISTED}, nil).Once()
+
+	err := AddListener(ctx, metaClient, "bank", HostAddress{Host: "192.168.8.100", Port: 9789})
+	assert.EqualError(t, err, "failed to add listener to space bank, error code: E_EXISTED")
+}
+
+func TestListListeners(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("ListListener", ctx, &meta.ListListenerReq{SpaceID: 1}).Return(&meta.ListListenerResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Listeners: []*meta.ListenerInfo{
+			{Type: meta.ListenerType_ELASTICSEARCH, Host: &nebula.HostAddr{Host: "192.168.8.100", Port: 9789}, PartID: 1, Status: meta.HostStatus_ONLINE},
+			{Type: meta.ListenerType_ELASTICSEARCH, Host: &nebula.HostAddr{Host: "192.168.8.100", Port: 9789}, PartID: 2, Status: meta.HostStatus_OFFLINE},
+		},
+	}, nil).Once()
+
+	listeners, err := ListListeners(ctx, metaClient, "bank")
+	assert.NoError(t, err)
+	assert.Equal(t, []Listener{
+		{Address: HostAddress{Host: "192.168.8.100", Port: 9789}, PartID: 1, Status: "ONLINE"},
+		{Address: HostAddress{Host: "192.168.8.100", Port: 9789}, PartID: 2, Status: "OFFLINE"},
+	}, listeners)
+}
+
+func TestCreateFullTextIndex(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	edgeType := nebula.EdgeType(3)
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	expectSchemaIDs(metaClient, ctx, 1)
+	metaClient.On("CreateFTIndex", ctx, &meta.CreateFTIndexReq{
+		FulltextIndexName: []byte("nebula_transfer_memo"),
+		Index: &meta.FTIndex{
+			SpaceID:      1,
+			DependSchema: &nebula.SchemaID{EdgeType: &edgeType},
+			Fields:       [][]byte{[]byte("memo")},
+			Analyzer:     []byte("standard"),
+		},
+	}).Return(succeededExecResp(), nil).Once()
+
+	err := CreateFullTextIndex(ctx, metaClient, "bank", FullTextIndex{
+		Name:       "nebula_transfer_memo",
+		SchemaType: IndexTypeEdge,
+		SchemaName: "transfer",
+		Fields:     []string{"memo"},
+		Analyzer:   "standard",
+	})
+	assert.NoError(t, err)
+}
+
+func TestCreateFullTextIndex_SchemaNotFound(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	expectSchemaIDs(metaClient, ctx, 1)
+
+	err := CreateFullTextIndex(ctx, metaClient, "bank", FullTextIndex{
+		Name:       "nebula_customer_name",
+		SchemaType: IndexTypeTag,
+		SchemaName: "customer",
+		Fields:     []string{"name"},
+	})
+	assert.EqualError(t, err, "tag customer not found")
+}
+
+func TestListFullTextIndexes(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	tagID := nebula.TagID(2)
+	edgeType := nebula.EdgeType(3)
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("ListFTIndexes", ctx, &meta.ListFTIndexesReq{}).Return(&meta.ListFTIndexesResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Indexes: map[string]*meta.FTIndex{
+			"nebula_transfer_memo": {SpaceID: 1, DependSchema: &nebula.SchemaID{EdgeType: &edgeType}, Fields: [][]byte{[]byte("memo")}},
+			"nebula_account_name":  {SpaceID: 1, DependSchema: &nebula.SchemaID{TagID: &tagID}, Fields: [][]byte{[]byte("name")}, Analyzer: []byte("standard")},
+			"nebula_other_space":   {SpaceID: 5, DependSchema: &nebula.SchemaID{TagID: &tagID}, Fields: [][]byte{[]byte("name")}},
+		},
+	}, nil).Once()
+	expectSchemaIDs(metaClient, ctx, 1)
+
+	indexes, err := ListFullTextIndexes(ctx, metaClient, "bank")
+	assert.NoError(t, err)
+	assert.Equal(t, []FullTextIndex{
+		{Name: "nebula_account_name", SchemaType: IndexTypeTag, SchemaName: "account", Fields: []string{"name"}, Analyzer: "standard"},
+		{Name: "nebula_transfer_memo", SchemaType: IndexTypeEdge, SchemaName: "transfer", Fields: []string{"memo"}},
+	}, indexes)
+}
+
+func TestDropFullTextIndex(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("DropFTIndex", ctx, &meta.DropFTIndexReq{SpaceID: 1, FulltextIndexName: []byte("nebula_account_name")}).
+		Return(succeededExecResp(), nil).Once()
+
+	assert.NoError(t, DropFullTextIndex(ctx, metaClient, "bank", "nebula_account_name"))
+}
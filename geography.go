@@ -0,0 +1,397 @@
+package nebula_sirius
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Geometry is a geography value as a Go type: a GeoPoint, a GeoLineString or a GeoPolygon.
+//
+// A GeoPoint has the same layout as the Point type of github.com/paulmach/orb, so it converts to it
+// with orb.Point(point).
+type Geometry interface {
+	// GeometryType returns the type of the geometry, Point, LineString or Polygon.
+	GeometryType() string
+	// Geography returns the geometry as a nebula.Geography.
+	Geography() *nebula.Geography
+}
+
+// GeoPoint is a point of longitude X and latitude Y.
+type GeoPoint [2]float64
+
+// GeoLineString is a line of two or more points.
+type GeoLineString []GeoPoint
+
+// GeoRing is a closed line string, whose first and last points are the same.
+type GeoRing []GeoPoint
+
+// GeoPolygon is a polygon, an exterior ring followed by the rings of its holes.
+type GeoPolygon []GeoRing
+
+// NewGeoPoint creates the point of the given longitude and latitude.
+func NewGeoPoint(x, y float64) GeoPoint {
+	return GeoPoint{x, y}
+}
+
+// X returns the longitude of the point.
+func (p GeoPoint) X() float64 {
+	return p[0]
+}
+
+// Y returns the latitude of the point.
+func (p GeoPoint) Y() float64 {
+	return p[1]
+}
+
+// GeometryType returns Point.
+func (p GeoPoint) GeometryType() string {
+	return "Point"
+}
+
+// Geography returns the point as a nebula.Geography.
+func (p GeoPoint) Geography() *nebula.Geography {
+	return &nebula.Geography{PtVal: &nebula.Point{Coord: p.coordinate()}}
+}
+
+func (p GeoPoint) coordinate() *nebula.Coordinate {
+	return &nebula.Coordinate{X: p[0], Y: p[1]}
+}
+
+// GeometryType returns LineString.
+func (ls GeoLineString) GeometryType() string {
+	return "LineString"
+}
+
+// Geography returns the line string as a nebula.Geography.
+func (ls GeoLineString) Geography() *nebula.Geography {
+	return &nebula.Geography{LsVal: &nebula.LineString{CoordList: geoCoordinates(ls)}}
+}
+
+// GeometryType returns Polygon.
+func (pg GeoPolygon) GeometryType() string {
+	return "Polygon"
+}
+
+// Geography returns the polygon as a nebula.Geography.
+func (pg GeoPolygon) Geography() *nebula.Geography {
+	rings := make([][]*nebula.Coordinate, 0, len(pg))
+	for _, ring := range pg {
+		rings = append(rings, geoCoordinates(ring))
+	}
+	return &nebula.Geography{PgVal: &nebula.Polygon{CoordListList: rings}}
+}
+
+func geoCoordinates(points []GeoPoint) []*nebula.Coordinate {
+	coords := make([]*nebula.Coordinate, 0, len(points))
+	for _, point := range points {
+		coords = append(coords, point.coordinate())
+	}
+	return coords
+}
+
+func geoPoints(coords []*nebula.Coordinate) []GeoPoint {
+	points := make([]GeoPoint, 0, len(coords))
+	for _, coord := range coords {
+		points = append(points, GeoPoint{coord.GetX(), coord.GetY()})
+	}
+	return points
+}
+
+// NewGeographyValue creates the value of the geometry, e.g. for the parameters of ExecuteWithParameter.
+func NewGeographyValue(geometry Geometry) *nebula.Value {
+	return &nebula.Value{GgVal: geometry.Geography()}
+}
+
+// GeometryFromGeography converts the nebula.Geography into a Geometry.
+func GeometryFromGeography(geo *nebula.Geography) (Geometry, error) {
+	switch {
+	case geo == nil:
+		return nil, fmt.Errorf("geography is nil")
+	case geo.IsSetPtVal():
+		coord := geo.GetPtVal().GetCoord()
+		return GeoPoint{coord.GetX(), coord.GetY()}, nil
+	case geo.IsSetLsVal():
+		return GeoLineString(geoPoints(geo.GetLsVal().GetCoordList())), nil
+	case geo.IsSetPgVal():
+		polygon := make(GeoPolygon, 0, len(geo.GetPgVal().GetCoordListList()))
+		for _, ring := range geo.GetPgVal().GetCoordListList() {
+			polygon = append(polygon, geoPoints(ring))
+		}
+		return polygon, nil
+	default:
+		return nil, fmt.Errorf("geography has no shape")
+	}
+}
+
+// GeographyToWKT formats the geography as WKT, e.g. POINT(3 8), as printed by ValueWrapper.String.
+func GeographyToWKT(geo *nebula.Geography) string {
+	return toWKT(geo)
+}
+
+// GeographyToGeoJSON encodes the geography as a GeoJSON geometry, e.g. {"type":"Point","coordinates":[3,8]}.
+func GeographyToGeoJSON(geo *nebula.Geography) ([]byte, error) {
+	geometry := geoJSON(geo)
+	if geometry == nil {
+		return nil, fmt.Errorf("geography has no shape")
+	}
+	return json.Marshal(geometry)
+}
+
+// GeographyToWKB encodes the geography as little-endian WKB.
+func GeographyToWKB(geo *nebula.Geography) ([]byte, error) {
+	geometry, err := GeometryFromGeography(geo)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writePoints := func(points []GeoPoint) {
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(points)))
+		for _, point := range points {
+			_ = binary.Write(&buf, binary.LittleEndian, point)
+		}
+	}
+	buf.WriteByte(1)
+	switch g := geometry.(type) {
+	case GeoPoint:
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(wkbPoint))
+		_ = binary.Write(&buf, binary.LittleEndian, g)
+	case GeoLineString:
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(wkbLineString))
+		writePoints(g)
+	case GeoPolygon:
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(wkbPolygon))
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(g)))
+		for _, ring := range g {
+			writePoints(ring)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+const (
+	wkbPoint      = 1
+	wkbLineString = 2
+	wkbPolygon    = 3
+)
+
+// ParseWKB decodes a point, line string or polygon encoded as WKB, in either byte order.
+func ParseWKB(wkb []byte) (Geometry, error) {
+	if len(wkb) < 5 {
+		return nil, fmt.Errorf("wkb: too short")
+	}
+	var order binary.ByteOrder
+	switch wkb[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("wkb: invalid byte order %d", wkb[0])
+	}
+
+	r := bytes.NewReader(wkb[5:])
+	readPoints := func() ([]GeoPoint, error) {
+		var n uint32
+		if err := binary.Read(r, order, &n); err != nil {
+			return nil, fmt.Errorf("wkb: %w", err)
+		}
+		if int64(n)*16 > int64(r.Len()) {
+			return nil, fmt.Errorf("wkb: %d points exceed the data", n)
+		}
+		points := make([]GeoPoint, n)
+		if err := binary.Read(r, order, points); err != nil {
+			return nil, fmt.Errorf("wkb: %w", err)
+		}
+		return points, nil
+	}
+
+	var geometry Geometry
+	switch geometryType := order.Uint32(wkb[1:5]); geometryType {
+	case wkbPoint:
+		var point GeoPoint
+		if err := binary.Read(r, order, &point); err != nil {
+			return nil, fmt.Errorf("wkb: %w", err)
+		}
+		geometry = point
+	case wkbLineString:
+		points, err := readPoints()
+		if err != nil {
+			return nil, err
+		}
+		geometry = GeoLineString(points)
+	case wkbPolygon:
+		var n uint32
+		if err := binary.Read(r, order, &n); err != nil {
+			return nil, fmt.Errorf("wkb: %w", err)
+		}
+		if int64(n)*4 > int64(r.Len()) {
+			return nil, fmt.Errorf("wkb: %d rings exceed the data", n)
+		}
+		polygon := make(GeoPolygon, 0, n)
+		for i := uint32(0); i < n; i++ {
+			ring, err := readPoints()
+			if err != nil {
+				return nil, err
+			}
+			polygon = append(polygon, ring)
+		}
+		geometry = polygon
+	default:
+		return nil, fmt.Errorf("wkb: unsupported geometry type %d", geometryType)
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("wkb: %d trailing bytes", r.Len())
+	}
+	return geometry, nil
+}
+
+// ParseGeoJSON decodes a GeoJSON geometry of type Point, LineString or Polygon.
+func ParseGeoJSON(data []byte) (Geometry, error) {
+	var geoJSON struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &geoJSON); err != nil {
+		return nil, fmt.Errorf("geojson: %w", err)
+	}
+
+	var (
+		geometry Geometry
+		err      error
+	)
+	switch geoJSON.Type {
+	case "Point":
+		var position []float64
+		if err = json.Unmarshal(geoJSON.Coordinates, &position); err == nil {
+			geometry, err = geoJSONPoint(position)
+		}
+	case "LineString":
+		var positions [][]float64
+		if err = json.Unmarshal(geoJSON.Coordinates, &positions); err == nil {
+			var points []GeoPoint
+			points, err = geoJSONPoints(positions)
+			geometry = GeoLineString(points)
+		}
+	case "Polygon":
+		var rings [][][]float64
+		if err = json.Unmarshal(geoJSON.Coordinates, &rings); err == nil {
+			polygon := make(GeoPolygon, 0, len(rings))
+			for _, positions := range rings {
+				var points []GeoPoint
+				if points, err = geoJSONPoints(positions); err != nil {
+					break
+				}
+				polygon = append(polygon, GeoRing(points))
+			}
+			geometry = polygon
+		}
+	default:
+		return nil, fmt.Errorf("geojson: unsupported geometry type %q", geoJSON.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("geojson: invalid coordinates of %s: %w", geoJSON.Type, err)
+	}
+	return geometry, nil
+}
+
+// geoJSONPoint converts a GeoJSON position into a point, ignoring its altitude if any.
+func geoJSONPoint(position []float64) (GeoPoint, error) {
+	if len(position) < 2 {
+		return GeoPoint{}, fmt.Errorf("position has %d coordinates, at least 2 are required", len(position))
+	}
+	return NewGeoPoint(position[0], position[1]), nil
+}
+
+func geoJSONPoints(positions [][]float64) ([]GeoPoint, error) {
+	points := make([]GeoPoint, 0, len(positions))
+	for _, position := range positions {
+		point, err := geoJSONPoint(position)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// ParseWKT decodes a point, line string or polygon formatted as WKT, e.g. POINT(3 8) or LINESTRING(3 8, 4.7 73.23).
+func ParseWKT(wkt string) (Geometry, error) {
+	wkt = strings.TrimSpace(wkt)
+	open := strings.IndexByte(wkt, '(')
+	if open < 0 || !strings.HasSuffix(wkt, ")") {
+		return nil, fmt.Errorf("wkt: invalid geometry %q", wkt)
+	}
+	body := wkt[open+1 : len(wkt)-1]
+
+	switch geometryType := strings.ToUpper(strings.TrimSpace(wkt[:open])); geometryType {
+	case "POINT":
+		points, err := parseWKTPoints(body)
+		if err != nil {
+			return nil, err
+		}
+		if len(points) != 1 {
+			return nil, fmt.Errorf("wkt: point has %d coordinates", len(points))
+		}
+		return points[0], nil
+	case "LINESTRING":
+		points, err := parseWKTPoints(body)
+		if err != nil {
+			return nil, err
+		}
+		return GeoLineString(points), nil
+	case "POLYGON":
+		var polygon GeoPolygon
+		for body = strings.TrimSpace(body); body != ""; {
+			if body[0] != '(' {
+				return nil, fmt.Errorf("wkt: expected ( of a ring, got %q", body)
+			}
+			end := strings.IndexByte(body, ')')
+			if end < 0 {
+				return nil, fmt.Errorf("wkt: unclosed ring %q", body)
+			}
+			ring, err := parseWKTPoints(body[1:end])
+			if err != nil {
+				return nil, err
+			}
+			polygon = append(polygon, ring)
+
+			body = strings.TrimSpace(body[end+1:])
+			if strings.HasPrefix(body, ",") {
+				body = strings.TrimSpace(body[1:])
+			}
+		}
+		return polygon, nil
+	default:
+		return nil, fmt.Errorf("wkt: unsupported geometry type %q", geometryType)
+	}
+}
+
+func parseWKTPoints(s string) ([]GeoPoint, error) {
+	var points []GeoPoint
+	for _, coord := range strings.Split(s, ",") {
+		fields := strings.Fields(coord)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("wkt: invalid coordinate %q", strings.TrimSpace(coord))
+		}
+		x, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("wkt: invalid coordinate %q", strings.TrimSpace(coord))
+		}
+		y, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("wkt: invalid coordinate %q", strings.TrimSpace(coord))
+		}
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return nil, fmt.Errorf("wkt: invalid coordinate %q", strings.TrimSpace(coord))
+		}
+		points = append(points, GeoPoint{x, y})
+	}
+	return points, nil
+}
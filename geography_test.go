@@ -0,0 +1,235 @@
+package nebula_sirius
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometry_Conversions(t *testing.T) {
+	testCases := []struct {
+		geometry Geometry
+		wkt      string
+		geoJSON  string
+	}{
+		{
+			geometry: NewGeoPoint(3, 8.5),
+			wkt:      "POINT(3 8.5)",
+			geoJSON:  `{"type":"Point","coordinates":[3,8.5]}`,
+		},
+		{
+			geometry: GeoLineString{{3, 8}, {4.7, 73.23}},
+			wkt:      "LINESTRING(3 8, 4.7 73.23)",
+			geoJSON:  `{"type":"LineString","coordinates":[[3,8],[4.7,73.23]]}`,
+		},
+		{
+			geometry: GeoPolygon{{{0, 1}, {1, 2}, {2, 3}, {0, 1}}, {{0.5, 1}, {1, 1.5}, {0.5, 1}}},
+			wkt:      "POLYGON((0 1, 1 2, 2 3, 0 1), (0.5 1, 1 1.5, 0.5 1))",
+			geoJSON:  `{"type":"Polygon","coordinates":[[[0,1],[1,2],[2,3],[0,1]],[[0.5,1],[1,1.5],[0.5,1]]]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.geometry.GeometryType(), func(t *testing.T) {
+			geo := tc.geometry.Geography()
+
+			assert.Equal(t, tc.wkt, GeographyToWKT(geo))
+			parsed, err := ParseWKT(tc.wkt)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.geometry, parsed)
+
+			geoJSON, err := GeographyToGeoJSON(geo)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.geoJSON, string(geoJSON))
+			parsed, err = ParseGeoJSON(geoJSON)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.geometry, parsed)
+
+			wkb, err := GeographyToWKB(geo)
+			assert.NoError(t, err)
+			parsed, err = ParseWKB(wkb)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.geometry, parsed)
+
+			converted, err := GeometryFromGeography(geo)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.geometry, converted)
+			assert.Equal(t, &nebula.Value{GgVal: geo}, NewGeographyValue(tc.geometry))
+		})
+	}
+}
+
+func TestParseWKB(t *testing.T) {
+	// POINT(1 2) in big endian
+	wkb := []byte{0, 0, 0, 0, 1}
+	wkb = binary.BigEndian.AppendUint64(wkb, math.Float64bits(1))
+	wkb = binary.BigEndian.AppendUint64(wkb, math.Float64bits(2))
+	point, err := ParseWKB(wkb)
+	assert.NoError(t, err)
+	assert.Equal(t, NewGeoPoint(1, 2), point)
+
+	_, err = ParseWKB(append(wkb, 0))
+	assert.EqualError(t, err, "wkb: 1 trailing bytes")
+	_, err = ParseWKB([]byte{1, 2, 0, 0, 0, 0xff, 0xff, 0xff, 0xff})
+	assert.EqualError(t, err, "wkb: 4294967295 points exceed the data")
+	_, err = ParseWKB([]byte{1, 7, 0, 0, 0})
+	assert.EqualError(t, err, "wkb: unsupported geometry type 7")
+	_, err = ParseWKB([]byte{2, 1, 0, 0, 0})
+	assert.EqualError(t, err, "wkb: invalid byte order 2")
+}
+
+func TestParseWKT_Invalid(t *testing.T) {
+	_, err := ParseWKT("POINT(1)")
+	assert.EqualError(t, err, `wkt: invalid coordinate "1"`)
+	_, err = ParseWKT("POINT(1 2, 3 4)")
+	assert.EqualError(t, err, "wkt: point has 2 coordinates")
+	_, err = ParseWKT("MULTIPOINT((1 2))")
+	assert.EqualError(t, err, `wkt: unsupported geometry type "MULTIPOINT"`)
+	_, err = ParseWKT("POINT 1 2")
+	assert.EqualError(t, err, `wkt: invalid geometry "POINT 1 2"`)
+
+	point, err := ParseWKT(" point ( 1.5  -2 ) ")
+	assert.NoError(t, err)
+	assert.Equal(t, NewGeoPoint(1.5, -2), point)
+}
+
+func TestParseGeoJSON_Invalid(t *testing.T) {
+	_, err := ParseGeoJSON([]byte(`{"type":"MultiPoint","coordinates":[[1,2]]}`))
+	assert.EqualError(t, err, `geojson: unsupported geometry type "MultiPoint"`)
+	_, err = ParseGeoJSON([]byte(`{"type":"Point","coordinates":[[1,2]]}`))
+	assert.ErrorContains(t, err, "geojson: invalid coordinates of Point")
+	_, err = ParseGeoJSON([]byte(`{"type":"Point","coordinates":[1]}`))
+	assert.EqualError(t, err, "geojson: invalid coordinates of Point: position has 1 coordinates, at least 2 are required")
+	_, err = ParseGeoJSON([]byte(`{"type":"LineString","coordinates":[[1,2],[3]]}`))
+	assert.EqualError(t, err, "geojson: invalid coordinates of LineString: position has 1 coordinates, at least 2 are required")
+	_, err = ParseGeoJSON([]byte(`{"type":"Polygon","coordinates":[[[0,0],[1,0],[],[0,0]]]}`))
+	assert.EqualError(t, err, "geojson: invalid coordinates of Polygon: position has 0 coordinates, at least 2 are required")
+}
+
+func TestAsGeometry(t *testing.T) {
+	value := ValueWrapper{value: NewGeographyValue(NewGeoPoint(1, 2)), timezoneInfo: testTimezone}
+	geometry, err := value.AsGeometry()
+	assert.NoError(t, err)
+	assert.Equal(t, NewGeoPoint(1, 2), geometry)
+
+	_, err = ValueWrapper{value: setIVal(1), timezoneInfo: testTimezone}.AsGeometry()
+	assert.EqualError(t, err, "failed to convert value int to Geometry, value is not an geography")
+}
+
+func TestScanGeometry(t *testing.T) {
+	type location struct {
+		Name  string        `nebula:"name"`
+		Point GeoPoint      `nebula:"point"`
+		Route GeoLineString `nebula:"route"`
+		Area  Geometry      `nebula:"area"`
+	}
+	type place struct {
+		Name  string   `nebula:"name"`
+		Point GeoPoint `nebula:"point"`
+	}
+	type placeRow struct {
+		Places []place `nebula:"places"`
+	}
+
+	area := GeoPolygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("name"), []byte("point"), []byte("route"), []byte("area")},
+			Rows: []*nebula.Row{
+				{Values: []*nebula.Value{
+					{SVal: []byte("depot")},
+					NewGeographyValue(NewGeoPoint(29.5, -98.4)),
+					NewGeographyValue(GeoLineString{{0, 0}, {1, 1}}),
+					NewGeographyValue(area),
+				}},
+				{Values: []*nebula.Value{{SVal: []byte("unknown")}, {NVal: nebula.NullTypePtr(nebula.NullType___NULL__)}, {}, {}}},
+			},
+		},
+	}, testTimezone)
+	require.NoError(t, err)
+
+	var locations []location
+	assert.NoError(t, resultSet.Scan(&locations))
+	assert.Equal(t, []location{
+		{Name: "depot", Point: NewGeoPoint(29.5, -98.4), Route: GeoLineString{{0, 0}, {1, 1}}, Area: area},
+		{Name: "unknown"},
+	}, locations)
+
+	type mismatch struct {
+		Point GeoPoint `nebula:"route"`
+	}
+	var mismatches []mismatch
+	assert.EqualError(t, resultSet.Scan(&mismatches), "scan: geography LineString cannot be scanned into nebula_sirius.GeoPoint")
+
+	type notGeography struct {
+		Point GeoPoint `nebula:"name"`
+	}
+	var notGeographies []notGeography
+	assert.EqualError(t, resultSet.Scan(&notGeographies), "scan: value of type string is not a geography")
+
+	vertex := &nebula.Vertex{
+		Vid: &nebula.Value{SVal: []byte("place1")},
+		Tags: []*nebula.Tag{{
+			Name: []byte("place"),
+			Props: map[string]*nebula.Value{
+				"name":  {SVal: []byte("depot")},
+				"point": NewGeographyValue(NewGeoPoint(1, 2)),
+			},
+		}},
+	}
+	resultSet, err = genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("places")},
+			Rows:        []*nebula.Row{{Values: []*nebula.Value{{LVal: &nebula.NList{Values: []*nebula.Value{{VVal: vertex}}}}}}},
+		},
+	}, testTimezone)
+	require.NoError(t, err)
+
+	var placeRows []placeRow
+	assert.NoError(t, resultSet.Scan(&placeRows))
+	assert.Equal(t, []placeRow{{Places: []place{{Name: "depot", Point: NewGeoPoint(1, 2)}}}}, placeRows)
+}
+
+func TestScanGeometry_Pointer(t *testing.T) {
+	type location struct {
+		Point *GeoPoint   `nebula:"point"`
+		Area  *GeoPolygon `nebula:"area"`
+		Shape *Geometry   `nebula:"shape"`
+	}
+
+	area := GeoPolygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("point"), []byte("area"), []byte("shape")},
+			Rows: []*nebula.Row{
+				{Values: []*nebula.Value{
+					NewGeographyValue(NewGeoPoint(29.5, -98.4)),
+					NewGeographyValue(area),
+					NewGeographyValue(GeoLineString{{0, 0}, {1, 1}}),
+				}},
+				{Values: []*nebula.Value{{NVal: nebula.NullTypePtr(nebula.NullType___NULL__)}, {}, {}}},
+			},
+		},
+	}, testTimezone)
+	require.NoError(t, err)
+
+	point := NewGeoPoint(29.5, -98.4)
+	var shape Geometry = GeoLineString{{0, 0}, {1, 1}}
+	var locations []location
+	assert.NoError(t, resultSet.Scan(&locations))
+	assert.Equal(t, []location{{Point: &point, Area: &area, Shape: &shape}, {}}, locations)
+
+	type mismatch struct {
+		Point *GeoPoint `nebula:"area"`
+	}
+	var mismatches []mismatch
+	assert.EqualError(t, resultSet.Scan(&mismatches), "scan: geography Polygon cannot be scanned into nebula_sirius.GeoPoint")
+}
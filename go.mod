@@ -7,6 +7,7 @@ go 1.23
 require (
 	github.com/apache/thrift v0.21.0
 	github.com/jolestar/go-commons-pool v2.0.0+incompatible
+	github.com/peterh/liner v1.2.2
 	github.com/stretchr/testify v1.10.0
 	golang.org/x/net v0.34.0
 )
@@ -14,8 +15,10 @@ require (
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/fortytw2/leaktest v1.3.0 // indirect
+	github.com/mattn/go-runewidth v0.0.3 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
+	golang.org/x/sys v0.29.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,74 @@
+package nebula_sirius
+
+import (
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+)
+
+// Rebuild statuses reported by ListIndexStatus
+const (
+	IndexStatusQueue    = "QUEUE"
+	IndexStatusRunning  = "RUNNING"
+	IndexStatusFinished = "FINISHED"
+	IndexStatusFailed   = "FAILED"
+	IndexStatusStopped  = "STOPPED"
+)
+
+// RebuildIndexAndWait submits a job rebuilding the tag or edge index of the space through the meta service,
+// and waits for the job with JobClient.Wait. It returns the last state of the job, and an error if the job
+// FAILED or was STOPPED.
+func RebuildIndexAndWait(ctx context.Context, metaClient meta.MetaService, spaceName string, indexType IndexType, indexName string, options ...WaitOption) (*Job, error) {
+	if indexName == "" {
+		return nil, fmt.Errorf("index name cannot be empty")
+	}
+
+	jobType := meta.JobType_REBUILD_TAG_INDEX
+	switch indexType {
+	case IndexTypeTag:
+	case IndexTypeEdge:
+		jobType = meta.JobType_REBUILD_EDGE_INDEX
+	default:
+		return nil, fmt.Errorf("invalid index type %s", indexType)
+	}
+
+	jobs := NewJobClient(metaClient, spaceName)
+	jobID, err := jobs.Submit(ctx, jobType, indexName)
+	if err != nil {
+		return nil, err
+	}
+	return jobs.Wait(ctx, jobID, options...)
+}
+
+// ListIndexStatus returns the rebuild status of the tag or edge indexes of the space by index name,
+// e.g. IndexStatusFinished.
+func ListIndexStatus(ctx context.Context, metaClient meta.MetaService, spaceName string, indexType IndexType) (map[string]string, error) {
+	spaceID, err := GetSpaceID(ctx, metaClient, spaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &meta.ListIndexStatusReq{SpaceID: spaceID}
+	var resp *meta.ListIndexStatusResp
+	switch indexType {
+	case IndexTypeTag:
+		resp, err = metaClient.ListTagIndexStatus(ctx, req)
+	case IndexTypeEdge:
+		resp, err = metaClient.ListEdgeIndexStatus(ctx, req)
+	default:
+		return nil, fmt.Errorf("invalid index type %s", indexType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list %s index status, error code: %s", indexType, resp.GetCode())
+	}
+
+	statuses := make(map[string]string, len(resp.GetStatuses()))
+	for _, status := range resp.GetStatuses() {
+		statuses[string(status.GetName())] = string(status.GetStatus())
+	}
+	return statuses, nil
+}
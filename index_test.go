@@ -0,0 +1,118 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func expectGetSpace(metaClient *mocks.MetaService, ctx context.Context, spaceName string, spaceID nebula.GraphSpaceID) {
+	metaClient.On("GetSpace", ctx, &meta.GetSpaceReq{SpaceName: []byte(spaceName)}).Return(&meta.GetSpaceResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Item: &meta.SpaceItem{SpaceID: spaceID},
+	}, nil).Once()
+}
+
+func showJobResp(jobID int32, jobType meta.JobType, status meta.JobStatus) *meta.AdminJobResp {
+	return &meta.AdminJobResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Result_: &meta.AdminJobResult_{
+			JobDesc: []*meta.JobDesc{{SpaceID: 1, JobID: jobID, Type: jobType, Status: status}},
+		},
+	}
+}
+
+func TestRebuildIndexAndWait(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	jobID := int32(7)
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{
+		SpaceID: 1,
+		Op:      meta.JobOp_ADD,
+		Type:    meta.JobType_REBUILD_TAG_INDEX,
+		Paras:   [][]byte{[]byte("account_name")},
+	}).Return(&meta.AdminJobResp{
+		Code:    nebula.ErrorCode_SUCCEEDED,
+		Result_: &meta.AdminJobResult_{JobID: &jobID},
+	}, nil).Once()
+
+	showReq := &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_SHOW, Type: meta.JobType_UNKNOWN, Paras: [][]byte{[]byte("7")}}
+	metaClient.On("RunAdminJob", ctx, showReq).Return(showJobResp(jobID, meta.JobType_REBUILD_TAG_INDEX, meta.JobStatus_QUEUE), nil).Once()
+	metaClient.On("RunAdminJob", ctx, showReq).Return(showJobResp(jobID, meta.JobType_REBUILD_TAG_INDEX, meta.JobStatus_RUNNING), nil).Once()
+	metaClient.On("RunAdminJob", ctx, showReq).Return(showJobResp(jobID, meta.JobType_REBUILD_TAG_INDEX, meta.JobStatus_FINISHED), nil).Once()
+
+	job, err := RebuildIndexAndWait(ctx, metaClient, "bank", IndexTypeTag, "account_name", fastWait)
+	assert.NoError(t, err)
+	assert.Equal(t, meta.JobStatus_FINISHED, job.Status)
+}
+
+func TestRebuildIndexAndWait_Failed(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	jobID := int32(8)
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{
+		SpaceID: 1,
+		Op:      meta.JobOp_ADD,
+		Type:    meta.JobType_REBUILD_EDGE_INDEX,
+		Paras:   [][]byte{[]byte("transfer_amount")},
+	}).Return(&meta.AdminJobResp{
+		Code:    nebula.ErrorCode_SUCCEEDED,
+		Result_: &meta.AdminJobResult_{JobID: &jobID},
+	}, nil).Once()
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_SHOW, Type: meta.JobType_UNKNOWN, Paras: [][]byte{[]byte("8")}}).
+		Return(showJobResp(jobID, meta.JobType_REBUILD_EDGE_INDEX, meta.JobStatus_FAILED), nil).Once()
+
+	job, err := RebuildIndexAndWait(ctx, metaClient, "bank", IndexTypeEdge, "transfer_amount", fastWait)
+	assert.EqualError(t, err, "REBUILD_EDGE_INDEX job 8 is FAILED, error code: SUCCEEDED")
+	assert.Equal(t, meta.JobStatus_FAILED, job.Status)
+}
+
+func TestRebuildIndexAndWait_ContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	metaClient := mocks.NewMetaService(t)
+
+	jobID := int32(9)
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{
+		SpaceID: 1,
+		Op:      meta.JobOp_ADD,
+		Type:    meta.JobType_REBUILD_TAG_INDEX,
+		Paras:   [][]byte{[]byte("account_name")},
+	}).Return(&meta.AdminJobResp{
+		Code:    nebula.ErrorCode_SUCCEEDED,
+		Result_: &meta.AdminJobResult_{JobID: &jobID},
+	}, nil).Once()
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_SHOW, Type: meta.JobType_UNKNOWN, Paras: [][]byte{[]byte("9")}}).
+		Return(showJobResp(jobID, meta.JobType_REBUILD_TAG_INDEX, meta.JobStatus_RUNNING), nil)
+
+	_, err := RebuildIndexAndWait(ctx, metaClient, "bank", IndexTypeTag, "account_name", fastWait)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestListIndexStatus(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("ListTagIndexStatus", ctx, &meta.ListIndexStatusReq{SpaceID: 1}).Return(&meta.ListIndexStatusResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Statuses: []*meta.IndexStatus{
+			{Name: []byte("account_name"), Status: []byte(IndexStatusFinished)},
+			{Name: []byte("account_age"), Status: []byte(IndexStatusRunning)},
+		},
+	}, nil).Once()
+
+	statuses, err := ListIndexStatus(ctx, metaClient, "bank", IndexTypeTag)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"account_name": IndexStatusFinished, "account_age": IndexStatusRunning}, statuses)
+}
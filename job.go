@@ -0,0 +1,230 @@
+package nebula_sirius
+
+import (
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Job is an admin job of a space, e.g. COMPACT, FLUSH, STATS or DATA_BALANCE.
+type Job struct {
+	ID        int32
+	SpaceID   nebula.GraphSpaceID
+	Type      meta.JobType
+	Params    []string
+	Status    meta.JobStatus
+	StartTime time.Time // zero until the job is started
+	StopTime  time.Time // zero until the job is done
+	Code      nebula.ErrorCode
+	Tasks     []JobTask // only set by JobClient.Get and JobClient.Wait
+}
+
+// JobTask is the part of a job run by a storage host.
+type JobTask struct {
+	ID        int32
+	Host      HostAddress
+	Status    meta.JobStatus
+	StartTime time.Time
+	StopTime  time.Time
+	Code      nebula.ErrorCode
+}
+
+// IsDone returns whether the job is FINISHED, FAILED or STOPPED.
+func (j *Job) IsDone() bool {
+	return isJobDone(j.Status)
+}
+
+// Progress returns the number of done tasks of the job, and its number of tasks.
+func (j *Job) Progress() (done int, total int) {
+	for _, task := range j.Tasks {
+		if isJobDone(task.Status) {
+			done++
+		}
+	}
+	return done, len(j.Tasks)
+}
+
+// JobClient submits the admin jobs of a space and follows them through the meta service.
+type JobClient struct {
+	metaClient meta.MetaService    // required
+	spaceName  string              // required
+	spaceID    nebula.GraphSpaceID // resolved on first use
+	mu         sync.Mutex          // guards spaceID
+}
+
+// NewJobClient creates a new JobClient of the given space.
+func NewJobClient(metaClient meta.MetaService, spaceName string) *JobClient {
+	return &JobClient{
+		metaClient: metaClient,
+		spaceName:  spaceName,
+	}
+}
+
+// Submit submits a job of the given type and returns its ID, e.g. meta.JobType_STATS.
+// The params are the ones of the SUBMIT JOB statement, if any.
+func (c *JobClient) Submit(ctx context.Context, jobType meta.JobType, params ...string) (int32, error) {
+	resp, err := c.runAdminJob(ctx, meta.JobOp_ADD, jobType, params...)
+	if err != nil {
+		return 0, err
+	}
+	if !resp.GetResult_().IsSetJobID() {
+		return 0, fmt.Errorf("no job ID returned for the %s job", jobType)
+	}
+	return resp.GetResult_().GetJobID(), nil
+}
+
+// Get returns the job along with its tasks.
+func (c *JobClient) Get(ctx context.Context, jobID int32) (*Job, error) {
+	resp, err := c.runAdminJob(ctx, meta.JobOp_SHOW, meta.JobType_UNKNOWN, strconv.Itoa(int(jobID)))
+	if err != nil {
+		return nil, err
+	}
+
+	descs := resp.GetResult_().GetJobDesc()
+	if len(descs) == 0 {
+		return nil, fmt.Errorf("job %d not found", jobID)
+	}
+
+	job := jobFromDesc(descs[0])
+	for _, taskDesc := range resp.GetResult_().GetTaskDesc() {
+		job.Tasks = append(job.Tasks, JobTask{
+			ID:        taskDesc.GetTaskID(),
+			Host:      hostAddress(taskDesc.GetHost()),
+			Status:    taskDesc.GetStatus(),
+			StartTime: jobTime(taskDesc.GetStartTime()),
+			StopTime:  jobTime(taskDesc.GetStopTime()),
+			Code:      taskDesc.GetCode(),
+		})
+	}
+	return &job, nil
+}
+
+// List returns the jobs of the space, without their tasks.
+func (c *JobClient) List(ctx context.Context) ([]Job, error) {
+	resp, err := c.runAdminJob(ctx, meta.JobOp_SHOW_All, meta.JobType_UNKNOWN)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(resp.GetResult_().GetJobDesc()))
+	for _, desc := range resp.GetResult_().GetJobDesc() {
+		jobs = append(jobs, jobFromDesc(desc))
+	}
+	return jobs, nil
+}
+
+// Wait polls the job with an exponential backoff until it is done, or until ctx is done.
+// It returns the last state of the job, and an error if the job FAILED or was STOPPED.
+func (c *JobClient) Wait(ctx context.Context, jobID int32, options ...WaitOption) (*Job, error) {
+	opts := newWaitOptions(options)
+	interval := opts.initialInterval
+	for {
+		job, err := c.Get(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch job.Status {
+		case meta.JobStatus_FINISHED:
+			return job, nil
+		case meta.JobStatus_FAILED, meta.JobStatus_STOPPED:
+			return job, fmt.Errorf("%s job %d is %s, error code: %s", job.Type, jobID, job.Status, job.Code)
+		}
+
+		if interval, err = backoff(ctx, opts, interval); err != nil {
+			done, total := job.Progress()
+			return job, fmt.Errorf("%s job %d is not finished: %w, last status: %s, %d/%d tasks done",
+				job.Type, jobID, err, job.Status, done, total)
+		}
+	}
+}
+
+// Stop stops the queued or running job.
+func (c *JobClient) Stop(ctx context.Context, jobID int32) error {
+	_, err := c.runAdminJob(ctx, meta.JobOp_STOP, meta.JobType_UNKNOWN, strconv.Itoa(int(jobID)))
+	return err
+}
+
+// Recover queues the given failed or stopped jobs again, or all of them when no job ID is given.
+// It returns the number of recovered jobs.
+func (c *JobClient) Recover(ctx context.Context, jobIDs ...int32) (int32, error) {
+	params := make([]string, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		params = append(params, strconv.Itoa(int(jobID)))
+	}
+
+	resp, err := c.runAdminJob(ctx, meta.JobOp_RECOVER, meta.JobType_UNKNOWN, params...)
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetResult_().GetRecoveredJobNum(), nil
+}
+
+func (c *JobClient) runAdminJob(ctx context.Context, op meta.JobOp, jobType meta.JobType, params ...string) (*meta.AdminJobResp, error) {
+	spaceID, err := c.getSpaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paras := make([][]byte, 0, len(params))
+	for _, param := range params {
+		paras = append(paras, []byte(param))
+	}
+
+	resp, err := c.metaClient.RunAdminJob(ctx, &meta.AdminJobReq{
+		SpaceID: spaceID,
+		Op:      op,
+		Type:    jobType,
+		Paras:   paras,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to run admin job operation %s in space %s, error code: %s", op, c.spaceName, resp.GetCode())
+	}
+	return resp, nil
+}
+
+// getSpaceID returns the ID of the space, resolved on first use. A failed lookup is retried by the next call.
+func (c *JobClient) getSpaceID(ctx context.Context) (nebula.GraphSpaceID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.spaceID == 0 {
+		spaceID, err := GetSpaceID(ctx, c.metaClient, c.spaceName)
+		if err != nil {
+			return 0, err
+		}
+		c.spaceID = spaceID
+	}
+	return c.spaceID, nil
+}
+
+func jobFromDesc(desc *meta.JobDesc) Job {
+	return Job{
+		ID:        desc.GetJobID(),
+		SpaceID:   desc.GetSpaceID(),
+		Type:      desc.GetType(),
+		Params:    desc.GetParas(),
+		Status:    desc.GetStatus(),
+		StartTime: jobTime(desc.GetStartTime()),
+		StopTime:  jobTime(desc.GetStopTime()),
+		Code:      desc.GetCode(),
+	}
+}
+
+// jobTime converts the seconds since epoch of the meta service into a time, zero when not set.
+func jobTime(seconds int64) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+func isJobDone(status meta.JobStatus) bool {
+	return status == meta.JobStatus_FINISHED || status == meta.JobStatus_FAILED || status == meta.JobStatus_STOPPED
+}
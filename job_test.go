@@ -0,0 +1,162 @@
+package nebula_sirius
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func showJobReq(jobID string) *meta.AdminJobReq {
+	return &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_SHOW, Type: meta.JobType_UNKNOWN, Paras: [][]byte{[]byte(jobID)}}
+}
+
+func showStatsJobResp(status meta.JobStatus, taskStatuses ...meta.JobStatus) *meta.AdminJobResp {
+	resp := &meta.AdminJobResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Result_: &meta.AdminJobResult_{
+			JobDesc: []*meta.JobDesc{{SpaceID: 1, JobID: 3, Type: meta.JobType_STATS, Status: status, StartTime: 1700000000}},
+		},
+	}
+	for i, taskStatus := range taskStatuses {
+		resp.Result_.TaskDesc = append(resp.Result_.TaskDesc, &meta.TaskDesc{
+			SpaceID: 1,
+			JobID:   3,
+			TaskID:  int32(i),
+			Host:    &nebula.HostAddr{Host: "storaged" + string(rune('0'+i)), Port: 9779},
+			Status:  taskStatus,
+		})
+	}
+	return resp
+}
+
+func TestJobClient_SubmitAndWait(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	jobID := int32(3)
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_ADD, Type: meta.JobType_STATS, Paras: [][]byte{}}).
+		Return(&meta.AdminJobResp{
+			Code:    nebula.ErrorCode_SUCCEEDED,
+			Result_: &meta.AdminJobResult_{JobID: &jobID},
+		}, nil).Once()
+	metaClient.On("RunAdminJob", ctx, showJobReq("3")).
+		Return(showStatsJobResp(meta.JobStatus_RUNNING, meta.JobStatus_FINISHED, meta.JobStatus_RUNNING), nil).Once()
+	metaClient.On("RunAdminJob", ctx, showJobReq("3")).
+		Return(showStatsJobResp(meta.JobStatus_FINISHED, meta.JobStatus_FINISHED, meta.JobStatus_FINISHED), nil).Once()
+
+	jobs := NewJobClient(metaClient, "bank")
+	id, err := jobs.Submit(ctx, meta.JobType_STATS)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), id)
+
+	job, err := jobs.Wait(ctx, id, fastWait)
+	assert.NoError(t, err)
+	assert.True(t, job.IsDone())
+	assert.Equal(t, time.Unix(1700000000, 0), job.StartTime)
+	assert.True(t, job.StopTime.IsZero())
+
+	done, total := job.Progress()
+	assert.Equal(t, 2, done)
+	assert.Equal(t, 2, total)
+}
+
+func TestJobClient_Wait_ContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, showJobReq("3")).
+		Return(showStatsJobResp(meta.JobStatus_RUNNING, meta.JobStatus_FINISHED, meta.JobStatus_RUNNING, meta.JobStatus_QUEUE), nil)
+
+	job, err := NewJobClient(metaClient, "bank").Wait(ctx, 3, fastWait)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "1/3 tasks done")
+	assert.Equal(t, meta.JobStatus_RUNNING, job.Status)
+}
+
+func TestJobClient_List(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_SHOW_All, Type: meta.JobType_UNKNOWN, Paras: [][]byte{}}).
+		Return(&meta.AdminJobResp{
+			Code: nebula.ErrorCode_SUCCEEDED,
+			Result_: &meta.AdminJobResult_{
+				JobDesc: []*meta.JobDesc{
+					{SpaceID: 1, JobID: 2, Type: meta.JobType_COMPACT, Status: meta.JobStatus_FINISHED},
+					{SpaceID: 1, JobID: 1, Type: meta.JobType_FLUSH, Paras: []string{"x"}, Status: meta.JobStatus_FAILED, Code: nebula.ErrorCode_E_UNKNOWN},
+				},
+			},
+		}, nil).Once()
+
+	jobs, err := NewJobClient(metaClient, "bank").List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Job{
+		{ID: 2, SpaceID: 1, Type: meta.JobType_COMPACT, Status: meta.JobStatus_FINISHED},
+		{ID: 1, SpaceID: 1, Type: meta.JobType_FLUSH, Params: []string{"x"}, Status: meta.JobStatus_FAILED, Code: nebula.ErrorCode_E_UNKNOWN},
+	}, jobs)
+}
+
+func TestJobClient_StopAndRecover(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	recovered := int32(2)
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_STOP, Type: meta.JobType_UNKNOWN, Paras: [][]byte{[]byte("3")}}).
+		Return(&meta.AdminJobResp{Code: nebula.ErrorCode_SUCCEEDED}, nil).Once()
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_RECOVER, Type: meta.JobType_UNKNOWN, Paras: [][]byte{[]byte("3"), []byte("4")}}).
+		Return(&meta.AdminJobResp{
+			Code:    nebula.ErrorCode_SUCCEEDED,
+			Result_: &meta.AdminJobResult_{RecoveredJobNum: &recovered},
+		}, nil).Once()
+
+	jobs := NewJobClient(metaClient, "bank")
+	assert.NoError(t, jobs.Stop(ctx, 3))
+
+	n, err := jobs.Recover(ctx, 3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), n)
+}
+
+func TestJobClient_Stop_Failed(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_STOP, Type: meta.JobType_UNKNOWN, Paras: [][]byte{[]byte("3")}}).
+		Return(&meta.AdminJobResp{Code: nebula.ErrorCode_E_JOB_ALREADY_FINISH}, nil).Once()
+
+	err := NewJobClient(metaClient, "bank").Stop(ctx, 3)
+	assert.EqualError(t, err, "failed to run admin job operation STOP in space bank, error code: E_JOB_ALREADY_FINISH")
+}
+
+func TestJobClient_GetSpaceID_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	// the space is described once however many calls race for it
+	expectGetSpace(metaClient, ctx, "bank", 1)
+
+	jobs := NewJobClient(metaClient, "bank")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			spaceID, err := jobs.getSpaceID(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, nebula.GraphSpaceID(1), spaceID)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,57 @@
+package nebula_sirius
+
+import (
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+)
+
+// GetSpaceID returns the ID of the space of the given name, as described by the meta service.
+func GetSpaceID(ctx context.Context, metaClient meta.MetaService, spaceName string) (nebula.GraphSpaceID, error) {
+	if spaceName == "" {
+		return 0, fmt.Errorf("space name cannot be empty")
+	}
+
+	resp, err := metaClient.GetSpace(ctx, &meta.GetSpaceReq{SpaceName: []byte(spaceName)})
+	if err != nil {
+		return 0, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return 0, fmt.Errorf("failed to get space %s, error code: %s", spaceName, resp.GetCode())
+	}
+	return resp.GetItem().GetSpaceID(), nil
+}
+
+// checkExecResp turns a failed meta response into an error.
+func checkExecResp(resp *meta.ExecResp, err error, action string) error {
+	if err != nil {
+		return err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return fmt.Errorf("failed to %s, error code: %s", action, resp.GetCode())
+	}
+	return nil
+}
+
+func hostAddr(address HostAddress) *nebula.HostAddr {
+	return &nebula.HostAddr{
+		Host: address.Host,
+		Port: nebula.Port(address.Port),
+	}
+}
+
+func hostAddress(addr *nebula.HostAddr) HostAddress {
+	return HostAddress{
+		Host: addr.GetHost(),
+		Port: int(addr.GetPort()),
+	}
+}
+
+func hostAddrs(addresses []HostAddress) []*nebula.HostAddr {
+	addrs := make([]*nebula.HostAddr, 0, len(addresses))
+	for _, address := range addresses {
+		addrs = append(addrs, hostAddr(address))
+	}
+	return addrs
+}
@@ -0,0 +1,213 @@
+package migration
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_alter"
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/tag_alter"
+	"github.com/nebula-contrib/nebula-sirius/statement/tag_create"
+)
+
+// DiffOptions configures how the desired schema is compared with the live one.
+type DiffOptions struct {
+	skipDrops bool // optional
+}
+
+// DiffOption is a functional option for configuring DiffOptions.
+type DiffOption func(*DiffOptions)
+
+// WithSkipDrops sets whether the properties that exist in the live schema but not in the
+// desired one are kept instead of being dropped.
+func WithSkipDrops(skipDrops bool) func(*DiffOptions) {
+	return func(opts *DiffOptions) {
+		opts.skipDrops = skipDrops
+	}
+}
+
+// schemaDiff is the minimal set of changes bringing a live schema to the desired one.
+type schemaDiff struct {
+	add    []PropertySchema
+	change []PropertySchema
+	drop   []string
+	ttl    *schemaTTL // nil when the TTL is unchanged
+}
+
+type schemaTTL struct {
+	duration int64
+	col      string
+}
+
+func (d schemaDiff) isEmpty() bool {
+	return len(d.add) == 0 && len(d.change) == 0 && len(d.drop) == 0 && d.ttl == nil
+}
+
+// DiffTag returns the statements bringing the live tag schema to the desired one.
+//
+// A nil live schema means the tag does not exist yet, the CREATE TAG statement is returned then.
+// Otherwise, an ALTER TAG statement with the ADD and CHANGE definitions and the TTL change is
+// returned, followed by a separate ALTER TAG statement with the DROP definitions, so that a TTL
+// column can be replaced and dropped in the same migration. No statement is returned when the
+// schemas are equal. The comment of the tag itself is not compared.
+func DiffTag(desired tag_create.CreateTagStatement, live *Schema, options ...DiffOption) ([]string, error) {
+	if live == nil {
+		stmt, err := tag_create.GenerateCreateTagStatement(desired)
+		if err != nil {
+			return nil, err
+		}
+		return []string{stmt}, nil
+	}
+
+	diff := diffSchema(TagSchema(desired), *live, newDiffOptions(options))
+	if diff.isEmpty() {
+		return nil, nil
+	}
+
+	name := desired.GetName()
+	stmts := make([]string, 0, 2)
+
+	defs := make([]tag_alter.IAlterTagTypeDefinition, 0, len(diff.add)+len(diff.change))
+	for _, prop := range diff.add {
+		defs = append(defs, tag_alter.NewAlterTypeAddDefinition(prop.Name, prop.Type,
+			tag_alter.WithAlterTypeAddNotNullable(!prop.Nullable),
+			tag_alter.WithAlterTypeAddComment(prop.Comment)))
+	}
+	for _, prop := range diff.change {
+		defs = append(defs, tag_alter.NewAlterTypeChangeDefinition(prop.Name, prop.Type,
+			tag_alter.WithAlterTypeChangeNotNullable(!prop.Nullable),
+			tag_alter.WithAlterTypeChangeComment(prop.Comment)))
+	}
+
+	if len(defs) > 0 || diff.ttl != nil {
+		var alterOptions []tag_alter.AlterTagStatementOption
+		if diff.ttl != nil {
+			alterOptions = append(alterOptions, tag_alter.WithTtlDefinitions([]tag_alter.TTLDefinition{
+				tag_alter.NewTTLDefinition(diff.ttl.duration, diff.ttl.col),
+			}))
+		}
+		stmt, err := tag_alter.GenerateAlterTagStatement(tag_alter.NewAlterTagStatement(name, defs, alterOptions...))
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	if len(diff.drop) > 0 {
+		dropDefs := make([]tag_alter.IAlterTagTypeDefinition, 0, len(diff.drop))
+		for _, prop := range diff.drop {
+			dropDefs = append(dropDefs, tag_alter.NewAlterTypeDropDefinition(prop))
+		}
+		stmt, err := tag_alter.GenerateAlterTagStatement(tag_alter.NewAlterTagStatement(name, dropDefs))
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	return stmts, nil
+}
+
+// DiffEdge returns the statements bringing the live edge type schema to the desired one.
+// It behaves the same way as DiffTag, see DiffTag.
+func DiffEdge(desired edge_create.CreateEdgeStatement, live *Schema, options ...DiffOption) ([]string, error) {
+	if live == nil {
+		stmt, err := edge_create.GenerateCreateEdgeStatement(desired)
+		if err != nil {
+			return nil, err
+		}
+		return []string{stmt}, nil
+	}
+
+	diff := diffSchema(EdgeSchema(desired), *live, newDiffOptions(options))
+	if diff.isEmpty() {
+		return nil, nil
+	}
+
+	name := desired.GetName()
+	stmts := make([]string, 0, 2)
+
+	defs := make([]edge_alter.IAlterEdgeTypeDefinition, 0, len(diff.add)+len(diff.change))
+	for _, prop := range diff.add {
+		defs = append(defs, edge_alter.NewAlterTypeAddDefinition(prop.Name, prop.Type,
+			edge_alter.WithAlterTypeAddNotNullable(!prop.Nullable),
+			edge_alter.WithAlterTypeAddComment(prop.Comment)))
+	}
+	for _, prop := range diff.change {
+		defs = append(defs, edge_alter.NewAlterTypeChangeDefinition(prop.Name, prop.Type,
+			edge_alter.WithAlterTypeChangeNotNullable(!prop.Nullable),
+			edge_alter.WithAlterTypeChangeComment(prop.Comment)))
+	}
+
+	if len(defs) > 0 || diff.ttl != nil {
+		var alterOptions []edge_alter.AlterEdgeStatementOption
+		if diff.ttl != nil {
+			alterOptions = append(alterOptions, edge_alter.WithTtlDefinitions([]edge_alter.TTLDefinition{
+				edge_alter.NewTTLDefinition(diff.ttl.duration, diff.ttl.col),
+			}))
+		}
+		stmt, err := edge_alter.GenerateAlterEdgeStatement(edge_alter.NewAlterEdgeStatement(name, defs, alterOptions...))
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	if len(diff.drop) > 0 {
+		dropDefs := make([]edge_alter.IAlterEdgeTypeDefinition, 0, len(diff.drop))
+		for _, prop := range diff.drop {
+			dropDefs = append(dropDefs, edge_alter.NewAlterTypeDropDefinition(prop))
+		}
+		stmt, err := edge_alter.GenerateAlterEdgeStatement(edge_alter.NewAlterEdgeStatement(name, dropDefs))
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, stmt)
+	}
+
+	return stmts, nil
+}
+
+func newDiffOptions(options []DiffOption) DiffOptions {
+	var opts DiffOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+// diffSchema compares the desired schema with the live one property by property.
+// Added properties keep the desired order, dropped properties keep the live order.
+func diffSchema(desired, live Schema, opts DiffOptions) schemaDiff {
+	var diff schemaDiff
+
+	for _, prop := range desired.Properties {
+		liveProp, ok := live.property(prop.Name)
+		if !ok {
+			diff.add = append(diff.add, prop)
+			continue
+		}
+		if !isSameProperty(prop, liveProp) {
+			diff.change = append(diff.change, prop)
+		}
+	}
+
+	if !opts.skipDrops {
+		for _, prop := range live.Properties {
+			if _, ok := desired.property(prop.Name); !ok {
+				diff.drop = append(diff.drop, prop.Name)
+			}
+		}
+	}
+
+	desiredDuration, desiredCol := desired.ttl()
+	liveDuration, liveCol := live.ttl()
+	if desiredDuration != liveDuration || desiredCol != liveCol {
+		diff.ttl = &schemaTTL{duration: desiredDuration, col: desiredCol}
+	}
+
+	return diff
+}
+
+func isSameProperty(desired, live PropertySchema) bool {
+	return normalizePropertyType(desired.Type) == normalizePropertyType(live.Type) &&
+		desired.Nullable == live.Nullable &&
+		desired.Comment == live.Comment
+}
@@ -0,0 +1,111 @@
+package migration
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/tag_create"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func accountTag() tag_create.CreateTagStatement {
+	return tag_create.NewCreateTagStatement("account",
+		tag_create.WithProperties([]tag_create.TagProperty{
+			tag_create.NewTagProperty("name", statement.PropertyTypeString, false),
+			tag_create.NewTagProperty("age", statement.PropertyTypeInt, true,
+				tag_create.WithPropertyComment("age in years")),
+			tag_create.NewTagProperty("created_at", statement.PropertyTypeTimestamp, true),
+		}),
+		tag_create.WithTtlDuration(100),
+		tag_create.WithTtlCol("created_at"))
+}
+
+func TestDiffTag_Create(t *testing.T) {
+	stmts, err := DiffTag(accountTag(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`CREATE TAG account (name string NOT NULL, age int NULL COMMENT 'age in years', created_at timestamp NULL) TTL_DURATION = 100, TTL_COL = "created_at";`,
+	}, stmts)
+}
+
+func TestDiffTag_Unchanged(t *testing.T) {
+	live := &Schema{
+		Kind: SchemaKindTag,
+		Name: "account",
+		Properties: []PropertySchema{
+			{Name: "name", Type: "string", Nullable: false},
+			{Name: "age", Type: "int64", Nullable: true, Comment: "age in years"},
+			{Name: "created_at", Type: "timestamp", Nullable: true},
+		},
+		TTLDuration: 100,
+		TTLCol:      "created_at",
+	}
+
+	stmts, err := DiffTag(accountTag(), live)
+	assert.NoError(t, err)
+	assert.Empty(t, stmts)
+}
+
+func TestDiffTag_AddChangeDropAndTTL(t *testing.T) {
+	live := &Schema{
+		Kind: SchemaKindTag,
+		Name: "account",
+		Properties: []PropertySchema{
+			{Name: "name", Type: "string", Nullable: true},
+			{Name: "nickname", Type: "string", Nullable: true},
+			{Name: "updated_at", Type: "timestamp", Nullable: true},
+		},
+		TTLDuration: 50,
+		TTLCol:      "updated_at",
+	}
+
+	stmts, err := DiffTag(accountTag(), live)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`ALTER TAG account ADD (age int NULL COMMENT 'age in years'), ADD (created_at timestamp NULL), CHANGE (name string NOT NULL) TTL_DURATION = 100, TTL_COL = "created_at";`,
+		`ALTER TAG account DROP (nickname), DROP (updated_at);`,
+	}, stmts)
+}
+
+func TestDiffTag_SkipDrops(t *testing.T) {
+	live := &Schema{
+		Kind: SchemaKindTag,
+		Name: "account",
+		Properties: []PropertySchema{
+			{Name: "name", Type: "string", Nullable: false},
+			{Name: "age", Type: "int64", Nullable: true, Comment: "age in years"},
+			{Name: "created_at", Type: "timestamp", Nullable: true},
+			{Name: "nickname", Type: "string", Nullable: true},
+		},
+		TTLDuration: 100,
+		TTLCol:      "created_at",
+	}
+
+	stmts, err := DiffTag(accountTag(), live, WithSkipDrops(true))
+	assert.NoError(t, err)
+	assert.Empty(t, stmts)
+}
+
+func TestDiffEdge_RemoveTTL(t *testing.T) {
+	desired := edge_create.NewCreateEdgeStatement("transfer",
+		edge_create.WithProperties([]edge_create.EdgeProperty{
+			edge_create.NewEdgeProperty("amount", statement.PropertyTypeDouble, false),
+		}))
+	live := &Schema{
+		Kind: SchemaKindEdge,
+		Name: "transfer",
+		Properties: []PropertySchema{
+			{Name: "amount", Type: "double", Nullable: false},
+			{Name: "created_at", Type: "timestamp", Nullable: true},
+		},
+		TTLDuration: 3600,
+		TTLCol:      "created_at",
+	}
+
+	stmts, err := DiffEdge(desired, live)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`ALTER EDGE transfer TTL_DURATION = 0, TTL_COL = "";`,
+		`ALTER EDGE transfer DROP (created_at);`,
+	}, stmts)
+}
@@ -0,0 +1,429 @@
+package migration
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	nebula_sirius "github.com/nebula-contrib/nebula-sirius"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/tag_create"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultHistoryTag is the tag storing the applied migrations in the graph.
+const DefaultHistoryTag = "nebula_sirius_migration"
+
+// Migration is a versioned set of desired tags and edge types.
+// A tag or an edge type is created when it does not exist yet, altered otherwise.
+type Migration struct {
+	Version     int64 // required, unique and positive
+	Description string
+	Tags        []tag_create.CreateTagStatement
+	Edges       []edge_create.CreateEdgeStatement
+}
+
+// MigrationResult is the outcome of a pending migration.
+type MigrationResult struct {
+	Version     int64
+	Description string
+	Statements  []string // statements bringing the schema to the migration, empty when it already matches
+	Applied     bool     // false in dry-run mode
+}
+
+// Migrator brings the schema of a space to the desired tags and edge types, applying the given
+// migrations in version order and recording them in a history tag, so that each migration is applied once.
+type Migrator struct {
+	executor      nebula_sirius.IExecutor    // required
+	spaceName     string                     // required
+	schemaReader  ISchemaReader              // optional, reads the schema through the executor by default
+	historyTag    string                     // optional
+	dryRun        bool                       // optional
+	diffOptions   []DiffOption               // optional
+	waitExecutors []nebula_sirius.IExecutor  // optional, the executor of the migrator by default
+	waitOptions   []nebula_sirius.WaitOption // optional
+}
+
+// MigratorOption is a functional option for configuring a Migrator.
+type MigratorOption func(*Migrator)
+
+// NewMigrator creates a new Migrator for the given space, executing its statements with the given executor.
+// The executor is switched to the space when migrating.
+func NewMigrator(executor nebula_sirius.IExecutor, spaceName string, options ...MigratorOption) *Migrator {
+	migrator := &Migrator{
+		executor:      executor,
+		spaceName:     spaceName,
+		schemaReader:  NewGraphSchemaReader(executor),
+		historyTag:    DefaultHistoryTag,
+		waitExecutors: []nebula_sirius.IExecutor{executor},
+	}
+
+	// Apply all the functional options to configure the migrator.
+	for _, opt := range options {
+		opt(migrator)
+	}
+
+	return migrator
+}
+
+// WithDryRun sets whether the migrator only plans the statements without executing them.
+func WithDryRun(dryRun bool) func(*Migrator) {
+	return func(m *Migrator) {
+		m.dryRun = dryRun
+	}
+}
+
+// WithSchemaReader sets the reader of the live schema, e.g. a MetaSchemaReader.
+func WithSchemaReader(schemaReader ISchemaReader) func(*Migrator) {
+	return func(m *Migrator) {
+		m.schemaReader = schemaReader
+	}
+}
+
+// WithHistoryTag sets the name of the tag storing the applied migrations.
+func WithHistoryTag(historyTag string) func(*Migrator) {
+	return func(m *Migrator) {
+		m.historyTag = historyTag
+	}
+}
+
+// WithDiffOptions sets the options used to compare the desired schemas with the live ones.
+func WithDiffOptions(options ...DiffOption) func(*Migrator) {
+	return func(m *Migrator) {
+		m.diffOptions = options
+	}
+}
+
+// WithWaitExecutors sets the executors used to wait for the schema changes to be visible, usually
+// sessions opened on each of the graph services, see nebula_sirius.WaitForTag.
+func WithWaitExecutors(executors ...nebula_sirius.IExecutor) func(*Migrator) {
+	return func(m *Migrator) {
+		m.waitExecutors = executors
+	}
+}
+
+// WithWaitOptions sets the options used to wait for the schema changes to be visible.
+func WithWaitOptions(options ...nebula_sirius.WaitOption) func(*Migrator) {
+	return func(m *Migrator) {
+		m.waitOptions = options
+	}
+}
+
+// Migrate applies the migrations not applied yet in version order, and returns their results.
+//
+// The schema of each migration is compared with the live schema, or with the schema of the previous
+// pending migration when both define the same tag or edge type, and only the difference is executed.
+// Once its statements are executed, a migration is recorded in the history tag. In dry-run mode
+// nothing is executed nor recorded, the results hold the planned statements.
+func (m *Migrator) Migrate(ctx context.Context, migrations []Migration) ([]MigrationResult, error) {
+	if m.spaceName == "" {
+		return nil, fmt.Errorf("space name is required")
+	}
+
+	migrations = slices.Clone(migrations)
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	for i, migration := range migrations {
+		if migration.Version <= 0 {
+			return nil, fmt.Errorf("migration version must be positive, got %d", migration.Version)
+		}
+		if i > 0 && migrations[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migration.Version)
+		}
+	}
+
+	if _, err := nebula_sirius.ExecuteChecked(ctx, m.executor, fmt.Sprintf("USE %s;", m.spaceName)); err != nil {
+		return nil, err
+	}
+
+	intVid, vidLength, err := m.vidType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !intVid {
+		// a longer vid would be rejected when the history is read or written
+		for _, migration := range migrations {
+			if key := historyKey(migration.Version); len(key) > vidLength {
+				return nil, fmt.Errorf("history vid %s of migration %d is longer than the vid length %d of space %s",
+					key, migration.Version, vidLength, m.spaceName)
+			}
+		}
+	}
+
+	historySchema, err := m.schemaReader.ReadTag(ctx, m.historyTag)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]bool)
+	if historySchema != nil {
+		applied, err = m.appliedVersions(ctx, migrations, intVid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pending := slices.DeleteFunc(migrations, func(migration Migration) bool {
+		return applied[migration.Version]
+	})
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	if historySchema == nil && !m.dryRun {
+		if err := m.createHistoryTag(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	planned := make(map[string]*Schema)
+	results := make([]MigrationResult, 0, len(pending))
+	for _, migration := range pending {
+		stmts, changed, err := m.plan(ctx, migration, planned)
+		if err != nil {
+			return results, fmt.Errorf("migration %d: %w", migration.Version, err)
+		}
+
+		result := MigrationResult{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Statements:  stmts,
+		}
+
+		if !m.dryRun {
+			if err := m.apply(ctx, migration, stmts, changed, intVid); err != nil {
+				return results, fmt.Errorf("migration %d: %w", migration.Version, err)
+			}
+			result.Applied = true
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// plan returns the statements of the migration along with the schemas they change, and records
+// the planned schemas so that the next migrations are compared with them.
+func (m *Migrator) plan(ctx context.Context, migration Migration, planned map[string]*Schema) ([]string, []Schema, error) {
+	stmts := make([]string, 0)
+	changed := make([]Schema, 0)
+
+	for _, tag := range migration.Tags {
+		live, err := m.liveSchema(ctx, SchemaKindTag, tag.GetName(), planned)
+		if err != nil {
+			return nil, nil, err
+		}
+		tagStmts, err := DiffTag(tag, live, m.diffOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+		stmts = append(stmts, tagStmts...)
+
+		schema := m.plannedSchema(TagSchema(tag), live)
+		planned[schemaKey(SchemaKindTag, tag.GetName())] = schema
+		if len(tagStmts) > 0 {
+			changed = append(changed, *schema)
+		}
+	}
+
+	for _, edge := range migration.Edges {
+		live, err := m.liveSchema(ctx, SchemaKindEdge, edge.GetName(), planned)
+		if err != nil {
+			return nil, nil, err
+		}
+		edgeStmts, err := DiffEdge(edge, live, m.diffOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+		stmts = append(stmts, edgeStmts...)
+
+		schema := m.plannedSchema(EdgeSchema(edge), live)
+		planned[schemaKey(SchemaKindEdge, edge.GetName())] = schema
+		if len(edgeStmts) > 0 {
+			changed = append(changed, *schema)
+		}
+	}
+
+	return stmts, changed, nil
+}
+
+// apply executes the statements of the migration, waits for the changed schemas to be visible
+// and records the migration in the history tag.
+func (m *Migrator) apply(ctx context.Context, migration Migration, stmts []string, changed []Schema, intVid bool) error {
+	for _, stmt := range stmts {
+		if _, err := nebula_sirius.ExecuteChecked(ctx, m.executor, stmt); err != nil {
+			return err
+		}
+	}
+
+	for _, schema := range changed {
+		if err := m.waitForSchema(ctx, schema); err != nil {
+			return err
+		}
+	}
+
+	insert := fmt.Sprintf("INSERT VERTEX %s (version, description, statements, applied_at) VALUES %s:(%d, %s, %s, now());",
+		m.historyTag,
+		historyVid(migration.Version, intVid),
+		migration.Version,
+		quote(migration.Description),
+		quote(strings.Join(stmts, "\n")))
+	_, err := nebula_sirius.ExecuteChecked(ctx, m.executor, insert)
+	return err
+}
+
+func (m *Migrator) liveSchema(ctx context.Context, kind SchemaKind, name string, planned map[string]*Schema) (*Schema, error) {
+	if schema, ok := planned[schemaKey(kind, name)]; ok {
+		return schema, nil
+	}
+	if kind == SchemaKindTag {
+		return m.schemaReader.ReadTag(ctx, name)
+	}
+	return m.schemaReader.ReadEdge(ctx, name)
+}
+
+// plannedSchema returns the schema resulting from the migration of the live schema to the desired one.
+func (m *Migrator) plannedSchema(desired Schema, live *Schema) *Schema {
+	if live != nil && newDiffOptions(m.diffOptions).skipDrops {
+		for _, prop := range live.Properties {
+			if _, ok := desired.property(prop.Name); !ok {
+				desired.Properties = append(desired.Properties, prop)
+			}
+		}
+	}
+	return &desired
+}
+
+// vidType returns whether the vids of the space are integers, and the length of its fixed string vids otherwise.
+func (m *Migrator) vidType(ctx context.Context) (bool, int, error) {
+	rs, err := nebula_sirius.ExecuteChecked(ctx, m.executor, fmt.Sprintf("DESCRIBE SPACE %s;", m.spaceName))
+	if err != nil {
+		return false, 0, err
+	}
+	if rs.GetRowSize() == 0 {
+		return false, 0, fmt.Errorf("space %s not found", m.spaceName)
+	}
+
+	record, err := rs.GetRowValuesByIndex(0)
+	if err != nil {
+		return false, 0, err
+	}
+	vidType, err := stringByColName(record, "Vid Type")
+	if err != nil {
+		return false, 0, err
+	}
+	vidType = strings.ToUpper(vidType)
+	if strings.HasPrefix(vidType, "INT") {
+		return true, 0, nil
+	}
+
+	length, ok := strings.CutPrefix(vidType, "FIXED_STRING(")
+	if !ok || !strings.HasSuffix(length, ")") {
+		return false, 0, fmt.Errorf("unsupported vid type %s of space %s", vidType, m.spaceName)
+	}
+	vidLength, err := strconv.Atoi(strings.TrimSuffix(length, ")"))
+	if err != nil {
+		return false, 0, fmt.Errorf("unsupported vid type %s of space %s", vidType, m.spaceName)
+	}
+	return false, vidLength, nil
+}
+
+func (m *Migrator) createHistoryTag(ctx context.Context) error {
+	stmt, err := tag_create.GenerateCreateTagStatement(tag_create.NewCreateTagStatement(m.historyTag,
+		tag_create.WithIfNotExists(true),
+		tag_create.WithProperties([]tag_create.TagProperty{
+			tag_create.NewTagProperty("version", statement.PropertyTypeInt64, false),
+			tag_create.NewTagProperty("description", statement.PropertyTypeString, true),
+			tag_create.NewTagProperty("statements", statement.PropertyTypeString, true),
+			tag_create.NewTagProperty("applied_at", statement.PropertyTypeTimestamp, false),
+		}),
+		tag_create.WithComment("schema migrations applied by nebula-sirius")))
+	if err != nil {
+		return err
+	}
+
+	if _, err := nebula_sirius.ExecuteChecked(ctx, m.executor, stmt); err != nil {
+		return err
+	}
+
+	return nebula_sirius.WaitForTag(ctx, m.waitExecutors, m.spaceName, m.historyTag,
+		append(slices.Clone(m.waitOptions), nebula_sirius.WithWaitProperties("version", "description", "statements", "applied_at"))...)
+}
+
+// appliedVersions returns the versions of the given migrations recorded in the history tag.
+func (m *Migrator) appliedVersions(ctx context.Context, migrations []Migration, intVid bool) (map[int64]bool, error) {
+	applied := make(map[int64]bool)
+	if len(migrations) == 0 {
+		return applied, nil
+	}
+
+	vids := make([]string, 0, len(migrations))
+	for _, migration := range migrations {
+		vids = append(vids, historyVid(migration.Version, intVid))
+	}
+
+	rs, err := nebula_sirius.ExecuteChecked(ctx, m.executor, fmt.Sprintf("FETCH PROP ON %s %s YIELD %s.version AS version;",
+		m.historyTag, strings.Join(vids, ", "), m.historyTag))
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := rs.GetValuesByColName("version")
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		if !version.IsInt() {
+			continue
+		}
+		v, err := version.AsInt()
+		if err != nil {
+			return nil, err
+		}
+		applied[v] = true
+	}
+
+	return applied, nil
+}
+
+// waitForSchema waits until the schema and its properties are visible on the graph services.
+func (m *Migrator) waitForSchema(ctx context.Context, schema Schema) error {
+	properties := make([]string, 0, len(schema.Properties))
+	for _, prop := range schema.Properties {
+		properties = append(properties, prop.Name)
+	}
+	options := append(slices.Clone(m.waitOptions), nebula_sirius.WithWaitProperties(properties...))
+
+	if schema.Kind == SchemaKindTag {
+		return nebula_sirius.WaitForTag(ctx, m.waitExecutors, m.spaceName, schema.Name, options...)
+	}
+	return nebula_sirius.WaitForEdge(ctx, m.waitExecutors, m.spaceName, schema.Name, options...)
+}
+
+func schemaKey(kind SchemaKind, name string) string {
+	return string(kind) + " " + name
+}
+
+// historyKey returns the key of the history record of the given version, the vid of the record in string vid spaces.
+func historyKey(version int64) string {
+	return fmt.Sprintf("migration_%d", version)
+}
+
+// historyVid returns the vertex ID of the history record of the given version.
+func historyVid(version int64, intVid bool) string {
+	if intVid {
+		return fmt.Sprintf(`hash("%s")`, historyKey(version))
+	}
+	return fmt.Sprintf(`"%s"`, historyKey(version))
+}
+
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote returns s as a double-quoted nGQL string literal.
+func quote(s string) string {
+	return `"` + quoteReplacer.Replace(s) + `"`
+}
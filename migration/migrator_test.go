@@ -0,0 +1,187 @@
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	nebula_sirius "github.com/nebula-contrib/nebula-sirius"
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/tag_create"
+	"github.com/stretchr/testify/assert"
+)
+
+func describeSpaceResponse(vidType string) []*nebula.Value {
+	return []*nebula.Value{iVal(1), sVal("bank"), iVal(10), iVal(1), sVal("utf8"), sVal("utf8_bin"), sVal(vidType)}
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			Version:     2,
+			Description: "add account age",
+			Tags: []tag_create.CreateTagStatement{
+				tag_create.NewCreateTagStatement("account",
+					tag_create.WithProperties([]tag_create.TagProperty{
+						tag_create.NewTagProperty("name", statement.PropertyTypeString, false),
+						tag_create.NewTagProperty("age", statement.PropertyTypeInt64, true),
+					})),
+			},
+		},
+		{
+			Version:     1,
+			Description: "create account and transfer",
+			Tags: []tag_create.CreateTagStatement{
+				tag_create.NewCreateTagStatement("account",
+					tag_create.WithProperties([]tag_create.TagProperty{
+						tag_create.NewTagProperty("name", statement.PropertyTypeString, false),
+					})),
+			},
+			Edges: []edge_create.CreateEdgeStatement{
+				edge_create.NewCreateEdgeStatement("transfer",
+					edge_create.WithProperties([]edge_create.EdgeProperty{
+						edge_create.NewEdgeProperty("amount", statement.PropertyTypeDouble, false),
+					})),
+			},
+		},
+	}
+}
+
+var describeSpaceColumns = []string{"ID", "Name", "Partition Number", "Replica Factor", "Charset", "Collate", "Vid Type"}
+
+var fastWait = WithWaitOptions(nebula_sirius.WithWaitInterval(time.Millisecond, time.Millisecond))
+
+// expectWait expects the statements of the WaitForTag/WaitForEdge probes.
+func expectWait(graphClient *mocks.GraphService, ctx context.Context, vidType string, probe string) {
+	expectExecute(graphClient, ctx, "USE bank;", succeededResponse())
+	expectExecute(graphClient, ctx, "DESCRIBE SPACE bank;", dataSetResponse(describeSpaceColumns, describeSpaceResponse(vidType)))
+	expectExecute(graphClient, ctx, probe, succeededResponse())
+}
+
+func TestMigrator_Migrate_DryRun(t *testing.T) {
+	ctx := context.Background()
+	session, graphClient := newTestSession(t, ctx)
+
+	expectExecute(graphClient, ctx, "USE bank;", succeededResponse())
+	expectExecute(graphClient, ctx, "DESCRIBE SPACE bank;", dataSetResponse(describeSpaceColumns, describeSpaceResponse("FIXED_STRING(32)")))
+	expectExecute(graphClient, ctx, "DESCRIBE TAG nebula_sirius_migration;", errorResponse(nebula_sirius.ErrorTagNotFound))
+	expectExecute(graphClient, ctx, "DESCRIBE TAG account;", errorResponse(nebula_sirius.ErrorTagNotFound))
+	expectExecute(graphClient, ctx, "DESCRIBE EDGE transfer;", errorResponse(nebula_sirius.ErrorEdgeNotFound))
+
+	results, err := NewMigrator(session, "bank", WithDryRun(true)).Migrate(ctx, testMigrations())
+	assert.NoError(t, err)
+	assert.Equal(t, []MigrationResult{
+		{
+			Version:     1,
+			Description: "create account and transfer",
+			Statements: []string{
+				`CREATE TAG account (name string NOT NULL);`,
+				`CREATE EDGE transfer (amount double NOT NULL);`,
+			},
+		},
+		{
+			Version:     2,
+			Description: "add account age",
+			Statements: []string{
+				`ALTER TAG account ADD (age int64 NULL);`,
+			},
+		},
+	}, results)
+}
+
+func TestMigrator_Migrate_ShortVidLength(t *testing.T) {
+	ctx := context.Background()
+	session, graphClient := newTestSession(t, ctx)
+
+	expectExecute(graphClient, ctx, "USE bank;", succeededResponse())
+	expectExecute(graphClient, ctx, "DESCRIBE SPACE bank;", dataSetResponse(describeSpaceColumns, describeSpaceResponse("FIXED_STRING(8)")))
+
+	_, err := NewMigrator(session, "bank").Migrate(ctx, testMigrations())
+	assert.EqualError(t, err, "history vid migration_1 of migration 1 is longer than the vid length 8 of space bank")
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	ctx := context.Background()
+	session, graphClient := newTestSession(t, ctx)
+
+	expectExecute(graphClient, ctx, "USE bank;", succeededResponse())
+	expectExecute(graphClient, ctx, "DESCRIBE SPACE bank;", dataSetResponse(describeSpaceColumns, describeSpaceResponse("INT64")))
+	expectExecute(graphClient, ctx, "DESCRIBE TAG nebula_sirius_migration;", describeResponse(
+		[]*nebula.Value{sVal("version"), sVal("int64"), sVal("NO"), nullVal(), nullVal()},
+	))
+	expectExecute(graphClient, ctx, "SHOW CREATE TAG nebula_sirius_migration;", dataSetResponse([]string{"Tag", "Create Tag"},
+		[]*nebula.Value{sVal("nebula_sirius_migration"), sVal(`CREATE TAG nebula_sirius_migration () ttl_duration = 0, ttl_col = ""`)},
+	))
+	expectExecute(graphClient, ctx,
+		`FETCH PROP ON nebula_sirius_migration hash("migration_1"), hash("migration_2") YIELD nebula_sirius_migration.version AS version;`,
+		dataSetResponse([]string{"version"}, []*nebula.Value{iVal(1)}))
+	expectExecute(graphClient, ctx, "DESCRIBE TAG account;", describeResponse(
+		[]*nebula.Value{sVal("name"), sVal("string"), sVal("NO"), nullVal(), nullVal()},
+	))
+	expectExecute(graphClient, ctx, "SHOW CREATE TAG account;", dataSetResponse([]string{"Tag", "Create Tag"},
+		[]*nebula.Value{sVal("account"), sVal("CREATE TAG `account` (\n `name` string NOT NULL\n) ttl_duration = 0, ttl_col = \"\"")},
+	))
+	expectExecute(graphClient, ctx, "ALTER TAG account ADD (age int64 NULL);", succeededResponse())
+	expectWait(graphClient, ctx, "INT64", "EXPLAIN FETCH PROP ON account 0 YIELD account.name, account.age;")
+	expectExecute(graphClient, ctx,
+		`INSERT VERTEX nebula_sirius_migration (version, description, statements, applied_at) VALUES hash("migration_2"):(2, "add account age", "ALTER TAG account ADD (age int64 NULL);", now());`,
+		succeededResponse())
+
+	results, err := NewMigrator(session, "bank", fastWait).Migrate(ctx, testMigrations())
+	assert.NoError(t, err)
+	assert.Equal(t, []MigrationResult{
+		{
+			Version:     2,
+			Description: "add account age",
+			Statements:  []string{`ALTER TAG account ADD (age int64 NULL);`},
+			Applied:     true,
+		},
+	}, results)
+}
+
+func TestMigrator_Migrate_CreatesHistoryTag(t *testing.T) {
+	ctx := context.Background()
+	session, graphClient := newTestSession(t, ctx)
+
+	migration := Migration{
+		Version:     1,
+		Description: `create "transfer"`,
+		Edges: []edge_create.CreateEdgeStatement{
+			edge_create.NewCreateEdgeStatement("transfer",
+				edge_create.WithProperties([]edge_create.EdgeProperty{
+					edge_create.NewEdgeProperty("amount", statement.PropertyTypeDouble, false),
+				})),
+		},
+	}
+
+	expectExecute(graphClient, ctx, "USE bank;", succeededResponse())
+	expectExecute(graphClient, ctx, "DESCRIBE SPACE bank;", dataSetResponse(describeSpaceColumns, describeSpaceResponse("FIXED_STRING(32)")))
+	expectExecute(graphClient, ctx, "DESCRIBE TAG nebula_sirius_migration;", errorResponse(nebula_sirius.ErrorTagNotFound))
+	expectExecute(graphClient, ctx,
+		`CREATE TAG IF NOT EXISTS nebula_sirius_migration (version int64 NOT NULL, description string NULL, statements string NULL, applied_at timestamp NOT NULL) COMMENT = 'schema migrations applied by nebula-sirius';`,
+		succeededResponse())
+	expectWait(graphClient, ctx, "FIXED_STRING(32)",
+		`EXPLAIN FETCH PROP ON nebula_sirius_migration "" YIELD nebula_sirius_migration.version, nebula_sirius_migration.description, nebula_sirius_migration.statements, nebula_sirius_migration.applied_at;`)
+	expectExecute(graphClient, ctx, "DESCRIBE EDGE transfer;", errorResponse(nebula_sirius.ErrorEdgeNotFound))
+	expectExecute(graphClient, ctx, "CREATE EDGE transfer (amount double NOT NULL);", succeededResponse())
+	expectWait(graphClient, ctx, "FIXED_STRING(32)", `EXPLAIN FETCH PROP ON transfer "" -> "" YIELD transfer.amount;`)
+	expectExecute(graphClient, ctx,
+		`INSERT VERTEX nebula_sirius_migration (version, description, statements, applied_at) VALUES "migration_1":(1, "create \"transfer\"", "CREATE EDGE transfer (amount double NOT NULL);", now());`,
+		succeededResponse())
+
+	results, err := NewMigrator(session, "bank", fastWait).Migrate(ctx, []Migration{migration})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.True(t, results[0].Applied)
+}
+
+func TestMigrator_Migrate_DuplicateVersion(t *testing.T) {
+	ctx := context.Background()
+	session, _ := newTestSession(t, ctx)
+
+	_, err := NewMigrator(session, "bank").Migrate(ctx, []Migration{{Version: 1}, {Version: 1}})
+	assert.EqualError(t, err, "duplicate migration version 1")
+}
@@ -0,0 +1,276 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nebula_sirius "github.com/nebula-contrib/nebula-sirius"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ISchemaReader reads the live schema of tags and edge types.
+// Both methods return a nil schema and no error when the tag or edge type does not exist.
+type ISchemaReader interface {
+	ReadTag(ctx context.Context, name string) (*Schema, error)
+	ReadEdge(ctx context.Context, name string) (*Schema, error)
+}
+
+// GraphSchemaReader reads the live schema through the graph service with DESCRIBE TAG/EDGE and
+// SHOW CREATE TAG/EDGE statements. The space must have been selected on the executor beforehand.
+type GraphSchemaReader struct {
+	executor nebula_sirius.IExecutor // required
+}
+
+// NewGraphSchemaReader creates a new GraphSchemaReader executing its statements with the given executor.
+func NewGraphSchemaReader(executor nebula_sirius.IExecutor) GraphSchemaReader {
+	return GraphSchemaReader{
+		executor: executor,
+	}
+}
+
+// ReadTag reads the live schema of the given tag.
+func (r GraphSchemaReader) ReadTag(ctx context.Context, name string) (*Schema, error) {
+	return r.read(ctx, SchemaKindTag, name)
+}
+
+// ReadEdge reads the live schema of the given edge type.
+func (r GraphSchemaReader) ReadEdge(ctx context.Context, name string) (*Schema, error) {
+	return r.read(ctx, SchemaKindEdge, name)
+}
+
+// ttlRegexp matches the TTL options of a SHOW CREATE TAG/EDGE output,
+// e.g. ttl_duration = 100, ttl_col = "created_at"
+var ttlRegexp = regexp.MustCompile(`(?i)ttl_duration\s*=\s*(\d+),\s*ttl_col\s*=\s*"([^"]*)"`)
+
+func (r GraphSchemaReader) read(ctx context.Context, kind SchemaKind, name string) (*Schema, error) {
+	rs, err := nebula_sirius.ExecuteChecked(ctx, r.executor, fmt.Sprintf("DESCRIBE %s %s;", kind, name))
+	if err != nil {
+		var execErr *nebula_sirius.ExecutionError
+		if errors.As(err, &execErr) && isSchemaNotFound(execErr.ErrorMsg) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	schema := &Schema{
+		Kind: kind,
+		Name: name,
+	}
+
+	for i := 0; i < rs.GetRowSize(); i++ {
+		record, err := rs.GetRowValuesByIndex(i)
+		if err != nil {
+			return nil, err
+		}
+
+		field, err := stringByColName(record, "Field")
+		if err != nil {
+			return nil, err
+		}
+		propertyType, err := stringByColName(record, "Type")
+		if err != nil {
+			return nil, err
+		}
+		null, err := stringByColName(record, "Null")
+		if err != nil {
+			return nil, err
+		}
+		comment, err := stringByColName(record, "Comment")
+		if err != nil {
+			return nil, err
+		}
+
+		schema.Properties = append(schema.Properties, PropertySchema{
+			Name:     field,
+			Type:     statement.PropertyType(propertyType),
+			Nullable: strings.EqualFold(null, "YES"),
+			Comment:  comment,
+		})
+	}
+
+	rs, err = nebula_sirius.ExecuteChecked(ctx, r.executor, fmt.Sprintf("SHOW CREATE %s %s;", kind, name))
+	if err != nil {
+		return nil, err
+	}
+	if rs.GetRowSize() > 0 && rs.GetColSize() > 1 {
+		record, err := rs.GetRowValuesByIndex(0)
+		if err != nil {
+			return nil, err
+		}
+		createValue, err := record.GetValueByIndex(1)
+		if err != nil {
+			return nil, err
+		}
+		create, err := createValue.AsString()
+		if err != nil {
+			return nil, err
+		}
+		if match := ttlRegexp.FindStringSubmatch(create); match != nil {
+			schema.TTLDuration, err = strconv.ParseInt(match[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			schema.TTLCol = match[2]
+		}
+	}
+
+	return schema, nil
+}
+
+// MetaSchemaReader reads the live schema directly from the meta service.
+type MetaSchemaReader struct {
+	metaClient meta.MetaService // required
+	spaceName  string           // required
+}
+
+// NewMetaSchemaReader creates a new MetaSchemaReader reading the schemas of the given space.
+func NewMetaSchemaReader(metaClient meta.MetaService, spaceName string) MetaSchemaReader {
+	return MetaSchemaReader{
+		metaClient: metaClient,
+		spaceName:  spaceName,
+	}
+}
+
+// latestSchemaVersion asks the meta service for the latest version of a schema.
+const latestSchemaVersion meta.SchemaVer = -1
+
+// ReadTag reads the latest live schema of the given tag.
+func (r MetaSchemaReader) ReadTag(ctx context.Context, name string) (*Schema, error) {
+	spaceID, err := nebula_sirius.GetSpaceID(ctx, r.metaClient, r.spaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.metaClient.GetTag(ctx, &meta.GetTagReq{
+		SpaceID: spaceID,
+		TagName: []byte(name),
+		Version: latestSchemaVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() == nebula.ErrorCode_E_TAG_NOT_FOUND {
+		return nil, nil
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to get tag %s, error code: %s", name, resp.GetCode())
+	}
+
+	return schemaFromMeta(SchemaKindTag, name, resp.GetSchema())
+}
+
+// ReadEdge reads the latest live schema of the given edge type.
+func (r MetaSchemaReader) ReadEdge(ctx context.Context, name string) (*Schema, error) {
+	spaceID, err := nebula_sirius.GetSpaceID(ctx, r.metaClient, r.spaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.metaClient.GetEdge(ctx, &meta.GetEdgeReq{
+		SpaceID:  spaceID,
+		EdgeName: []byte(name),
+		Version:  latestSchemaVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() == nebula.ErrorCode_E_EDGE_NOT_FOUND {
+		return nil, nil
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to get edge %s, error code: %s", name, resp.GetCode())
+	}
+
+	return schemaFromMeta(SchemaKindEdge, name, resp.GetSchema())
+}
+
+func schemaFromMeta(kind SchemaKind, name string, metaSchema *meta.Schema) (*Schema, error) {
+	schema := &Schema{
+		Kind: kind,
+		Name: name,
+	}
+
+	for _, col := range metaSchema.GetColumns() {
+		propertyType, err := propertyTypeFromMeta(col.GetType())
+		if err != nil {
+			return nil, fmt.Errorf("property %s of %s %s: %w", string(col.GetName()), kind, name, err)
+		}
+		schema.Properties = append(schema.Properties, PropertySchema{
+			Name:     string(col.GetName()),
+			Type:     propertyType,
+			Nullable: col.GetNullable(),
+			Comment:  string(col.GetComment()),
+		})
+	}
+
+	if prop := metaSchema.GetSchemaProp(); prop != nil {
+		schema.TTLDuration = prop.GetTTLDuration()
+		schema.TTLCol = string(prop.GetTTLCol())
+	}
+
+	return schema, nil
+}
+
+// propertyTypeFromMeta returns the property type the way DESCRIBE TAG/EDGE reports it.
+func propertyTypeFromMeta(typeDef *meta.ColumnTypeDef) (statement.PropertyType, error) {
+	switch typeDef.GetType() {
+	case nebula.PropertyType_BOOL:
+		return statement.PropertyTypeBoolean, nil
+	case nebula.PropertyType_INT64:
+		return statement.PropertyTypeInt64, nil
+	case nebula.PropertyType_INT32:
+		return statement.PropertyTypeInt32, nil
+	case nebula.PropertyType_INT16:
+		return statement.PropertyTypeInt16, nil
+	case nebula.PropertyType_INT8:
+		return statement.PropertyTypeInt8, nil
+	case nebula.PropertyType_FLOAT:
+		return statement.PropertyTypeFloat, nil
+	case nebula.PropertyType_DOUBLE:
+		return statement.PropertyTypeDouble, nil
+	case nebula.PropertyType_STRING:
+		return statement.PropertyTypeString, nil
+	case nebula.PropertyType_FIXED_STRING:
+		return statement.PropertyType(fmt.Sprintf(string(statement.PropertyTypeFixedStringF), typeDef.GetTypeLength())), nil
+	case nebula.PropertyType_TIMESTAMP:
+		return statement.PropertyTypeTimestamp, nil
+	case nebula.PropertyType_DURATION:
+		return statement.PropertyTypeDuration, nil
+	case nebula.PropertyType_DATE:
+		return statement.PropertyTypeDate, nil
+	case nebula.PropertyType_DATETIME:
+		return statement.PropertyTypeDateTime, nil
+	case nebula.PropertyType_TIME:
+		return statement.PropertyTypeTime, nil
+	case nebula.PropertyType_GEOGRAPHY:
+		if !typeDef.IsSetGeoShape() || typeDef.GetGeoShape() == meta.GeoShape_ANY {
+			return statement.PropertyTypeGeography, nil
+		}
+		return statement.PropertyType(fmt.Sprintf("%s(%s)", statement.PropertyTypeGeography,
+			strings.ToLower(typeDef.GetGeoShape().String()))), nil
+	default:
+		return "", fmt.Errorf("unsupported property type: %s", typeDef.GetType())
+	}
+}
+
+func isSchemaNotFound(errorMsg string) bool {
+	return strings.Contains(errorMsg, nebula_sirius.ErrorTagNotFound) ||
+		strings.Contains(errorMsg, nebula_sirius.ErrorEdgeNotFound)
+}
+
+// stringByColName returns the string value of the given column, an empty string for null values.
+func stringByColName(record *nebula_sirius.Record, colName string) (string, error) {
+	value, err := record.GetValueByColName(colName)
+	if err != nil {
+		return "", err
+	}
+	if !value.IsString() {
+		return "", nil
+	}
+	return value.AsString()
+}
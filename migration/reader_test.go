@@ -0,0 +1,184 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	nebula_sirius "github.com/nebula-contrib/nebula-sirius"
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSessionID = int64(1)
+
+// newTestSession returns a session authenticated on the mocked graph service.
+func newTestSession(t *testing.T, ctx context.Context) (*nebula_sirius.Session, *mocks.GraphService) {
+	graphClient := mocks.NewGraphService(t)
+
+	sessionID := testSessionID
+	graphClient.On("Authenticate", ctx, []byte("root"), []byte("nebula")).Return(&graph.AuthResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		SessionID: &sessionID,
+	}, nil)
+
+	session, err := nebula_sirius.NewSession(ctx, graphClient, "root", "nebula")
+	assert.NoError(t, err)
+
+	return session, graphClient
+}
+
+func expectExecute(graphClient *mocks.GraphService, ctx context.Context, stmt string, resp *graph.ExecutionResponse) {
+	graphClient.On("Execute", ctx, testSessionID, []byte(stmt)).Return(resp, nil).Once()
+}
+
+func succeededResponse() *graph.ExecutionResponse {
+	return &graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED}
+}
+
+func errorResponse(errorMsg string) *graph.ExecutionResponse {
+	return &graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_E_EXECUTION_ERROR,
+		ErrorMsg:  []byte(errorMsg),
+	}
+}
+
+func dataSetResponse(colNames []string, rows ...[]*nebula.Value) *graph.ExecutionResponse {
+	dataSet := &nebula.DataSet{}
+	for _, colName := range colNames {
+		dataSet.ColumnNames = append(dataSet.ColumnNames, []byte(colName))
+	}
+	for _, row := range rows {
+		dataSet.Rows = append(dataSet.Rows, &nebula.Row{Values: row})
+	}
+	return &graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data:      dataSet,
+	}
+}
+
+func sVal(s string) *nebula.Value {
+	return &nebula.Value{SVal: []byte(s)}
+}
+
+func iVal(i int64) *nebula.Value {
+	return &nebula.Value{IVal: &i}
+}
+
+func nullVal() *nebula.Value {
+	null := nebula.NullType___NULL__
+	return &nebula.Value{NVal: &null}
+}
+
+func describeResponse(rows ...[]*nebula.Value) *graph.ExecutionResponse {
+	return dataSetResponse([]string{"Field", "Type", "Null", "Default", "Comment"}, rows...)
+}
+
+func TestGraphSchemaReader_ReadTag(t *testing.T) {
+	ctx := context.Background()
+	session, graphClient := newTestSession(t, ctx)
+
+	expectExecute(graphClient, ctx, "DESCRIBE TAG account;", describeResponse(
+		[]*nebula.Value{sVal("name"), sVal("string"), sVal("NO"), nullVal(), nullVal()},
+		[]*nebula.Value{sVal("age"), sVal("int64"), sVal("YES"), nullVal(), sVal("age in years")},
+		[]*nebula.Value{sVal("created_at"), sVal("timestamp"), sVal("YES"), nullVal(), nullVal()},
+	))
+	expectExecute(graphClient, ctx, "SHOW CREATE TAG account;", dataSetResponse([]string{"Tag", "Create Tag"},
+		[]*nebula.Value{sVal("account"), sVal("CREATE TAG `account` (\n `name` string NOT NULL,\n `age` int64 NULL COMMENT \"age in years\",\n `created_at` timestamp NULL\n) ttl_duration = 100, ttl_col = \"created_at\"")},
+	))
+
+	schema, err := NewGraphSchemaReader(session).ReadTag(ctx, "account")
+	assert.NoError(t, err)
+	assert.Equal(t, &Schema{
+		Kind: SchemaKindTag,
+		Name: "account",
+		Properties: []PropertySchema{
+			{Name: "name", Type: "string", Nullable: false},
+			{Name: "age", Type: "int64", Nullable: true, Comment: "age in years"},
+			{Name: "created_at", Type: "timestamp", Nullable: true},
+		},
+		TTLDuration: 100,
+		TTLCol:      "created_at",
+	}, schema)
+}
+
+func TestGraphSchemaReader_ReadEdge_NotFound(t *testing.T) {
+	ctx := context.Background()
+	session, graphClient := newTestSession(t, ctx)
+
+	expectExecute(graphClient, ctx, "DESCRIBE EDGE transfer;", errorResponse(nebula_sirius.ErrorEdgeNotFound))
+
+	schema, err := NewGraphSchemaReader(session).ReadEdge(ctx, "transfer")
+	assert.NoError(t, err)
+	assert.Nil(t, schema)
+}
+
+func TestGraphSchemaReader_ReadTag_Error(t *testing.T) {
+	ctx := context.Background()
+	session, graphClient := newTestSession(t, ctx)
+
+	expectExecute(graphClient, ctx, "DESCRIBE TAG account;", errorResponse("SpaceNotFound: "))
+
+	schema, err := NewGraphSchemaReader(session).ReadTag(ctx, "account")
+	assert.Error(t, err)
+	assert.Nil(t, schema)
+}
+
+func TestMetaSchemaReader_ReadTag(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	ttlDuration := int64(100)
+	point := meta.GeoShape_POINT
+	metaClient.On("GetSpace", ctx, &meta.GetSpaceReq{SpaceName: []byte("bank")}).Return(&meta.GetSpaceResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Item: &meta.SpaceItem{SpaceID: 3},
+	}, nil)
+	metaClient.On("GetTag", ctx, &meta.GetTagReq{SpaceID: 3, TagName: []byte("account"), Version: -1}).Return(&meta.GetTagResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Schema: &meta.Schema{
+			Columns: []*meta.ColumnDef{
+				{Name: []byte("name"), Type: &meta.ColumnTypeDef{Type: nebula.PropertyType_FIXED_STRING, TypeLength: 32}},
+				{Name: []byte("age"), Type: &meta.ColumnTypeDef{Type: nebula.PropertyType_INT64}, Nullable: true, Comment: []byte("age in years")},
+				{Name: []byte("home"), Type: &meta.ColumnTypeDef{Type: nebula.PropertyType_GEOGRAPHY, GeoShape: &point}, Nullable: true},
+				{Name: []byte("created_at"), Type: &meta.ColumnTypeDef{Type: nebula.PropertyType_TIMESTAMP}, Nullable: true},
+			},
+			SchemaProp: &meta.SchemaProp{TTLDuration: &ttlDuration, TTLCol: []byte("created_at")},
+		},
+	}, nil)
+
+	schema, err := NewMetaSchemaReader(metaClient, "bank").ReadTag(ctx, "account")
+	assert.NoError(t, err)
+	assert.Equal(t, &Schema{
+		Kind: SchemaKindTag,
+		Name: "account",
+		Properties: []PropertySchema{
+			{Name: "name", Type: "fixed_string(32)", Nullable: false},
+			{Name: "age", Type: "int64", Nullable: true, Comment: "age in years"},
+			{Name: "home", Type: "geography(point)", Nullable: true},
+			{Name: "created_at", Type: "timestamp", Nullable: true},
+		},
+		TTLDuration: 100,
+		TTLCol:      "created_at",
+	}, schema)
+}
+
+func TestMetaSchemaReader_ReadEdge_NotFound(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("GetSpace", ctx, mock.Anything).Return(&meta.GetSpaceResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Item: &meta.SpaceItem{SpaceID: 3},
+	}, nil)
+	metaClient.On("GetEdge", ctx, mock.Anything).Return(&meta.GetEdgeResp{
+		Code: nebula.ErrorCode_E_EDGE_NOT_FOUND,
+	}, nil)
+
+	schema, err := NewMetaSchemaReader(metaClient, "bank").ReadEdge(ctx, "transfer")
+	assert.NoError(t, err)
+	assert.Nil(t, schema)
+}
@@ -0,0 +1,99 @@
+package migration
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/tag_create"
+	"strings"
+)
+
+// SchemaKind is the kind of schema, either a tag or an edge type, as the statement.SchemaType of the statement builders.
+type SchemaKind = statement.SchemaType
+
+const (
+	SchemaKindTag  = statement.SchemaTypeTag
+	SchemaKindEdge = statement.SchemaTypeEdge
+)
+
+// Schema is the definition of a tag or an edge type, either desired or read from the live space.
+type Schema struct {
+	Kind        SchemaKind
+	Name        string
+	Properties  []PropertySchema
+	TTLDuration int64
+	TTLCol      string
+}
+
+// PropertySchema is the definition of a single property of a tag or an edge type.
+type PropertySchema struct {
+	Name     string
+	Type     statement.PropertyType
+	Nullable bool
+	Comment  string
+}
+
+// TagSchema returns the schema described by the given CREATE TAG statement.
+func TagSchema(stmt tag_create.CreateTagStatement) Schema {
+	schema := Schema{
+		Kind:        SchemaKindTag,
+		Name:        stmt.GetName(),
+		TTLDuration: int64(stmt.GetTtlDuration()),
+		TTLCol:      stmt.GetTtlCol(),
+	}
+	for _, prop := range stmt.GetProperties() {
+		schema.Properties = append(schema.Properties, PropertySchema{
+			Name:     prop.GetField(),
+			Type:     prop.GetType(),
+			Nullable: prop.IsNullable(),
+			Comment:  prop.GetComment(),
+		})
+	}
+	return schema
+}
+
+// EdgeSchema returns the schema described by the given CREATE EDGE statement.
+func EdgeSchema(stmt edge_create.CreateEdgeStatement) Schema {
+	schema := Schema{
+		Kind:        SchemaKindEdge,
+		Name:        stmt.GetName(),
+		TTLDuration: int64(stmt.GetTtlDuration()),
+		TTLCol:      stmt.GetTtlCol(),
+	}
+	for _, prop := range stmt.GetProperties() {
+		schema.Properties = append(schema.Properties, PropertySchema{
+			Name:     prop.GetField(),
+			Type:     prop.GetType(),
+			Nullable: prop.IsNullable(),
+			Comment:  prop.GetComment(),
+		})
+	}
+	return schema
+}
+
+// property returns the property with the given name.
+func (s Schema) property(name string) (PropertySchema, bool) {
+	for _, prop := range s.Properties {
+		if prop.Name == name {
+			return prop, true
+		}
+	}
+	return PropertySchema{}, false
+}
+
+// ttl returns the TTL of the schema, a schema without TTL column has no TTL duration either.
+func (s Schema) ttl() (int64, string) {
+	if s.TTLCol == "" {
+		return 0, ""
+	}
+	return s.TTLDuration, s.TTLCol
+}
+
+// normalizePropertyType returns the canonical form of a property type as reported by the server,
+// e.g. int is an alias of int64.
+func normalizePropertyType(propertyType statement.PropertyType) statement.PropertyType {
+	normalized := statement.PropertyType(strings.ToLower(strings.ReplaceAll(string(propertyType), " ", "")))
+	if normalized == statement.PropertyTypeInt {
+		return statement.PropertyTypeInt64
+	}
+	return normalized
+}
@@ -0,0 +1,608 @@
+package nebula_sirius
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/nebula-contrib/nebula-sirius/nebula/storage"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// EdgeDirection is the direction of the edges GetNeighbors traverses from the vertices.
+type EdgeDirection = storage.EdgeDirection
+
+const (
+	EdgeDirectionOut  = storage.EdgeDirection_OUT_EDGE
+	EdgeDirectionIn   = storage.EdgeDirection_IN_EDGE
+	EdgeDirectionBoth = storage.EdgeDirection_BOTH
+)
+
+const (
+	// vidHashSeed is the seed of the MurmurHash2 of the string vids, as by the storage service
+	vidHashSeed = 0xc70f6907
+	// edgeDstProp and edgeRankProp are the reserved props of the edges read by GetNeighbors
+	edgeDstProp  = "_dst"
+	edgeRankProp = "_rank"
+)
+
+// StorageClientProvider returns a client of the storage service on the given host. The client is only used
+// by one request at a time.
+type StorageClientProvider func(ctx context.Context, host HostAddress) (storage.GraphStorageService, error)
+
+// StorageClient reads the graph from the storage hosts directly, bypassing the query engine for low latency
+// lookups. The requests are routed to the leaders of the partitions of the vertices, which are cached
+// along with the schemas of the spaces, so that a space is only described once by the meta service.
+type StorageClient struct {
+	metaClient    meta.MetaService      // required
+	clientOf      StorageClientProvider // required
+	leaderRetries int                   // optional
+
+	mu     sync.Mutex
+	spaces map[string]*spaceMeta
+}
+
+// StorageClientOption is a functional option for configuring a StorageClient.
+type StorageClientOption func(*StorageClient)
+
+// NewStorageClient creates a new StorageClient describing the spaces with the given meta client
+// and sending its requests to the storage clients of the given provider.
+func NewStorageClient(metaClient meta.MetaService, clientOf StorageClientProvider, options ...StorageClientOption) *StorageClient {
+	client := &StorageClient{
+		metaClient:    metaClient,
+		clientOf:      clientOf,
+		leaderRetries: 3,
+		spaces:        map[string]*spaceMeta{},
+	}
+
+	for _, opt := range options {
+		opt(client)
+	}
+
+	return client
+}
+
+// WithLeaderRetries sets how many times the requests to the partitions whose leader changed are sent
+// to their new leader, 3 by default.
+func WithLeaderRetries(retries int) func(*StorageClient) {
+	return func(c *StorageClient) {
+		c.leaderRetries = retries
+	}
+}
+
+// GetNeighborsOptions is the projection and limit of GetNeighbors.
+type GetNeighborsOptions struct {
+	tags      []string            // optional
+	tagProps  map[string][]string // optional
+	edgeProps map[string][]string // optional
+	limit     *int64              // optional
+}
+
+// GetNeighborsOption is a functional option for configuring GetNeighbors.
+type GetNeighborsOption func(*GetNeighborsOptions)
+
+// WithVertexProps reads the given props of the tag of the vertices, all its props if none is given.
+// No tag is read by default.
+func WithVertexProps(tag string, props ...string) func(*GetNeighborsOptions) {
+	return func(o *GetNeighborsOptions) {
+		if _, ok := o.tagProps[tag]; !ok {
+			o.tags = append(o.tags, tag)
+		}
+		o.tagProps[tag] = props
+	}
+}
+
+// WithEdgeProps reads the given props of the edges of the given type, all their props if none is given,
+// which is the default.
+func WithEdgeProps(edge string, props ...string) func(*GetNeighborsOptions) {
+	return func(o *GetNeighborsOptions) {
+		o.edgeProps[edge] = props
+	}
+}
+
+// WithNeighborsLimit limits the number of edges read by vertex.
+func WithNeighborsLimit(limit int64) func(*GetNeighborsOptions) {
+	return func(o *GetNeighborsOptions) {
+		o.limit = &limit
+	}
+}
+
+// Adjacency is a vertex along with its edges of the requested types and direction.
+type Adjacency struct {
+	Vertex *Node           // the vertex, with the tags read by WithVertexProps it has
+	Edges  []*Relationship // the edges, whose src or dst is the vertex depending on their direction
+}
+
+// Neighbors returns the IDs of the vertices at the other end of the edges, in the order of the edges.
+func (a Adjacency) Neighbors() []ValueWrapper {
+	neighbors := make([]ValueWrapper, 0, len(a.Edges))
+	for _, edge := range a.Edges {
+		if edge.edge.GetType() > 0 {
+			neighbors = append(neighbors, edge.GetDstVertexID())
+		} else {
+			neighbors = append(neighbors, edge.GetSrcVertexID())
+		}
+	}
+	return neighbors
+}
+
+// spaceMeta is the description of a space cached by the StorageClient.
+type spaceMeta struct {
+	id       nebula.GraphSpaceID
+	numParts int32
+	intVids  bool
+	leaders  map[nebula.PartitionID]HostAddress
+	tags     map[string]schemaProps
+	edges    map[string]schemaProps
+}
+
+// schemaProps is the ID of a tag or the type of an edge, along with its props.
+type schemaProps struct {
+	id      int32
+	version meta.SchemaVer
+	props   []string
+}
+
+// neighborsResult is the response of a storage host to the request of some partitions.
+type neighborsResult struct {
+	host HostAddress
+	resp *storage.GetNeighborsResponse
+	err  error
+}
+
+// GetNeighbors reads the edges of the given types, all the edge types of the space if none is given, from or to
+// the given vertices, along with the props of the vertices and edges projected by the options.
+// The adjacencies are returned in the order of the vertices; the vertices without any edge or tag read are missing.
+// The datetimes are in UTC, the storage service knowing nothing of the timezone of the graph service.
+func (c *StorageClient) GetNeighbors(ctx context.Context, spaceName string, vids []*nebula.Value, edges []string,
+	direction EdgeDirection, options ...GetNeighborsOption) ([]Adjacency, error) {
+	opts := &GetNeighborsOptions{
+		tagProps:  map[string][]string{},
+		edgeProps: map[string][]string{},
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	space, err := c.spaceMeta(ctx, spaceName)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := space.traverseSpec(edges, direction, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := map[nebula.PartitionID][]*nebula.Value{}
+	seen := map[string]bool{}
+	for _, vid := range vids {
+		partID, err := space.partID(vid)
+		if err != nil {
+			return nil, err
+		}
+		if key := vidKey(vid); !seen[key] {
+			seen[key] = true
+			parts[partID] = append(parts[partID], vid)
+		}
+	}
+
+	var datasets []*nebula.DataSet
+	for retry := 0; len(parts) > 0; retry++ {
+		results := c.sendGetNeighbors(ctx, space, parts, spec)
+
+		retryParts := map[nebula.PartitionID][]*nebula.Value{}
+		for _, result := range results {
+			if result.err != nil {
+				return nil, fmt.Errorf("failed to get neighbors from storage host %s:%d: %w", result.host.Host, result.host.Port, result.err)
+			}
+			for _, failedPart := range result.resp.GetResult_().GetFailedParts() {
+				partID := failedPart.GetPartID()
+				if failedPart.GetCode() != nebula.ErrorCode_E_LEADER_CHANGED || retry >= c.leaderRetries {
+					return nil, fmt.Errorf("failed to get neighbors of part %d, error code: %s", partID, failedPart.GetCode())
+				}
+				if failedPart.IsSetLeader() {
+					c.setLeader(space, partID, hostAddress(failedPart.GetLeader()))
+				} else if err := c.refreshLeaders(ctx, space); err != nil {
+					return nil, err
+				}
+				retryParts[partID] = parts[partID]
+			}
+			if result.resp.IsSetVertices() {
+				datasets = append(datasets, result.resp.GetVertices())
+			}
+		}
+		parts = retryParts
+	}
+
+	adjacencies := map[string]*Adjacency{}
+	for _, dataset := range datasets {
+		if err := space.parseNeighbors(dataset, adjacencies); err != nil {
+			return nil, err
+		}
+	}
+
+	var result []Adjacency
+	for _, vid := range vids {
+		key := vidKey(vid)
+		if adjacency, ok := adjacencies[key]; ok {
+			result = append(result, *adjacency)
+			delete(adjacencies, key)
+		}
+	}
+	return result, nil
+}
+
+// sendGetNeighbors sends a request by leader host of the partitions, concurrently.
+func (c *StorageClient) sendGetNeighbors(ctx context.Context, space *spaceMeta,
+	parts map[nebula.PartitionID][]*nebula.Value, spec *storage.TraverseSpec) []neighborsResult {
+	hostParts := map[HostAddress]map[nebula.PartitionID][]*nebula.Value{}
+	c.mu.Lock()
+	for partID, vids := range parts {
+		leader := space.leaders[partID]
+		if hostParts[leader] == nil {
+			hostParts[leader] = map[nebula.PartitionID][]*nebula.Value{}
+		}
+		hostParts[leader][partID] = vids
+	}
+	c.mu.Unlock()
+
+	results := make([]neighborsResult, 0, len(hostParts))
+	for host := range hostParts {
+		results = append(results, neighborsResult{host: host})
+	}
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(result *neighborsResult) {
+			defer wg.Done()
+			client, err := c.clientOf(ctx, result.host)
+			if err != nil {
+				result.err = err
+				return
+			}
+			result.resp, result.err = client.GetNeighbors(ctx, &storage.GetNeighborsRequest{
+				SpaceID:      space.id,
+				Parts:        hostParts[result.host],
+				TraverseSpec: spec,
+			})
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// Invalidate drops the cached description of the space, to be described again by the next request,
+// e.g. after its schemas changed.
+func (c *StorageClient) Invalidate(spaceName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.spaces, spaceName)
+}
+
+func (c *StorageClient) setLeader(space *spaceMeta, partID nebula.PartitionID, leader HostAddress) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	space.leaders[partID] = leader
+}
+
+// spaceMeta returns the cached description of the space, describing it if needed.
+func (c *StorageClient) spaceMeta(ctx context.Context, spaceName string) (*spaceMeta, error) {
+	c.mu.Lock()
+	space, ok := c.spaces[spaceName]
+	c.mu.Unlock()
+	if ok {
+		return space, nil
+	}
+
+	if spaceName == "" {
+		return nil, fmt.Errorf("space name cannot be empty")
+	}
+	spaceResp, err := c.metaClient.GetSpace(ctx, &meta.GetSpaceReq{SpaceName: []byte(spaceName)})
+	if err != nil {
+		return nil, err
+	}
+	if spaceResp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to get space %s, error code: %s", spaceName, spaceResp.GetCode())
+	}
+	properties := spaceResp.GetItem().GetProperties()
+	space = &spaceMeta{
+		id:       spaceResp.GetItem().GetSpaceID(),
+		numParts: properties.GetPartitionNum(),
+		intVids:  properties.IsSetVidType() && properties.GetVidType().GetType() == nebula.PropertyType_INT64,
+		tags:     map[string]schemaProps{},
+		edges:    map[string]schemaProps{},
+	}
+	if space.numParts <= 0 {
+		return nil, fmt.Errorf("space %s has no partition", spaceName)
+	}
+
+	if err := c.refreshLeaders(ctx, space); err != nil {
+		return nil, err
+	}
+
+	tagsResp, err := c.metaClient.ListTags(ctx, &meta.ListTagsReq{SpaceID: space.id})
+	if err != nil {
+		return nil, err
+	}
+	if tagsResp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list tags, error code: %s", tagsResp.GetCode())
+	}
+	for _, tag := range tagsResp.GetTags() {
+		addSchemaProps(space.tags, string(tag.GetTagName()), int32(tag.GetTagID()), tag.GetVersion(), tag.GetSchema())
+	}
+
+	edgesResp, err := c.metaClient.ListEdges(ctx, &meta.ListEdgesReq{SpaceID: space.id})
+	if err != nil {
+		return nil, err
+	}
+	if edgesResp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list edges, error code: %s", edgesResp.GetCode())
+	}
+	for _, edge := range edgesResp.GetEdges() {
+		addSchemaProps(space.edges, string(edge.GetEdgeName()), int32(edge.GetEdgeType()), edge.GetVersion(), edge.GetSchema())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spaces[spaceName] = space
+	return space, nil
+}
+
+// refreshLeaders describes the leaders of the partitions of the space, or their first peer if they have none.
+func (c *StorageClient) refreshLeaders(ctx context.Context, space *spaceMeta) error {
+	resp, err := c.metaClient.ListParts(ctx, &meta.ListPartsReq{SpaceID: space.id})
+	if err != nil {
+		return err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return fmt.Errorf("failed to list parts, error code: %s", resp.GetCode())
+	}
+
+	leaders := make(map[nebula.PartitionID]HostAddress, len(resp.GetParts()))
+	for _, part := range resp.GetParts() {
+		switch {
+		case part.IsSetLeader():
+			leaders[part.GetPartID()] = hostAddress(part.GetLeader())
+		case len(part.GetPeers()) > 0:
+			leaders[part.GetPartID()] = hostAddress(part.GetPeers()[0])
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	space.leaders = leaders
+	return nil
+}
+
+// addSchemaProps adds the props of the schema, keeping the latest version of the schemas listed by version.
+func addSchemaProps(schemas map[string]schemaProps, name string, id int32, version meta.SchemaVer, schema *meta.Schema) {
+	if existing, ok := schemas[name]; ok && existing.version > version {
+		return
+	}
+	props := make([]string, 0, len(schema.GetColumns()))
+	for _, column := range schema.GetColumns() {
+		props = append(props, string(column.GetName()))
+	}
+	schemas[name] = schemaProps{id: id, version: version, props: props}
+}
+
+// traverseSpec builds the traversal of the edges in the direction, with the props of the options.
+// The reverse edges are read with their negative type, as stored.
+func (s *spaceMeta) traverseSpec(edges []string, direction EdgeDirection, opts *GetNeighborsOptions) (*storage.TraverseSpec, error) {
+	if len(edges) == 0 {
+		for name := range s.edges {
+			edges = append(edges, name)
+		}
+		slices.Sort(edges)
+	}
+
+	spec := &storage.TraverseSpec{
+		EdgeDirection: direction,
+		Limit:         opts.limit,
+	}
+	for _, name := range edges {
+		edge, ok := s.edges[name]
+		if !ok {
+			return nil, fmt.Errorf("edge %s not found", name)
+		}
+		props := opts.edgeProps[name]
+		if props == nil {
+			props = edge.props
+		}
+		edgeProps := [][]byte{[]byte(edgeDstProp), []byte(edgeRankProp)}
+		for _, prop := range props {
+			if prop != edgeDstProp && prop != edgeRankProp {
+				edgeProps = append(edgeProps, []byte(prop))
+			}
+		}
+
+		var edgeTypes []nebula.EdgeType
+		switch direction {
+		case EdgeDirectionOut:
+			edgeTypes = []nebula.EdgeType{nebula.EdgeType(edge.id)}
+		case EdgeDirectionIn:
+			edgeTypes = []nebula.EdgeType{-nebula.EdgeType(edge.id)}
+		case EdgeDirectionBoth:
+			edgeTypes = []nebula.EdgeType{nebula.EdgeType(edge.id), -nebula.EdgeType(edge.id)}
+		default:
+			return nil, fmt.Errorf("invalid edge direction %s", direction)
+		}
+		for _, edgeType := range edgeTypes {
+			spec.EdgeTypes = append(spec.EdgeTypes, edgeType)
+			spec.EdgeProps = append(spec.EdgeProps, &storage.EdgeProp{Type: edgeType, Props: edgeProps})
+		}
+	}
+
+	for _, name := range opts.tags {
+		tag, ok := s.tags[name]
+		if !ok {
+			return nil, fmt.Errorf("tag %s not found", name)
+		}
+		props := opts.tagProps[name]
+		if len(props) == 0 {
+			props = tag.props
+		}
+		vertexProp := &storage.VertexProp{Tag: nebula.TagID(tag.id)}
+		for _, prop := range props {
+			vertexProp.Props = append(vertexProp.Props, []byte(prop))
+		}
+		spec.VertexProps = append(spec.VertexProps, vertexProp)
+	}
+	return spec, nil
+}
+
+// partID returns the partition of the vertex as the storage service does: the integer vids, and the string vids
+// of 8 bytes read as little-endian integers, modulo the number of partitions, the MurmurHash2 of the other
+// string vids otherwise.
+func (s *spaceMeta) partID(vid *nebula.Value) (nebula.PartitionID, error) {
+	var id uint64
+	switch {
+	case s.intVids && vid.IsSetIVal():
+		id = uint64(vid.GetIVal())
+	case !s.intVids && vid.IsSetSVal():
+		if sVal := vid.GetSVal(); len(sVal) == 8 {
+			id = binary.LittleEndian.Uint64(sVal)
+		} else {
+			id = murmurHash2(sVal, vidHashSeed)
+		}
+	case s.intVids:
+		return 0, fmt.Errorf("invalid vid %s, vids of the space are integers", ValueWrapper{vid, timezoneInfo{}})
+	default:
+		return 0, fmt.Errorf("invalid vid %s, vids of the space are strings", ValueWrapper{vid, timezoneInfo{}})
+	}
+	return nebula.PartitionID(id%uint64(s.numParts) + 1), nil
+}
+
+// parseNeighbors adds the vertices and edges of the rows of a GetNeighbors response to the adjacencies by vid.
+// The response has a column of the vid, one of the stats, one by tag named _tag:<tag>:<props...> holding the list
+// of the props of the tag, one by edge type named _edge:<+|-><edge>:<props...> holding the list of the edges,
+// each a list of props, and one of the expressions.
+func (s *spaceMeta) parseNeighbors(dataset *nebula.DataSet, adjacencies map[string]*Adjacency) error {
+	for _, row := range dataset.GetRows() {
+		if len(row.GetValues()) != len(dataset.GetColumnNames()) || len(row.GetValues()) == 0 {
+			return fmt.Errorf("invalid neighbors row of %d values for %d columns", len(row.GetValues()), len(dataset.GetColumnNames()))
+		}
+		vid := row.GetValues()[0]
+		vertex := &nebula.Vertex{Vid: vid}
+		var edges []*nebula.Edge
+
+		for i, columnName := range dataset.GetColumnNames() {
+			value := row.GetValues()[i]
+			fields := strings.Split(string(columnName), ":")
+			switch {
+			case fields[0] == "_tag" && len(fields) >= 2:
+				if !value.IsSetLVal() {
+					// the vertex does not have the tag
+					continue
+				}
+				tag := &nebula.Tag{Name: []byte(fields[1]), Props: map[string]*nebula.Value{}}
+				for j, propValue := range value.GetLVal().GetValues() {
+					if j+2 < len(fields) {
+						tag.Props[fields[j+2]] = propValue
+					}
+				}
+				vertex.Tags = append(vertex.Tags, tag)
+			case fields[0] == "_edge" && len(fields) >= 2 && len(fields[1]) > 1:
+				if !value.IsSetLVal() {
+					continue
+				}
+				edgeName := fields[1][1:]
+				edge, ok := s.edges[edgeName]
+				if !ok {
+					return fmt.Errorf("edge %s not found", edgeName)
+				}
+				edgeType := nebula.EdgeType(edge.id)
+				if fields[1][0] == '-' {
+					edgeType = -edgeType
+				}
+				for _, edgeValue := range value.GetLVal().GetValues() {
+					if !edgeValue.IsSetLVal() {
+						continue
+					}
+					edges = append(edges, newNeighborEdge(vid, edgeType, edgeName, fields[2:], edgeValue.GetLVal().GetValues()))
+				}
+			}
+		}
+
+		key := vidKey(vid)
+		adjacency, ok := adjacencies[key]
+		if !ok {
+			node, err := genNode(vertex, timezoneInfo{})
+			if err != nil {
+				return err
+			}
+			adjacency = &Adjacency{Vertex: node}
+			adjacencies[key] = adjacency
+		}
+		for _, edge := range edges {
+			relationship, err := genRelationship(edge, timezoneInfo{})
+			if err != nil {
+				return err
+			}
+			adjacency.Edges = append(adjacency.Edges, relationship)
+		}
+	}
+	return nil
+}
+
+// newNeighborEdge creates the edge of the vertex from its props. The reverse edges have the vertex as src,
+// as returned by the graph service for the edges read reversely.
+func newNeighborEdge(vid *nebula.Value, edgeType nebula.EdgeType, edgeName string, propNames []string, propValues []*nebula.Value) *nebula.Edge {
+	edge := &nebula.Edge{
+		Src:   vid,
+		Type:  edgeType,
+		Name:  []byte(edgeName),
+		Props: map[string]*nebula.Value{},
+	}
+	for i, propValue := range propValues {
+		if i >= len(propNames) {
+			break
+		}
+		switch propNames[i] {
+		case edgeDstProp:
+			edge.Dst = propValue
+		case edgeRankProp:
+			edge.Ranking = nebula.EdgeRanking(propValue.GetIVal())
+		default:
+			edge.Props[propNames[i]] = propValue
+		}
+	}
+	return edge
+}
+
+// vidKey identifies the vid, telling apart the integer and string vids.
+func vidKey(vid *nebula.Value) string {
+	if vid.IsSetIVal() {
+		return fmt.Sprintf("i%d", vid.GetIVal())
+	}
+	return "s" + string(vid.GetSVal())
+}
+
+// murmurHash2 is the 64-bit MurmurHash2 of the storage service, MurmurHash64A.
+func murmurHash2(data []byte, seed uint64) uint64 {
+	const (
+		m = 0xc6a4a7935bd1e995
+		r = 47
+	)
+	h := seed ^ (uint64(len(data)) * m)
+	for ; len(data) >= 8; data = data[8:] {
+		k := binary.LittleEndian.Uint64(data)
+		k *= m
+		k ^= k >> r
+		k *= m
+		h ^= k
+		h *= m
+	}
+	if len(data) > 0 {
+		for i := len(data) - 1; i >= 0; i-- {
+			h ^= uint64(data[i]) << (8 * i)
+		}
+		h *= m
+	}
+	h ^= h >> r
+	h *= m
+	h ^= h >> r
+	return h
+}
@@ -0,0 +1,259 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/nebula-contrib/nebula-sirius/nebula/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	storageHostA = HostAddress{Host: "192.168.8.10", Port: 9779}
+	storageHostB = HostAddress{Host: "192.168.8.11", Port: 9779}
+)
+
+func expectSpaceMeta(metaClient *mocks.MetaService, ctx context.Context, vidType nebula.PropertyType, leaders map[nebula.PartitionID]HostAddress) {
+	metaClient.On("GetSpace", ctx, &meta.GetSpaceReq{SpaceName: []byte("basketball")}).Return(&meta.GetSpaceResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Item: &meta.SpaceItem{
+			SpaceID: 1,
+			Properties: &meta.SpaceDesc{
+				SpaceName:    []byte("basketball"),
+				PartitionNum: 10,
+				VidType:      &meta.ColumnTypeDef{Type: vidType},
+			},
+		},
+	}, nil).Once()
+
+	var parts []*meta.PartItem
+	for partID := nebula.PartitionID(1); partID <= 10; partID++ {
+		leader, ok := leaders[partID]
+		if !ok {
+			leader = storageHostA
+		}
+		parts = append(parts, &meta.PartItem{PartID: partID, Leader: hostAddr(leader), Peers: []*nebula.HostAddr{hostAddr(leader)}})
+	}
+	metaClient.On("ListParts", ctx, &meta.ListPartsReq{SpaceID: 1}).Return(&meta.ListPartsResp{
+		Code:  nebula.ErrorCode_SUCCEEDED,
+		Parts: parts,
+	}, nil).Once()
+
+	metaClient.On("ListTags", ctx, &meta.ListTagsReq{SpaceID: 1}).Return(&meta.ListTagsResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Tags: []*meta.TagItem{
+			{TagID: 2, TagName: []byte("player"), Version: 0, Schema: &meta.Schema{Columns: []*meta.ColumnDef{{Name: []byte("name")}}}},
+			{TagID: 2, TagName: []byte("player"), Version: 1, Schema: &meta.Schema{Columns: []*meta.ColumnDef{{Name: []byte("name")}, {Name: []byte("age")}}}},
+		},
+	}, nil).Once()
+	metaClient.On("ListEdges", ctx, &meta.ListEdgesReq{SpaceID: 1}).Return(&meta.ListEdgesResp{
+		Code:  nebula.ErrorCode_SUCCEEDED,
+		Edges: []*meta.EdgeItem{{EdgeType: 3, EdgeName: []byte("follow"), Schema: &meta.Schema{Columns: []*meta.ColumnDef{{Name: []byte("degree")}}}}},
+	}, nil).Once()
+}
+
+func storageClients(clients map[HostAddress]*mocks.GraphStorageService) StorageClientProvider {
+	return func(ctx context.Context, host HostAddress) (storage.GraphStorageService, error) {
+		return clients[host], nil
+	}
+}
+
+func neighborsResponse(columns []string, rows ...[]*nebula.Value) *storage.GetNeighborsResponse {
+	dataset := &nebula.DataSet{}
+	for _, column := range columns {
+		dataset.ColumnNames = append(dataset.ColumnNames, []byte(column))
+	}
+	for _, row := range rows {
+		dataset.Rows = append(dataset.Rows, &nebula.Row{Values: row})
+	}
+	return &storage.GetNeighborsResponse{Result_: &storage.ResponseCommon{}, Vertices: dataset}
+}
+
+func listValue(values ...*nebula.Value) *nebula.Value {
+	return &nebula.Value{LVal: &nebula.NList{Values: values}}
+}
+
+func TestMurmurHash2(t *testing.T) {
+	// computed by MurmurHash64A of the storage service
+	assert.Equal(t, uint64(7289597605171850056), murmurHash2([]byte("player100"), vidHashSeed))
+	assert.Equal(t, uint64(5662213458193308137), murmurHash2([]byte("Tim Duncan"), vidHashSeed))
+	assert.Equal(t, uint64(4993892634952068459), murmurHash2([]byte("a"), vidHashSeed))
+	assert.Equal(t, uint64(6313177053991227731), murmurHash2([]byte("hello, nebula!"), vidHashSeed))
+}
+
+func TestSpaceMeta_PartID(t *testing.T) {
+	strSpace := &spaceMeta{numParts: 10}
+	partID, err := strSpace.partID(&nebula.Value{SVal: []byte("player100")})
+	require.NoError(t, err)
+	assert.Equal(t, nebula.PartitionID(7), partID)
+	// the vids of 8 bytes are read as integers
+	partID, err = strSpace.partID(&nebula.Value{SVal: []byte{0x0d, 0, 0, 0, 0, 0, 0, 0}})
+	require.NoError(t, err)
+	assert.Equal(t, nebula.PartitionID(4), partID)
+	_, err = strSpace.partID(setIVal(1))
+	assert.EqualError(t, err, "invalid vid 1, vids of the space are strings")
+
+	intSpace := &spaceMeta{numParts: 10, intVids: true}
+	partID, err = intSpace.partID(setIVal(23))
+	require.NoError(t, err)
+	assert.Equal(t, nebula.PartitionID(4), partID)
+	partID, err = intSpace.partID(setIVal(-1))
+	require.NoError(t, err)
+	assert.Equal(t, nebula.PartitionID(6), partID)
+	_, err = intSpace.partID(&nebula.Value{SVal: []byte("player100")})
+	assert.EqualError(t, err, `invalid vid "player100", vids of the space are integers`)
+}
+
+func TestStorageClient_GetNeighbors(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+	clientA := mocks.NewGraphStorageService(t)
+	clientB := mocks.NewGraphStorageService(t)
+	expectSpaceMeta(metaClient, ctx, nebula.PropertyType_FIXED_STRING, map[nebula.PartitionID]HostAddress{8: storageHostB})
+
+	player100 := &nebula.Value{SVal: []byte("player100")}
+	timDuncan := &nebula.Value{SVal: []byte("Tim Duncan")}
+	spec := &storage.TraverseSpec{
+		EdgeTypes:     []nebula.EdgeType{3, -3},
+		EdgeDirection: storage.EdgeDirection_BOTH,
+		EdgeProps: []*storage.EdgeProp{
+			{Type: 3, Props: [][]byte{[]byte("_dst"), []byte("_rank"), []byte("degree")}},
+			{Type: -3, Props: [][]byte{[]byte("_dst"), []byte("_rank"), []byte("degree")}},
+		},
+		VertexProps: []*storage.VertexProp{{Tag: 2, Props: [][]byte{[]byte("name"), []byte("age")}}},
+	}
+	columns := []string{"_vid", "_stats", "_tag:player:name:age", "_edge:+follow:_dst:_rank:degree", "_edge:-follow:_dst:_rank:degree", "_expr"}
+
+	clientA.On("GetNeighbors", ctx, &storage.GetNeighborsRequest{
+		SpaceID:      1,
+		Parts:        map[nebula.PartitionID][]*nebula.Value{7: {player100}},
+		TraverseSpec: spec,
+	}).Return(neighborsResponse(columns, []*nebula.Value{
+		player100,
+		{NVal: nebula.NullTypePtr(nebula.NullType___NULL__)},
+		listValue(&nebula.Value{SVal: []byte("Tony Parker")}, setIVal(36)),
+		listValue(listValue(timDuncan, setIVal(0), setIVal(95))),
+		{NVal: nebula.NullTypePtr(nebula.NullType___NULL__)},
+		{NVal: nebula.NullTypePtr(nebula.NullType___NULL__)},
+	}), nil).Once()
+	clientB.On("GetNeighbors", ctx, &storage.GetNeighborsRequest{
+		SpaceID:      1,
+		Parts:        map[nebula.PartitionID][]*nebula.Value{8: {timDuncan}},
+		TraverseSpec: spec,
+	}).Return(neighborsResponse(columns, []*nebula.Value{
+		timDuncan,
+		{NVal: nebula.NullTypePtr(nebula.NullType___NULL__)},
+		{NVal: nebula.NullTypePtr(nebula.NullType___NULL__)},
+		listValue(),
+		listValue(listValue(player100, setIVal(1), setIVal(95))),
+		{NVal: nebula.NullTypePtr(nebula.NullType___NULL__)},
+	}), nil).Once()
+
+	client := NewStorageClient(metaClient, storageClients(map[HostAddress]*mocks.GraphStorageService{storageHostA: clientA, storageHostB: clientB}))
+	adjacencies, err := client.GetNeighbors(ctx, "basketball", []*nebula.Value{timDuncan, player100, timDuncan},
+		[]string{"follow"}, EdgeDirectionBoth, WithVertexProps("player"))
+	require.NoError(t, err)
+	require.Len(t, adjacencies, 2)
+
+	assert.Equal(t, `("Tim Duncan")`, adjacencies[0].Vertex.String())
+	require.Len(t, adjacencies[0].Edges, 1)
+	edge := adjacencies[0].Edges[0]
+	assert.Equal(t, "follow", edge.GetEdgeName())
+	assert.Equal(t, int64(1), edge.GetRanking())
+	assert.Equal(t, `"player100"`, edge.GetSrcVertexID().String())
+	assert.Equal(t, `"Tim Duncan"`, edge.GetDstVertexID().String())
+	assert.Equal(t, "95", edge.Properties()["degree"].String())
+	assert.Equal(t, `"player100"`, adjacencies[0].Neighbors()[0].String())
+
+	assert.Equal(t, `("player100" :player{age: 36, name: "Tony Parker"})`, adjacencies[1].Vertex.String())
+	require.Len(t, adjacencies[1].Edges, 1)
+	assert.Equal(t, `"player100"`, adjacencies[1].Edges[0].GetSrcVertexID().String())
+	assert.Equal(t, `"Tim Duncan"`, adjacencies[1].Edges[0].GetDstVertexID().String())
+	assert.Equal(t, `"Tim Duncan"`, adjacencies[1].Neighbors()[0].String())
+
+	// the space is described once
+	clientA.On("GetNeighbors", ctx, &storage.GetNeighborsRequest{
+		SpaceID: 1,
+		Parts:   map[nebula.PartitionID][]*nebula.Value{7: {player100}},
+		TraverseSpec: &storage.TraverseSpec{
+			EdgeTypes:     []nebula.EdgeType{3},
+			EdgeDirection: storage.EdgeDirection_OUT_EDGE,
+			EdgeProps:     []*storage.EdgeProp{{Type: 3, Props: [][]byte{[]byte("_dst"), []byte("_rank")}}},
+			Limit:         setIVal(10).IVal,
+		},
+	}).Return(neighborsResponse([]string{"_vid", "_stats", "_edge:+follow:_dst:_rank", "_expr"}), nil).Once()
+	adjacencies, err = client.GetNeighbors(ctx, "basketball", []*nebula.Value{player100}, nil, EdgeDirectionOut,
+		WithEdgeProps("follow", "_rank"), WithNeighborsLimit(10))
+	require.NoError(t, err)
+	assert.Empty(t, adjacencies)
+}
+
+func TestStorageClient_GetNeighbors_LeaderChanged(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+	clientA := mocks.NewGraphStorageService(t)
+	clientB := mocks.NewGraphStorageService(t)
+	expectSpaceMeta(metaClient, ctx, nebula.PropertyType_INT64, nil)
+
+	vid := setIVal(23)
+	req := &storage.GetNeighborsRequest{
+		SpaceID: 1,
+		Parts:   map[nebula.PartitionID][]*nebula.Value{4: {vid}},
+		TraverseSpec: &storage.TraverseSpec{
+			EdgeTypes:     []nebula.EdgeType{-3},
+			EdgeDirection: storage.EdgeDirection_IN_EDGE,
+			EdgeProps:     []*storage.EdgeProp{{Type: -3, Props: [][]byte{[]byte("_dst"), []byte("_rank"), []byte("degree")}}},
+		},
+	}
+	clientA.On("GetNeighbors", ctx, req).Return(&storage.GetNeighborsResponse{
+		Result_: &storage.ResponseCommon{FailedParts: []*storage.PartitionResult_{
+			{Code: nebula.ErrorCode_E_LEADER_CHANGED, PartID: 4, Leader: hostAddr(storageHostB)},
+		}},
+	}, nil).Once()
+	clientB.On("GetNeighbors", ctx, req).Return(neighborsResponse(
+		[]string{"_vid", "_stats", "_edge:-follow:_dst:_rank:degree", "_expr"},
+		[]*nebula.Value{vid, {}, listValue(listValue(setIVal(42), setIVal(0), setIVal(90))), {}},
+	), nil).Once()
+
+	client := NewStorageClient(metaClient, storageClients(map[HostAddress]*mocks.GraphStorageService{storageHostA: clientA, storageHostB: clientB}))
+	adjacencies, err := client.GetNeighbors(ctx, "basketball", []*nebula.Value{vid}, []string{"follow"}, EdgeDirectionIn)
+	require.NoError(t, err)
+	require.Len(t, adjacencies, 1)
+	require.Len(t, adjacencies[0].Edges, 1)
+	assert.Equal(t, "42", adjacencies[0].Edges[0].GetSrcVertexID().String())
+	assert.Equal(t, "23", adjacencies[0].Edges[0].GetDstVertexID().String())
+	assert.Equal(t, "42", adjacencies[0].Neighbors()[0].String())
+}
+
+func TestStorageClient_GetNeighbors_Errors(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+	clientA := mocks.NewGraphStorageService(t)
+	expectSpaceMeta(metaClient, ctx, nebula.PropertyType_FIXED_STRING, nil)
+	client := NewStorageClient(metaClient, storageClients(map[HostAddress]*mocks.GraphStorageService{storageHostA: clientA}))
+
+	vids := []*nebula.Value{{SVal: []byte("player100")}}
+	_, err := client.GetNeighbors(ctx, "basketball", vids, []string{"serve"}, EdgeDirectionOut)
+	assert.EqualError(t, err, "edge serve not found")
+
+	_, err = client.GetNeighbors(ctx, "basketball", vids, nil, EdgeDirectionOut, WithVertexProps("team"))
+	assert.EqualError(t, err, "tag team not found")
+
+	clientA.On("GetNeighbors", ctx, &storage.GetNeighborsRequest{
+		SpaceID: 1,
+		Parts:   map[nebula.PartitionID][]*nebula.Value{7: vids},
+		TraverseSpec: &storage.TraverseSpec{
+			EdgeTypes:     []nebula.EdgeType{3},
+			EdgeDirection: storage.EdgeDirection_OUT_EDGE,
+			EdgeProps:     []*storage.EdgeProp{{Type: 3, Props: [][]byte{[]byte("_dst"), []byte("_rank"), []byte("degree")}}},
+		},
+	}).Return(&storage.GetNeighborsResponse{
+		Result_: &storage.ResponseCommon{FailedParts: []*storage.PartitionResult_{{Code: nebula.ErrorCode_E_PART_NOT_FOUND, PartID: 7}}},
+	}, nil).Once()
+	_, err = client.GetNeighbors(ctx, "basketball", vids, nil, EdgeDirectionOut)
+	assert.EqualError(t, err, "failed to get neighbors of part 7, error code: E_PART_NOT_FOUND")
+}
@@ -0,0 +1,309 @@
+package nebula_sirius
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fullScanOperators are the operators reading all the vertices or edges of a space, or all the entries of an index.
+var fullScanOperators = map[string]bool{
+	"scanvertices":      true,
+	"scanedges":         true,
+	"indexfullscan":     true,
+	"tagindexfullscan":  true,
+	"edgeindexfullscan": true,
+}
+
+// Plan is the execution plan of a statement, as returned by EXPLAIN and PROFILE.
+type Plan struct {
+	Root         *PlanNode   // the last operator, whose output is the result of the statement
+	Nodes        []*PlanNode // all the operators, in the order of the plan description
+	Format       string
+	OptimizeTime time.Duration
+	nodeByID     map[int64]*PlanNode
+}
+
+// PlanNode is an operator of an execution plan.
+type PlanNode struct {
+	ID           int64
+	Name         string
+	OutputVar    string
+	Description  map[string]string // the arguments of the operator, e.g. columns or filter
+	Profiles     []PlanNodeProfile // the profiling stats by execution, empty for EXPLAIN
+	Branch       *PlanNodeBranch   // the branch of a Select or Loop the operator ends, if any
+	Dependencies []*PlanNode       // the operators whose output the operator reads
+
+	// DoBranch and ElseBranch are the last operators of the then and else branches of a Select,
+	// DoBranch the last operator of the body of a Loop.
+	DoBranch   *PlanNode
+	ElseBranch *PlanNode
+}
+
+// PlanNodeBranch tells which branch of its conditional operator a plan node ends.
+type PlanNodeBranch struct {
+	IsDoBranch      bool
+	ConditionNodeID int64
+}
+
+// PlanNodeProfile is the profiling stats of an execution of a plan node. An operator in the body of
+// a Loop has a profile by iteration.
+type PlanNodeProfile struct {
+	Rows          int64
+	ExecDuration  time.Duration
+	TotalDuration time.Duration
+	OtherStats    map[string]string
+}
+
+// PlanNodeHostStats is the profiling stats of the requests of a plan node to a storage host.
+type PlanNodeHostStats struct {
+	Host          string
+	ExecDuration  time.Duration
+	TotalDuration time.Duration
+}
+
+// Explain returns the execution plan of the statement, without executing it.
+func (s *Session) Explain(ctx context.Context, stmt string) (*Plan, error) {
+	rs, err := s.Execute(ctx, "EXPLAIN "+stmt)
+	if err != nil {
+		return nil, err
+	}
+	return rs.Plan()
+}
+
+// Profile executes the statement and returns its execution plan along with the profiling stats of the operators.
+func (s *Session) Profile(ctx context.Context, stmt string) (*Plan, error) {
+	rs, err := s.Execute(ctx, "PROFILE "+stmt)
+	if err != nil {
+		return nil, err
+	}
+	return rs.Plan()
+}
+
+// Plan returns the execution plan of the result set of an EXPLAIN or PROFILE statement as a tree of operators.
+func (res ResultSet) Plan() (*Plan, error) {
+	if !res.IsSetPlanDesc() || len(res.GetPlanDesc().GetPlanNodeDescs()) == 0 {
+		return nil, fmt.Errorf("result set has no execution plan")
+	}
+	planDesc := res.GetPlanDesc()
+
+	plan := &Plan{
+		Format:       string(planDesc.GetFormat()),
+		OptimizeTime: time.Duration(planDesc.GetOptimizeTimeInUs()) * time.Microsecond,
+		nodeByID:     make(map[int64]*PlanNode, len(planDesc.GetPlanNodeDescs())),
+	}
+	for _, planNodeDesc := range planDesc.GetPlanNodeDescs() {
+		node := newPlanNode(planNodeDesc)
+		plan.Nodes = append(plan.Nodes, node)
+		plan.nodeByID[node.ID] = node
+	}
+
+	dependents := map[int64]bool{}
+	for i, planNodeDesc := range planDesc.GetPlanNodeDescs() {
+		node := plan.Nodes[i]
+		for _, depID := range planNodeDesc.GetDependencies() {
+			dep, ok := plan.nodeByID[depID]
+			if !ok {
+				return nil, fmt.Errorf("plan node %d depends on unknown node %d", node.ID, depID)
+			}
+			node.Dependencies = append(node.Dependencies, dep)
+			dependents[depID] = true
+		}
+
+		if node.Branch != nil {
+			cond, ok := plan.nodeByID[node.Branch.ConditionNodeID]
+			if !ok {
+				return nil, fmt.Errorf("plan node %d ends a branch of unknown node %d", node.ID, node.Branch.ConditionNodeID)
+			}
+			if node.Branch.IsDoBranch {
+				cond.DoBranch = node
+			} else {
+				cond.ElseBranch = node
+			}
+			dependents[node.ID] = true
+		}
+	}
+
+	for _, node := range plan.Nodes {
+		if !dependents[node.ID] {
+			plan.Root = node
+			break
+		}
+	}
+	if plan.Root == nil {
+		return nil, fmt.Errorf("execution plan has no root node")
+	}
+	return plan, nil
+}
+
+func newPlanNode(planNodeDesc *graph.PlanNodeDescription) *PlanNode {
+	node := &PlanNode{
+		ID:          planNodeDesc.GetID(),
+		Name:        string(planNodeDesc.GetName()),
+		OutputVar:   string(planNodeDesc.GetOutputVar()),
+		Description: make(map[string]string, len(planNodeDesc.GetDescription())),
+	}
+	for _, pair := range planNodeDesc.GetDescription() {
+		node.Description[string(pair.GetKey())] = string(pair.GetValue())
+	}
+	for _, profile := range planNodeDesc.GetProfiles() {
+		otherStats := make(map[string]string, len(profile.GetOtherStats()))
+		for k, v := range profile.GetOtherStats() {
+			otherStats[k] = string(v)
+		}
+		node.Profiles = append(node.Profiles, PlanNodeProfile{
+			Rows:          profile.GetRows(),
+			ExecDuration:  time.Duration(profile.GetExecDurationInUs()) * time.Microsecond,
+			TotalDuration: time.Duration(profile.GetTotalDurationInUs()) * time.Microsecond,
+			OtherStats:    otherStats,
+		})
+	}
+	if planNodeDesc.IsSetBranchInfo() {
+		node.Branch = &PlanNodeBranch{
+			IsDoBranch:      planNodeDesc.GetBranchInfo().GetIsDoBranch(),
+			ConditionNodeID: planNodeDesc.GetBranchInfo().GetConditionNodeID(),
+		}
+	}
+	return node
+}
+
+// Node returns the plan node of the given ID, nil if there is none.
+func (p *Plan) Node(id int64) *PlanNode {
+	return p.nodeByID[id]
+}
+
+// IsProfiled tells whether the plan holds the profiling stats of PROFILE.
+func (p *Plan) IsProfiled() bool {
+	for _, node := range p.Nodes {
+		if len(node.Profiles) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FindNodes returns the plan nodes of the operator of the given name, case-insensitively.
+func (p *Plan) FindNodes(name string) []*PlanNode {
+	var nodes []*PlanNode
+	for _, node := range p.Nodes {
+		if strings.EqualFold(node.Name, name) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// SlowestNode returns the plan node with the longest execution time, summed over its executions,
+// nil if the plan is not profiled.
+func (p *Plan) SlowestNode() *PlanNode {
+	var slowest *PlanNode
+	for _, node := range p.Nodes {
+		if len(node.Profiles) == 0 {
+			continue
+		}
+		if slowest == nil || node.ExecDuration() > slowest.ExecDuration() {
+			slowest = node
+		}
+	}
+	return slowest
+}
+
+// FullScans returns the plan nodes scanning all the vertices or edges of the space, or all the entries of an index.
+func (p *Plan) FullScans() []*PlanNode {
+	var nodes []*PlanNode
+	for _, node := range p.Nodes {
+		if node.IsFullScan() {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// IndexScans returns the plan nodes looking up a prefix or a range of an index.
+func (p *Plan) IndexScans() []*PlanNode {
+	var nodes []*PlanNode
+	for _, node := range p.Nodes {
+		if node.IsIndexScan() {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// IsFullScan tells whether the operator scans all the vertices or edges of the space, or all the entries of an index.
+func (n *PlanNode) IsFullScan() bool {
+	return fullScanOperators[strings.ToLower(n.Name)]
+}
+
+// IsIndexScan tells whether the operator looks up a prefix or a range of an index.
+func (n *PlanNode) IsIndexScan() bool {
+	name := strings.ToLower(n.Name)
+	return strings.Contains(name, "index") && strings.HasSuffix(name, "scan") && !n.IsFullScan()
+}
+
+// Rows returns the number of rows output by the operator, summed over its executions.
+func (n *PlanNode) Rows() int64 {
+	var rows int64
+	for _, profile := range n.Profiles {
+		rows += profile.Rows
+	}
+	return rows
+}
+
+// ExecDuration returns the execution time of the operator, summed over its executions.
+func (n *PlanNode) ExecDuration() time.Duration {
+	var duration time.Duration
+	for _, profile := range n.Profiles {
+		duration += profile.ExecDuration
+	}
+	return duration
+}
+
+// TotalDuration returns the time spent in the operator including its scheduling, summed over its executions.
+func (n *PlanNode) TotalDuration() time.Duration {
+	var duration time.Duration
+	for _, profile := range n.Profiles {
+		duration += profile.TotalDuration
+	}
+	return duration
+}
+
+// HostStats returns the stats of the requests to the storage hosts, as reported in the other stats of the profile
+// by the operators reading the storage, sorted by host.
+func (p PlanNodeProfile) HostStats() []PlanNodeHostStats {
+	var stats []PlanNodeHostStats
+	for _, value := range p.OtherStats {
+		var hostStats struct {
+			Host  string `json:"host"`
+			Exec  string `json:"exec"`
+			Total string `json:"total"`
+		}
+		if err := json.Unmarshal([]byte(value), &hostStats); err != nil || hostStats.Host == "" {
+			continue
+		}
+		stats = append(stats, PlanNodeHostStats{
+			Host:          hostStats.Host,
+			ExecDuration:  parseProfileDuration(hostStats.Exec),
+			TotalDuration: parseProfileDuration(hostStats.Total),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Host < stats[j].Host
+	})
+	return stats
+}
+
+// parseProfileDuration parses the durations of the profiles, e.g. 1024(us).
+func parseProfileDuration(s string) time.Duration {
+	s = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(s), "(us)"), "us")
+	us, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(us) * time.Microsecond
+}
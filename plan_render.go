@@ -0,0 +1,176 @@
+package nebula_sirius
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"strings"
+)
+
+// planJSONNode is a plan node of MakePlanJSON, along with the nodes it reads from.
+type planJSONNode struct {
+	ID           int64             `json:"id"`
+	Name         string            `json:"name"`
+	OutputVar    string            `json:"outputVar"`
+	Description  map[string]string `json:"description,omitempty"`
+	Profiles     []planJSONProfile `json:"profiles,omitempty"`
+	Branches     []planJSONBranch  `json:"branches,omitempty"`
+	Dependencies []*planJSONNode   `json:"dependencies,omitempty"`
+	Ref          bool              `json:"ref,omitempty"` // the node is one of its own ancestors, so its children are left out
+}
+
+type planJSONProfile struct {
+	Rows          int64             `json:"rows"`
+	ExecTimeInUs  int64             `json:"execTimeInUs"`
+	TotalTimeInUs int64             `json:"totalTimeInUs"`
+	OtherStats    map[string]string `json:"otherStats,omitempty"`
+}
+
+// planJSONBranch is a branch of a select or loop node, from the last node of the branch
+// down to the start node it begins with.
+type planJSONBranch struct {
+	Label   string        `json:"label"` // Y or N for select, Do for loop
+	StartID int64         `json:"startId"`
+	Node    *planJSONNode `json:"node"`
+}
+
+// MakeMermaidGraph generates the execution plan as a Mermaid flowchart, with the same nodes, edges and
+// branches as MakeDotGraph, and the profiling stats of PROFILE on the nodes.
+func (res ResultSet) MakeMermaidGraph() string {
+	var builder strings.Builder
+	builder.WriteString("flowchart BT\n")
+	writePlanGraph(&builder, res.GetPlanDesc(), mermaidPlanGraphEmitter)
+	return builder.String()
+}
+
+var mermaidPlanGraphEmitter = planGraphEmitter{
+	node:            mermaidNodeString,
+	conditionalNode: mermaidConditionalNodeString,
+	edge:            mermaidEdgeString,
+	conditionalEdge: mermaidConditionalEdgeString,
+}
+
+func mermaidNodeString(planNodeDesc *graph.PlanNodeDescription, planNodeName string) string {
+	lines := []string{planNodeName, "outputVar: " + string(planNodeDesc.GetOutputVar())}
+	for _, pair := range planNodeDesc.GetDescription() {
+		if string(pair.GetKey()) == "inputVar" {
+			lines = append(lines, "inputVar: "+string(pair.GetValue()))
+		}
+	}
+	lines = append(lines, mermaidProfileLines(planNodeDesc)...)
+	return fmt.Sprintf("    %s[\"%s\"]\n", planNodeName, mermaidString(lines))
+}
+
+func mermaidConditionalNodeString(planNodeDesc *graph.PlanNodeDescription, planNodeName string) string {
+	lines := append([]string{planNodeName}, mermaidProfileLines(planNodeDesc)...)
+	return fmt.Sprintf("    %s{\"%s\"}\n", planNodeName, mermaidString(lines))
+}
+
+// mermaidProfileLines sums the profiling stats of the node over its executions.
+func mermaidProfileLines(planNodeDesc *graph.PlanNodeDescription) []string {
+	if !planNodeDesc.IsSetProfiles() || len(planNodeDesc.GetProfiles()) == 0 {
+		return nil
+	}
+	var rows, execTime, totalTime int64
+	for _, profile := range planNodeDesc.GetProfiles() {
+		rows += profile.GetRows()
+		execTime += profile.GetExecDurationInUs()
+		totalTime += profile.GetTotalDurationInUs()
+	}
+	lines := []string{fmt.Sprintf("rows: %d", rows), fmt.Sprintf("execTime: %d(us)", execTime), fmt.Sprintf("totalTime: %d(us)", totalTime)}
+	if versions := len(planNodeDesc.GetProfiles()); versions > 1 {
+		lines = append(lines, fmt.Sprintf("versions: %d", versions))
+	}
+	return lines
+}
+
+// mermaidString joins the lines of a label, escaping the characters Mermaid would interpret.
+func mermaidString(lines []string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ")
+	for i, line := range lines {
+		lines[i] = replacer.Replace(line)
+	}
+	return strings.Join(lines, "<br/>")
+}
+
+func mermaidEdgeString(start, end string) string {
+	return fmt.Sprintf("    %s --> %s\n", start, end)
+}
+
+func mermaidConditionalEdgeString(start, end, label string) string {
+	return fmt.Sprintf("    %s -.->|%s| %s\n", start, label, end)
+}
+
+// MakePlanJSON generates the execution plan as a JSON tree for web UIs, from the root node down to the start
+// nodes through the dependencies, with the branches of the select and loop nodes labeled as by MakeDotGraph
+// and the profiling stats of PROFILE on the nodes.
+func (res ResultSet) MakePlanJSON() ([]byte, error) {
+	plan, err := res.Plan()
+	if err != nil {
+		return nil, err
+	}
+	p := res.GetPlanDesc()
+
+	var build func(planNode *PlanNode, ancestors map[*PlanNode]bool) *planJSONNode
+	build = func(planNode *PlanNode, ancestors map[*PlanNode]bool) *planJSONNode {
+		node := &planJSONNode{
+			ID:        planNode.ID,
+			Name:      planNode.Name,
+			OutputVar: planNode.OutputVar,
+		}
+		if ancestors[planNode] {
+			node.Ref = true
+			return node
+		}
+		ancestors[planNode] = true
+		defer delete(ancestors, planNode)
+
+		if len(planNode.Description) > 0 {
+			node.Description = planNode.Description
+		}
+		for _, profile := range planNode.Profiles {
+			jsonProfile := planJSONProfile{
+				Rows:          profile.Rows,
+				ExecTimeInUs:  profile.ExecDuration.Microseconds(),
+				TotalTimeInUs: profile.TotalDuration.Microseconds(),
+			}
+			if len(profile.OtherStats) > 0 {
+				jsonProfile.OtherStats = profile.OtherStats
+			}
+			node.Profiles = append(node.Profiles, jsonProfile)
+		}
+
+		for _, doBranch := range []bool{true, false} {
+			endNode := planNode.ElseBranch
+			if doBranch {
+				endNode = planNode.DoBranch
+			}
+			if endNode == nil {
+				continue
+			}
+			label := condEdgeLabel(nodeById(p, planNode.ID), doBranch)
+			if label == "" {
+				continue
+			}
+			node.Branches = append(node.Branches, planJSONBranch{
+				Label:   label,
+				StartID: findFirstStartNodeFrom(p, endNode.ID),
+				Node:    build(endNode, ancestors),
+			})
+		}
+		for _, dep := range planNode.Dependencies {
+			node.Dependencies = append(node.Dependencies, build(dep, ancestors))
+		}
+		return node
+	}
+
+	return json.Marshal(struct {
+		Format           string        `json:"format"`
+		OptimizeTimeInUs int64         `json:"optimizeTimeInUs"`
+		Root             *planJSONNode `json:"root"`
+	}{
+		Format:           plan.Format,
+		OptimizeTimeInUs: plan.OptimizeTime.Microseconds(),
+		Root:             build(plan.Root, map[*PlanNode]bool{}),
+	})
+}
@@ -0,0 +1,64 @@
+package nebula_sirius
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getSelectPlanDesc() *graph.PlanDescription {
+	return &graph.PlanDescription{
+		PlanNodeDescs: []*graph.PlanNodeDescription{
+			{Name: []byte("Project"), ID: 5, OutputVar: []byte("__Project_5"), Dependencies: []int64{4}},
+			{Name: []byte("Select"), ID: 4, OutputVar: []byte("__Select_4"), Dependencies: []int64{0},
+				Description: []*graph.Pair{{Key: []byte("condition"), Value: []byte(`$a > "b"`)}}},
+			{Name: []byte("Project"), ID: 3, OutputVar: []byte("__Project_3"), Dependencies: []int64{2},
+				BranchInfo: &graph.PlanNodeBranchInfo{IsDoBranch: true, ConditionNodeID: 4}},
+			{Name: []byte("Start"), ID: 2, OutputVar: []byte("__Start_2")},
+			{Name: []byte("Project"), ID: 1, OutputVar: []byte("__Project_1"), Dependencies: []int64{6},
+				BranchInfo: &graph.PlanNodeBranchInfo{IsDoBranch: false, ConditionNodeID: 4}},
+			{Name: []byte("Start"), ID: 6, OutputVar: []byte("__Start_6")},
+			{Name: []byte("Start"), ID: 0, OutputVar: []byte("__Start_0")},
+		},
+		NodeIndexMap: map[int64]int64{5: 0, 4: 1, 3: 2, 2: 3, 1: 4, 6: 5, 0: 6},
+		Format:       []byte("row"),
+	}
+}
+
+func TestResultSet_MakeMermaidGraph_MakePlanJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		planDesc *graph.PlanDescription
+	}{
+		{name: "loop", planDesc: getLoopPlanDesc()},
+		{name: "select", planDesc: getSelectPlanDesc()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resultSet, err := genResultSet(&graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED, PlanDesc: tc.planDesc}, testTimezone)
+			require.NoError(t, err)
+
+			assertGolden(t, filepath.Join("testdata", "plan_render", tc.name+".mmd"), []byte(resultSet.MakeMermaidGraph()))
+
+			encoded, err := resultSet.MakePlanJSON()
+			require.NoError(t, err)
+			var indented bytes.Buffer
+			require.NoError(t, json.Indent(&indented, encoded, "", "  "))
+			assertGolden(t, filepath.Join("testdata", "plan_render", tc.name+".json"), append(indented.Bytes(), '\n'))
+		})
+	}
+}
+
+func TestResultSet_MakePlanJSON_NoPlan(t *testing.T) {
+	resultSet, err := genResultSet(&graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED}, testTimezone)
+	require.NoError(t, err)
+	_, err = resultSet.MakePlanJSON()
+	assert.EqualError(t, err, "result set has no execution plan")
+}
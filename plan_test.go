@@ -0,0 +1,149 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getLoopPlanDesc() *graph.PlanDescription {
+	profile := func(rows, exec int64, otherStats map[string][]byte) []*graph.ProfilingStats {
+		return []*graph.ProfilingStats{{Rows: rows, ExecDurationInUs: exec, TotalDurationInUs: exec + 10, OtherStats: otherStats}}
+	}
+	return &graph.PlanDescription{
+		PlanNodeDescs: []*graph.PlanNodeDescription{
+			{Name: []byte("Project"), ID: 5, OutputVar: []byte("__Project_5"), Dependencies: []int64{4},
+				Description: []*graph.Pair{{Key: []byte("columns"), Value: []byte(`["$-.name AS name"]`)}},
+				Profiles:    profile(2, 20, nil)},
+			{Name: []byte("Loop"), ID: 4, OutputVar: []byte("__Loop_4"), Dependencies: []int64{0},
+				Profiles: profile(1, 5, nil)},
+			{Name: []byte("Filter"), ID: 3, OutputVar: []byte("__Filter_3"), Dependencies: []int64{2},
+				BranchInfo: &graph.PlanNodeBranchInfo{IsDoBranch: true, ConditionNodeID: 4},
+				Profiles: []*graph.ProfilingStats{
+					{Rows: 3, ExecDurationInUs: 30, TotalDurationInUs: 40},
+					{Rows: 4, ExecDurationInUs: 50, TotalDurationInUs: 60},
+				}},
+			{Name: []byte("TagIndexPrefixScan"), ID: 2, OutputVar: []byte("__TagIndexPrefixScan_2"), Dependencies: []int64{1},
+				Profiles: profile(7, 60, map[string][]byte{
+					"resp[0]":   []byte(`{"exec": "40(us)", "host": "storaged1:9779", "total": "55(us)"}`),
+					"resp[1]":   []byte(`{"exec": "30(us)", "host": "storaged0:9779", "total": "45(us)"}`),
+					"total_rpc": []byte(`{"latency": "100(us)"}`),
+				})},
+			{Name: []byte("Start"), ID: 1, OutputVar: []byte("__Start_1"), Profiles: profile(0, 1, nil)},
+			{Name: []byte("Start"), ID: 0, OutputVar: []byte("__Start_0"), Profiles: profile(0, 1, nil)},
+		},
+		NodeIndexMap:     map[int64]int64{5: 0, 4: 1, 3: 2, 2: 3, 1: 4, 0: 5},
+		Format:           []byte("row"),
+		OptimizeTimeInUs: 12,
+	}
+}
+
+func TestResultSet_Plan(t *testing.T) {
+	resultSet, err := genResultSet(&graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED, PlanDesc: getLoopPlanDesc()}, testTimezone)
+	require.NoError(t, err)
+
+	plan, err := resultSet.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, "row", plan.Format)
+	assert.Equal(t, 12*time.Microsecond, plan.OptimizeTime)
+	assert.Len(t, plan.Nodes, 6)
+	assert.True(t, plan.IsProfiled())
+
+	root := plan.Root
+	assert.Equal(t, int64(5), root.ID)
+	assert.Equal(t, "__Project_5", root.OutputVar)
+	assert.Equal(t, map[string]string{"columns": `["$-.name AS name"]`}, root.Description)
+
+	loop := root.Dependencies[0]
+	assert.Equal(t, "Loop", loop.Name)
+	assert.Equal(t, plan.Node(3), loop.DoBranch)
+	assert.Nil(t, loop.ElseBranch)
+	assert.Equal(t, &PlanNodeBranch{IsDoBranch: true, ConditionNodeID: 4}, loop.DoBranch.Branch)
+	assert.Equal(t, []*PlanNode{plan.Node(0)}, loop.Dependencies)
+
+	filter := plan.Node(3)
+	assert.Equal(t, int64(7), filter.Rows())
+	assert.Equal(t, 80*time.Microsecond, filter.ExecDuration())
+	assert.Equal(t, 100*time.Microsecond, filter.TotalDuration())
+	assert.Equal(t, filter, plan.SlowestNode())
+
+	scan := plan.Node(2)
+	assert.Equal(t, []*PlanNode{scan}, plan.IndexScans())
+	assert.Empty(t, plan.FullScans())
+	assert.Equal(t, []PlanNodeHostStats{
+		{Host: "storaged0:9779", ExecDuration: 30 * time.Microsecond, TotalDuration: 45 * time.Microsecond},
+		{Host: "storaged1:9779", ExecDuration: 40 * time.Microsecond, TotalDuration: 55 * time.Microsecond},
+	}, scan.Profiles[0].HostStats())
+
+	assert.Len(t, plan.FindNodes("start"), 2)
+	assert.Nil(t, plan.Node(42))
+}
+
+func TestResultSet_Plan_Invalid(t *testing.T) {
+	resultSet, err := genResultSet(&graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED, PlanDesc: graph.NewPlanDescription()}, testTimezone)
+	require.NoError(t, err)
+	_, err = resultSet.Plan()
+	assert.EqualError(t, err, "result set has no execution plan")
+
+	planDesc := getLoopPlanDesc()
+	planDesc.PlanNodeDescs[0].Dependencies = []int64{42}
+	resultSet, err = genResultSet(&graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED, PlanDesc: planDesc}, testTimezone)
+	require.NoError(t, err)
+	_, err = resultSet.Plan()
+	assert.EqualError(t, err, "plan node 5 depends on unknown node 42")
+}
+
+func TestSession_Explain(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	stmt := "LOOKUP ON player YIELD id(vertex) AS id;"
+	graphClient.On("Execute", ctx, int64(1), []byte("EXPLAIN "+stmt)).Return(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		PlanDesc: &graph.PlanDescription{
+			PlanNodeDescs: []*graph.PlanNodeDescription{
+				{Name: []byte("Project"), ID: 2, OutputVar: []byte("__Project_2"), Dependencies: []int64{1}},
+				{Name: []byte("TagIndexFullScan"), ID: 1, OutputVar: []byte("__TagIndexFullScan_1"), Dependencies: []int64{0}},
+				{Name: []byte("Start"), ID: 0, OutputVar: []byte("__Start_0")},
+			},
+			NodeIndexMap: map[int64]int64{2: 0, 1: 1, 0: 2},
+			Format:       []byte("row"),
+		},
+	}, nil).Once()
+
+	plan, err := session.Explain(ctx, stmt)
+	require.NoError(t, err)
+	assert.False(t, plan.IsProfiled())
+	assert.Nil(t, plan.SlowestNode())
+	assert.Equal(t, []*PlanNode{plan.Node(1)}, plan.FullScans())
+	assert.Empty(t, plan.IndexScans())
+}
+
+func TestSession_Profile(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	stmt := "GO FROM 'player100' OVER follow YIELD dst(edge);"
+	graphClient.On("Execute", ctx, int64(1), []byte("PROFILE "+stmt)).Return(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		PlanDesc:  getLoopPlanDesc(),
+	}, nil).Once()
+	graphClient.On("Execute", ctx, int64(1), []byte("PROFILE YIEL 1;")).Return(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_E_SYNTAX_ERROR,
+	}, nil).Once()
+
+	plan, err := session.Profile(ctx, stmt)
+	require.NoError(t, err)
+	assert.Equal(t, "Filter", plan.SlowestNode().Name)
+
+	_, err = session.Profile(ctx, "YIEL 1;")
+	assert.IsType(t, &ExecutionError{}, err)
+}
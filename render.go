@@ -0,0 +1,245 @@
+package nebula_sirius
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// RenderStyle is the set of characters a table is drawn with.
+type RenderStyle struct {
+	Horizontal  string
+	Vertical    string
+	TopLeft     string
+	TopMid      string
+	TopRight    string
+	MidLeft     string
+	MidMid      string
+	MidRight    string
+	BottomLeft  string
+	BottomMid   string
+	BottomRight string
+	Ellipsis    string // appended to the truncated cells
+}
+
+var (
+	// RenderStyleASCII draws the tables like nebula-console.
+	RenderStyleASCII = RenderStyle{
+		Horizontal: "-", Vertical: "|",
+		TopLeft: "+", TopMid: "+", TopRight: "+",
+		MidLeft: "+", MidMid: "+", MidRight: "+",
+		BottomLeft: "+", BottomMid: "+", BottomRight: "+",
+		Ellipsis: "...",
+	}
+	// RenderStyleUnicode draws the tables with box-drawing characters.
+	RenderStyleUnicode = RenderStyle{
+		Horizontal: "─", Vertical: "│",
+		TopLeft: "┌", TopMid: "┬", TopRight: "┐",
+		MidLeft: "├", MidMid: "┼", MidRight: "┤",
+		BottomLeft: "└", BottomMid: "┴", BottomRight: "┘",
+		Ellipsis: "…",
+	}
+)
+
+// RenderOptions configures how Render draws a result set.
+type RenderOptions struct {
+	style        RenderStyle // optional
+	maxCellWidth int         // optional
+	footer       bool        // optional
+	plan         bool        // optional
+}
+
+// RenderOption is a functional option for configuring RenderOptions.
+type RenderOption func(*RenderOptions)
+
+// WithRenderStyle sets the characters the tables are drawn with, RenderStyleASCII by default.
+func WithRenderStyle(style RenderStyle) func(*RenderOptions) {
+	return func(opts *RenderOptions) {
+		opts.style = style
+	}
+}
+
+// WithMaxCellWidth sets the width in characters above which the lines of the cells are truncated,
+// 80 by default. Zero disables the truncation.
+func WithMaxCellWidth(width int) func(*RenderOptions) {
+	return func(opts *RenderOptions) {
+		opts.maxCellWidth = width
+	}
+}
+
+// WithRenderFooter sets whether the row count and the latency are written after the table, true by default.
+func WithRenderFooter(footer bool) func(*RenderOptions) {
+	return func(opts *RenderOptions) {
+		opts.footer = footer
+	}
+}
+
+// WithRenderPlan sets whether the execution plan of EXPLAIN and PROFILE is written, true by default.
+func WithRenderPlan(plan bool) func(*RenderOptions) {
+	return func(opts *RenderOptions) {
+		opts.plan = plan
+	}
+}
+
+// Render writes the result set as an aligned table in the format of nebula-console, with the values
+// formatted by ValueWrapper.String, followed by the row count and the latency of the graph service.
+// The execution plan is written after it when the result set holds one.
+func (res ResultSet) Render(w io.Writer, options ...RenderOption) error {
+	opts := RenderOptions{
+		style:        RenderStyleASCII,
+		maxCellWidth: 80,
+		footer:       true,
+		plan:         true,
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var builder strings.Builder
+	latency := time.Duration(res.GetLatency()) * time.Microsecond
+	switch {
+	case !res.IsSucceed():
+		fmt.Fprintf(&builder, "[ERROR (%d)]: %s\n", res.GetErrorCode(), res.GetErrorMsg())
+	case res.GetColSize() == 0:
+		if opts.footer {
+			fmt.Fprintf(&builder, "Execution succeeded (time spent %s)\n", latency)
+		}
+	case res.GetRowSize() == 0:
+		if opts.footer {
+			fmt.Fprintf(&builder, "Empty set (time spent %s)\n", latency)
+		}
+	default:
+		rows := make([][]string, 0, res.GetRowSize())
+		for _, row := range res.GetRows() {
+			cells := make([]string, 0, len(row.GetValues()))
+			for _, value := range row.GetValues() {
+				cells = append(cells, ValueWrapper{value, res.timezoneInfo}.String())
+			}
+			rows = append(rows, cells)
+		}
+		renderTable(&builder, res.GetColNames(), rows, opts, false)
+		if opts.footer {
+			fmt.Fprintf(&builder, "Got %d rows (time spent %s)\n", res.GetRowSize(), latency)
+		}
+	}
+
+	if opts.plan && res.IsSetPlanDesc() && len(res.GetPlanDesc().GetPlanNodeDescs()) > 0 {
+		if err := res.renderPlan(&builder, opts); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// renderPlan writes the execution plan in its format, as a table for row and tck, as a graph for dot and dot:struct.
+func (res ResultSet) renderPlan(builder *strings.Builder, opts RenderOptions) error {
+	planDesc := res.GetPlanDesc()
+	fmt.Fprintf(builder, "\nExecution Plan (optimize time %d us)\n\n", planDesc.GetOptimizeTimeInUs())
+
+	var (
+		planRows [][]interface{}
+		err      error
+	)
+	switch strings.ToLower(string(planDesc.GetFormat())) {
+	case "dot":
+		builder.WriteString(res.MakeDotGraph() + "\n")
+		return nil
+	case "dot:struct":
+		builder.WriteString(res.MakeDotGraphByStruct() + "\n")
+		return nil
+	case "tck":
+		planRows, err = res.MakePlanByTck()
+	default:
+		planRows, err = res.MakePlanByRow()
+	}
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(planRows))
+	for _, planRow := range planRows {
+		cells := make([]string, 0, len(planRow))
+		for _, cell := range planRow {
+			cells = append(cells, fmt.Sprint(cell))
+		}
+		rows = append(rows, cells)
+	}
+	renderTable(builder, []string{"id", "name", "dependencies", "profiling data", "operator info"}, rows, opts, true)
+	return nil
+}
+
+// renderTable writes the table, the cells spanning several lines when they hold line breaks.
+func renderTable(builder *strings.Builder, header []string, rows [][]string, opts RenderOptions, separateRows bool) {
+	style := opts.style
+	widths := make([]int, len(header))
+	split := func(cells []string) [][]string {
+		lines := make([][]string, len(cells))
+		for i, cell := range cells {
+			for _, line := range strings.Split(cell, "\n") {
+				line = truncateCell(line, opts.maxCellWidth, style.Ellipsis)
+				lines[i] = append(lines[i], line)
+				if i < len(widths) {
+					widths[i] = max(widths[i], utf8.RuneCountInString(line))
+				}
+			}
+		}
+		return lines
+	}
+
+	headerLines := split(header)
+	rowLines := make([][][]string, 0, len(rows))
+	for _, row := range rows {
+		rowLines = append(rowLines, split(row))
+	}
+
+	border := func(left, mid, right string) {
+		builder.WriteString(left)
+		for i, width := range widths {
+			if i > 0 {
+				builder.WriteString(mid)
+			}
+			builder.WriteString(strings.Repeat(style.Horizontal, width+2))
+		}
+		builder.WriteString(right + "\n")
+	}
+	line := func(cells [][]string) {
+		height := 0
+		for _, cell := range cells {
+			height = max(height, len(cell))
+		}
+		for l := 0; l < height; l++ {
+			builder.WriteString(style.Vertical)
+			for i, width := range widths {
+				text := ""
+				if i < len(cells) && l < len(cells[i]) {
+					text = cells[i][l]
+				}
+				builder.WriteString(" " + text + strings.Repeat(" ", width-utf8.RuneCountInString(text)) + " " + style.Vertical)
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	border(style.TopLeft, style.TopMid, style.TopRight)
+	line(headerLines)
+	border(style.MidLeft, style.MidMid, style.MidRight)
+	for i, cells := range rowLines {
+		if separateRows && i > 0 {
+			border(style.MidLeft, style.MidMid, style.MidRight)
+		}
+		line(cells)
+	}
+	border(style.BottomLeft, style.BottomMid, style.BottomRight)
+}
+
+func truncateCell(line string, maxWidth int, ellipsis string) string {
+	if maxWidth <= 0 || utf8.RuneCountInString(line) <= maxWidth {
+		return line
+	}
+	keep := max(maxWidth-utf8.RuneCountInString(ellipsis), 0)
+	return string([]rune(line)[:keep]) + ellipsis
+}
@@ -0,0 +1,109 @@
+package nebula_sirius
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getRenderResultSet(t *testing.T, planDesc *graph.PlanDescription) *ResultSet {
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode:   nebula.ErrorCode_SUCCEEDED,
+		LatencyInUs: 1234,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("name"), []byte("age"), []byte("v")},
+			Rows: []*nebula.Row{
+				{Values: []*nebula.Value{{SVal: []byte("Tim Duncan")}, setIVal(42), {VVal: getVertex("player100", 1, 1)}}},
+				{Values: []*nebula.Value{{SVal: []byte("Tony Parker")}, setIVal(36), {VVal: getVertex("player101", 1, 1)}}},
+			},
+		},
+		PlanDesc: planDesc,
+	}, testTimezone)
+	require.NoError(t, err)
+	return resultSet
+}
+
+func TestResultSet_Render_Golden(t *testing.T) {
+	planDesc := &graph.PlanDescription{
+		PlanNodeDescs: []*graph.PlanNodeDescription{
+			{
+				Name:         []byte("Project"),
+				ID:           1,
+				OutputVar:    []byte("__Project_1"),
+				Description:  []*graph.Pair{{Key: []byte("columns"), Value: []byte(`["$-.name AS name"]`)}},
+				Profiles:     []*graph.ProfilingStats{{Rows: 2, ExecDurationInUs: 10, TotalDurationInUs: 15}},
+				Dependencies: []int64{0},
+			},
+			{
+				Name:         []byte("Start"),
+				ID:           0,
+				OutputVar:    []byte("__Start_0"),
+				Profiles:     []*graph.ProfilingStats{{Rows: 0, ExecDurationInUs: 1, TotalDurationInUs: 2}},
+				Dependencies: []int64{},
+			},
+		},
+		NodeIndexMap:     map[int64]int64{1: 0, 0: 1},
+		Format:           []byte("row"),
+		OptimizeTimeInUs: 56,
+	}
+
+	testCases := []struct {
+		name      string
+		resultSet *ResultSet
+		options   []RenderOption
+	}{
+		{name: "ascii", resultSet: getRenderResultSet(t, nil)},
+		{name: "unicode", resultSet: getRenderResultSet(t, nil), options: []RenderOption{WithRenderStyle(RenderStyleUnicode)}},
+		{name: "truncate", resultSet: getRenderResultSet(t, nil), options: []RenderOption{WithMaxCellWidth(12)}},
+		{name: "plan", resultSet: getRenderResultSet(t, planDesc)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tc.resultSet.Render(&buf, tc.options...))
+			assertGolden(t, filepath.Join("testdata", "render", tc.name+".golden"), buf.Bytes())
+		})
+	}
+}
+
+func TestResultSet_Render(t *testing.T) {
+	errMsg := []byte("SyntaxError: syntax error near `YIEL'")
+	resultSet, err := genResultSet(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_E_SYNTAX_ERROR,
+		ErrorMsg:  errMsg,
+	}, testTimezone)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	assert.NoError(t, resultSet.Render(&buf))
+	assert.Equal(t, "[ERROR (-1004)]: SyntaxError: syntax error near `YIEL'\n", buf.String())
+
+	resultSet, err = genResultSet(&graph.ExecutionResponse{
+		ErrorCode:   nebula.ErrorCode_SUCCEEDED,
+		LatencyInUs: 1500,
+		Data:        &nebula.DataSet{ColumnNames: [][]byte{[]byte("name")}},
+		PlanDesc:    graph.NewPlanDescription(),
+	}, testTimezone)
+	require.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, resultSet.Render(&buf))
+	assert.Equal(t, "Empty set (time spent 1.5ms)\n", buf.String())
+
+	resultSet, err = genResultSet(&graph.ExecutionResponse{
+		ErrorCode:   nebula.ErrorCode_SUCCEEDED,
+		LatencyInUs: 800,
+	}, testTimezone)
+	require.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, resultSet.Render(&buf))
+	assert.Equal(t, "Execution succeeded (time spent 800µs)\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, getRenderResultSet(t, nil).Render(&buf, WithRenderFooter(false)))
+	assert.NotContains(t, buf.String(), "Got 2 rows")
+}
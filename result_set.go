@@ -97,6 +97,8 @@ const (
 	ErrorCode_E_PARTIAL_SUCCEEDED     ErrorCode = ErrorCode(nebula.ErrorCode_E_PARTIAL_SUCCEEDED)
 )
 
+// GenResultSet generates the result set of the response, with the times and datetimes in UTC.
+// Use GenResultSetWithTimezone for the timezone of the server, which the result sets of Session already have.
 func GenResultSet(resp *graph.ExecutionResponse) (*ResultSet, error) {
 	var defaultTimezone timezoneInfo = timezoneInfo{0, []byte("UTC")}
 	return genResultSet(resp, defaultTimezone)
@@ -363,6 +365,13 @@ func (res ResultSet) scanRow(row *nebula.Row, colNames []string, rowType reflect
 
 		rowVal := rowVals[cIdx]
 
+		if scanned, err := scanGeometryCol(rowVal, structVal.Field(fIdx)); scanned || err != nil {
+			if err != nil {
+				return result, err
+			}
+			continue
+		}
+
 		if f.Type.Kind() == reflect.Slice {
 			list := rowVal.GetLVal()
 			err := scanListCol(list.Values, structVal.Field(fIdx), f.Type)
@@ -463,6 +472,12 @@ func scanValFromProps(props map[string]*nebula.Value, val reflect.Value, tpe ref
 		if !ok {
 			continue
 		}
+		if scanned, err := scanGeometryCol(v, val.Field(fIdx)); scanned || err != nil {
+			if err != nil {
+				return err
+			}
+			continue
+		}
 		err := scanPrimitiveCol(v, val.Field(fIdx), f.Type.Kind())
 		if err != nil {
 			return err
@@ -472,6 +487,58 @@ func scanValFromProps(props map[string]*nebula.Value, val reflect.Value, tpe ref
 	return nil
 }
 
+var geometryInterface = reflect.TypeOf((*Geometry)(nil)).Elem()
+
+// scanGeometryCol scans a geography into a field of type Geometry, GeoPoint, GeoLineString or GeoPolygon,
+// or a pointer to one of them left nil for null values, and tells whether the field is of one of them.
+func scanGeometryCol(rowVal *nebula.Value, val reflect.Value) (bool, error) {
+	fieldType := val.Type()
+	if fieldType.Kind() == reflect.Ptr {
+		if !isGeometryType(fieldType.Elem()) {
+			return false, nil
+		}
+		w := ValueWrapper{value: rowVal}
+		if w.IsNull() || w.IsEmpty() {
+			val.Set(reflect.Zero(fieldType))
+			return true, nil
+		}
+		elem := reflect.New(fieldType.Elem())
+		if _, err := scanGeometryCol(rowVal, elem.Elem()); err != nil {
+			return true, err
+		}
+		val.Set(elem)
+		return true, nil
+	}
+	if !isGeometryType(fieldType) {
+		return false, nil
+	}
+
+	w := ValueWrapper{value: rowVal}
+	if w.IsNull() || w.IsEmpty() {
+		return true, nil
+	}
+	if !w.IsGeography() {
+		return true, fmt.Errorf("scan: value of type %s is not a geography", w.GetType())
+	}
+	geometry, err := GeometryFromGeography(rowVal.GetGgVal())
+	if err != nil {
+		return true, fmt.Errorf("scan: %w", err)
+	}
+
+	geometryVal := reflect.ValueOf(geometry)
+	if fieldType != geometryInterface && geometryVal.Type() != fieldType {
+		return true, fmt.Errorf("scan: geography %s cannot be scanned into %s", geometry.GeometryType(), fieldType)
+	}
+	val.Set(geometryVal)
+	return true, nil
+}
+
+// isGeometryType tells whether the type is Geometry or one of the types implementing it.
+// The pointers to them implement it as well, but are scanned through the type they point to.
+func isGeometryType(t reflect.Type) bool {
+	return t == geometryInterface || (t.Kind() != reflect.Ptr && t.Implements(geometryInterface))
+}
+
 func scanPrimitiveCol(rowVal *nebula.Value, val reflect.Value, kind reflect.Kind) error {
 	w := ValueWrapper{value: rowVal}
 	if w.IsNull() || w.IsEmpty() {
@@ -1394,50 +1461,71 @@ func findFirstStartNodeFrom(p *graph.PlanDescription, nodeId int64) int64 {
 	}
 }
 
-// explain/profile format="dot"
-func (res ResultSet) MakeDotGraph() string {
-	p := res.GetPlanDesc()
-	planNodeDescs := p.GetPlanNodeDescs()
-	var builder strings.Builder
-	builder.WriteString("digraph exec_plan {\n")
-	builder.WriteString("\trankdir=BT;\n")
-	for _, planNodeDesc := range planNodeDescs {
+// planGraphEmitter writes the nodes and the edges of a plan graph in the syntax of a graph language.
+type planGraphEmitter struct {
+	node            func(planNodeDesc *graph.PlanNodeDescription, planNodeName string) string
+	conditionalNode func(planNodeDesc *graph.PlanNodeDescription, planNodeName string) string
+	edge            func(start, end string) string
+	conditionalEdge func(start, end, label string) string
+}
+
+// writePlanGraph writes the nodes of the plan along with their dependencies, and the branches of
+// the select and loop nodes from their condition node to the start node they begin with.
+func writePlanGraph(builder *strings.Builder, p *graph.PlanDescription, emitter planGraphEmitter) {
+	for _, planNodeDesc := range p.GetPlanNodeDescs() {
 		planNodeName := name(planNodeDesc)
 		switch strings.ToLower(string(planNodeDesc.GetName())) {
 		case "select":
-			builder.WriteString(conditionalNodeString(planNodeName))
+			builder.WriteString(emitter.conditionalNode(planNodeDesc, planNodeName))
 			dep := nodeById(p, planNodeDesc.GetDependencies()[0])
 			// then branch
 			thenNodeId := findBranchEndNode(p, planNodeDesc.GetID(), true)
-			builder.WriteString(edgeString(name(nodeById(p, thenNodeId)), name(dep)))
+			builder.WriteString(emitter.edge(name(nodeById(p, thenNodeId)), name(dep)))
 			thenStartId := findFirstStartNodeFrom(p, thenNodeId)
-			builder.WriteString(conditionalEdgeString(name(planNodeDesc), name(nodeById(p, thenStartId)), "Y"))
+			builder.WriteString(emitter.conditionalEdge(planNodeName, name(nodeById(p, thenStartId)), "Y"))
 			// else branch
 			elseNodeId := findBranchEndNode(p, planNodeDesc.GetID(), false)
-			builder.WriteString(edgeString(name(nodeById(p, elseNodeId)), name(dep)))
+			builder.WriteString(emitter.edge(name(nodeById(p, elseNodeId)), name(dep)))
 			elseStartId := findFirstStartNodeFrom(p, elseNodeId)
-			builder.WriteString(conditionalEdgeString(name(planNodeDesc), name(nodeById(p, elseStartId)), "N"))
+			builder.WriteString(emitter.conditionalEdge(planNodeName, name(nodeById(p, elseStartId)), "N"))
 			// dep
-			builder.WriteString(edgeString(name(dep), planNodeName))
+			builder.WriteString(emitter.edge(name(dep), planNodeName))
 		case "loop":
-			builder.WriteString(conditionalNodeString(planNodeName))
+			builder.WriteString(emitter.conditionalNode(planNodeDesc, planNodeName))
 			dep := nodeById(p, planNodeDesc.GetDependencies()[0])
 			// do branch
 			doNodeId := findBranchEndNode(p, planNodeDesc.GetID(), true)
-			builder.WriteString(edgeString(name(nodeById(p, doNodeId)), name(planNodeDesc)))
+			builder.WriteString(emitter.edge(name(nodeById(p, doNodeId)), planNodeName))
 			doStartId := findFirstStartNodeFrom(p, doNodeId)
-			builder.WriteString(conditionalEdgeString(name(planNodeDesc), name(nodeById(p, doStartId)), "Do"))
+			builder.WriteString(emitter.conditionalEdge(planNodeName, name(nodeById(p, doStartId)), "Do"))
 			// dep
-			builder.WriteString(edgeString(name(dep), planNodeName))
+			builder.WriteString(emitter.edge(name(dep), planNodeName))
 		default:
-			builder.WriteString(nodeString(planNodeDesc, planNodeName))
+			builder.WriteString(emitter.node(planNodeDesc, planNodeName))
 			if planNodeDesc.IsSetDependencies() {
 				for _, depId := range planNodeDesc.GetDependencies() {
-					builder.WriteString(edgeString(name(nodeById(p, depId)), planNodeName))
+					builder.WriteString(emitter.edge(name(nodeById(p, depId)), planNodeName))
 				}
 			}
 		}
 	}
+}
+
+var dotPlanGraphEmitter = planGraphEmitter{
+	node: nodeString,
+	conditionalNode: func(planNodeDesc *graph.PlanNodeDescription, planNodeName string) string {
+		return conditionalNodeString(planNodeName)
+	},
+	edge:            edgeString,
+	conditionalEdge: conditionalEdgeString,
+}
+
+// explain/profile format="dot"
+func (res ResultSet) MakeDotGraph() string {
+	var builder strings.Builder
+	builder.WriteString("digraph exec_plan {\n")
+	builder.WriteString("\trankdir=BT;\n")
+	writePlanGraph(&builder, res.GetPlanDesc(), dotPlanGraphEmitter)
 	builder.WriteString("}")
 	return builder.String()
 }
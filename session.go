@@ -0,0 +1,118 @@
+package nebula_sirius
+
+import (
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+)
+
+// Session represents an authenticated session on the graph service.
+//
+// A session is bound to the graph client it was authenticated with, so the
+// client must not be returned to the pool while the session is in use.
+type Session struct {
+	graphClient  graph.GraphService
+	sessionID    int64
+	timezoneInfo timezoneInfo
+}
+
+// ExecutionError is returned when the graph service answers a statement with a
+// non-succeeded error code.
+type ExecutionError struct {
+	Stmt      string
+	ErrorCode ErrorCode
+	ErrorMsg  string
+}
+
+// Error returns the error message of the graph service along with the error code.
+func (e *ExecutionError) Error() string {
+	return fmt.Sprintf("failed to execute statement, error code: %s, error message: %s",
+		nebula.ErrorCode(e.ErrorCode), e.ErrorMsg)
+}
+
+// NewSession authenticates with the given username and password on the graph
+// service and returns the resulting session.
+//
+// The timezone reported by the server is kept in the session and used for the
+// result sets generated by the session.
+func NewSession(ctx context.Context, graphClient graph.GraphService, username, password string) (*Session, error) {
+	resp, err := graphClient.Authenticate(ctx, []byte(username), []byte(password))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.GetErrorCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to authenticate, error code: %s, error message: %s",
+			resp.GetErrorCode(), string(resp.GetErrorMsg()))
+	}
+
+	tzInfo := timezoneInfo{0, []byte("UTC")}
+	if resp.IsSetTimeZoneOffsetSeconds() {
+		tzInfo = timezoneInfo{resp.GetTimeZoneOffsetSeconds(), resp.GetTimeZoneName()}
+	}
+
+	return &Session{
+		graphClient:  graphClient,
+		sessionID:    resp.GetSessionID(),
+		timezoneInfo: tzInfo,
+	}, nil
+}
+
+// GetSessionID returns the ID of the session.
+func (s *Session) GetSessionID() int64 {
+	return s.sessionID
+}
+
+// GetGraphClient returns the graph client the session is bound to.
+func (s *Session) GetGraphClient() graph.GraphService {
+	return s.graphClient
+}
+
+// Execute executes the given statement in the session and returns its result set.
+//
+// If the graph service answers with a non-succeeded error code, the result set is
+// returned along with an *ExecutionError.
+func (s *Session) Execute(ctx context.Context, stmt string) (*ResultSet, error) {
+	return s.ExecuteWithParameter(ctx, stmt, nil)
+}
+
+// ExecuteWithParameter executes the given parameterized statement in the session
+// and returns its result set.
+//
+// If the graph service answers with a non-succeeded error code, the result set is
+// returned along with an *ExecutionError.
+func (s *Session) ExecuteWithParameter(ctx context.Context, stmt string, params map[string]*nebula.Value) (*ResultSet, error) {
+	var (
+		resp *graph.ExecutionResponse
+		err  error
+	)
+	if params == nil {
+		resp, err = s.graphClient.Execute(ctx, s.sessionID, []byte(stmt))
+	} else {
+		resp, err = s.graphClient.ExecuteWithParameter(ctx, s.sessionID, []byte(stmt), params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := genResultSet(resp, s.timezoneInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rs.IsSucceed() {
+		return rs, &ExecutionError{
+			Stmt:      stmt,
+			ErrorCode: rs.GetErrorCode(),
+			ErrorMsg:  rs.GetErrorMsg(),
+		}
+	}
+
+	return rs, nil
+}
+
+// Release signs out the session on the graph service.
+func (s *Session) Release(ctx context.Context) error {
+	return s.graphClient.Signout(ctx, s.sessionID)
+}
@@ -0,0 +1,110 @@
+package nebula_sirius
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSession(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+
+	sessionID := int64(42)
+	offset := int32(28800)
+	graphClient.On("Authenticate", ctx, []byte("root"), []byte("nebula")).Return(&graph.AuthResponse{
+		ErrorCode:             nebula.ErrorCode_SUCCEEDED,
+		SessionID:             &sessionID,
+		TimeZoneOffsetSeconds: &offset,
+		TimeZoneName:          []byte("Asia/Shanghai"),
+	}, nil)
+
+	session, err := NewSession(ctx, graphClient, "root", "nebula")
+	assert.NoError(t, err)
+	assert.Equal(t, sessionID, session.GetSessionID())
+	assert.Equal(t, graphClient, session.GetGraphClient())
+	assert.Equal(t, timezoneInfo{offset, []byte("Asia/Shanghai")}, session.timezoneInfo)
+}
+
+func TestNewSession_BadPassword(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+
+	graphClient.On("Authenticate", ctx, []byte("root"), []byte("wrong")).Return(&graph.AuthResponse{
+		ErrorCode: nebula.ErrorCode_E_BAD_USERNAME_PASSWORD,
+		ErrorMsg:  []byte("Invalid password"),
+	}, nil)
+
+	session, err := NewSession(ctx, graphClient, "root", "wrong")
+	assert.Error(t, err)
+	assert.Nil(t, session)
+}
+
+func TestSession_Execute(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1, timezoneInfo: timezoneInfo{28800, []byte("Asia/Shanghai")}}
+
+	graphClient.On("Execute", ctx, int64(1), []byte("YIELD 1 AS one;")).Return(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("one")},
+			Rows:        []*nebula.Row{{Values: []*nebula.Value{setIVal(1)}}},
+		},
+	}, nil)
+
+	rs, err := session.Execute(ctx, "YIELD 1 AS one;")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"one"}, rs.GetColNames())
+	assert.Equal(t, 1, rs.GetRowSize())
+	assert.Equal(t, session.timezoneInfo, rs.timezoneInfo)
+}
+
+func TestSession_ExecuteWithParameter(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	params := map[string]*nebula.Value{"p": setIVal(1)}
+	graphClient.On("ExecuteWithParameter", ctx, int64(1), []byte("YIELD $p;"), params).Return(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+	}, nil)
+
+	_, err := session.ExecuteWithParameter(ctx, "YIELD $p;", params)
+	assert.NoError(t, err)
+}
+
+func TestSession_Execute_ExecutionError(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	graphClient.On("Execute", ctx, int64(1), []byte("DESCRIBE TAG t;")).Return(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_E_EXECUTION_ERROR,
+		ErrorMsg:  []byte("TagNotFound: Tag not existed!"),
+	}, nil)
+
+	rs, err := session.Execute(ctx, "DESCRIBE TAG t;")
+	assert.NotNil(t, rs)
+
+	var execErr *ExecutionError
+	assert.True(t, errors.As(err, &execErr))
+	assert.Equal(t, ErrorCode_E_EXECUTION_ERROR, execErr.ErrorCode)
+	assert.Equal(t, "TagNotFound: Tag not existed!", execErr.ErrorMsg)
+	assert.Equal(t, "DESCRIBE TAG t;", execErr.Stmt)
+}
+
+func TestSession_Release(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 7}
+
+	graphClient.On("Signout", ctx, int64(7)).Return(nil)
+
+	assert.NoError(t, session.Release(ctx))
+}
@@ -0,0 +1,305 @@
+package nebula_sirius
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"slices"
+	"time"
+)
+
+// SessionInfo is a session of the graph service as listed by SHOW SESSIONS.
+type SessionInfo struct {
+	ID           int64
+	User         string
+	Space        string // empty until a space is used
+	GraphAddress HostAddress
+	ClientIP     string
+	Timezone     int32 // offset from UTC in seconds
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Queries      []RunningQuery // sorted by start time
+}
+
+// RunningQuery is a query of a session as listed by SHOW QUERIES.
+type RunningQuery struct {
+	SessionID    int64
+	PlanID       int64
+	User         string // user of the session
+	Space        string // space of the session
+	Query        string
+	Status       meta.QueryStatus
+	StartTime    time.Time
+	Duration     time.Duration // as last reported by the graph service to the meta service
+	GraphAddress HostAddress
+}
+
+// Elapsed returns how long the query has been running at the given time. The reported duration
+// lags behind, as the graph services only report it to the meta service with their heartbeats.
+func (q *RunningQuery) Elapsed(now time.Time) time.Duration {
+	if q.StartTime.IsZero() {
+		return q.Duration
+	}
+	return max(q.Duration, now.Sub(q.StartTime))
+}
+
+// Sessions administrates the sessions of the graph services and their queries through the meta service.
+type Sessions struct {
+	metaClient meta.MetaService // required
+	now        func() time.Time // optional
+}
+
+// NewSessions creates a new Sessions sending its requests to the given meta client.
+func NewSessions(metaClient meta.MetaService) *Sessions {
+	return &Sessions{
+		metaClient: metaClient,
+		now:        time.Now,
+	}
+}
+
+// List returns the active sessions along with their queries, sorted by ID.
+func (s *Sessions) List(ctx context.Context) ([]SessionInfo, error) {
+	resp, err := s.metaClient.ListSessions(ctx, &meta.ListSessionsReq{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to list sessions, error code: %s", resp.GetCode())
+	}
+
+	sessions := make([]SessionInfo, 0, len(resp.GetSessions()))
+	for _, session := range resp.GetSessions() {
+		sessions = append(sessions, sessionInfo(session))
+	}
+	slices.SortFunc(sessions, func(a, b SessionInfo) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return sessions, nil
+}
+
+// Get returns the session along with its queries.
+func (s *Sessions) Get(ctx context.Context, sessionID int64) (*SessionInfo, error) {
+	resp, err := s.metaClient.GetSession(ctx, &meta.GetSessionReq{SessionID: nebula.SessionID(sessionID)})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() == nebula.ErrorCode_E_SESSION_NOT_FOUND {
+		return nil, fmt.Errorf("session %d not found", sessionID)
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to get session %d, error code: %s", sessionID, resp.GetCode())
+	}
+
+	session := sessionInfo(resp.GetSession())
+	return &session, nil
+}
+
+// ListQueries returns the running queries of all the sessions, the longest running first.
+func (s *Sessions) ListQueries(ctx context.Context) ([]RunningQuery, error) {
+	sessions, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	queries := make([]RunningQuery, 0)
+	for _, session := range sessions {
+		queries = append(queries, session.Queries...)
+	}
+	slices.SortStableFunc(queries, func(a, b RunningQuery) int {
+		return cmp.Compare(b.Elapsed(now), a.Elapsed(now))
+	})
+	return queries, nil
+}
+
+// KillQuery kills the query with the given plan ID of the session, the equivalent of KILL QUERY.
+func (s *Sessions) KillQuery(ctx context.Context, sessionID int64, planID int64) error {
+	resp, err := s.metaClient.KillQuery(ctx, &meta.KillQueryReq{
+		KillQueries: map[nebula.SessionID][]nebula.ExecutionPlanID{
+			nebula.SessionID(sessionID): {nebula.ExecutionPlanID(planID)},
+		},
+	})
+	return checkExecResp(resp, err, fmt.Sprintf("kill query %d of session %d", planID, sessionID))
+}
+
+// KillSessions removes the sessions along with their queries, the equivalent of KILL SESSION.
+// It returns the IDs of the removed sessions.
+func (s *Sessions) KillSessions(ctx context.Context, sessionIDs ...int64) ([]int64, error) {
+	if len(sessionIDs) == 0 {
+		return nil, fmt.Errorf("at least one session ID is required")
+	}
+
+	ids := make([]nebula.SessionID, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		ids = append(ids, nebula.SessionID(sessionID))
+	}
+
+	resp, err := s.metaClient.RemoveSession(ctx, &meta.RemoveSessionReq{SessionIds: ids})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to kill sessions %v, error code: %s", sessionIDs, resp.GetCode())
+	}
+
+	removed := make([]int64, 0, len(resp.GetRemovedSessionIds()))
+	for _, sessionID := range resp.GetRemovedSessionIds() {
+		removed = append(removed, int64(sessionID))
+	}
+	return removed, nil
+}
+
+// KillSlowQueries kills the running queries which have been running for longer than maxDuration,
+// and returns them. It tries to kill all of them, and returns the first error met.
+func (s *Sessions) KillSlowQueries(ctx context.Context, maxDuration time.Duration) ([]RunningQuery, error) {
+	return s.killSlowQueries(ctx, maxDuration, WatchdogOptions{})
+}
+
+// WatchdogOptions configures how a watchdog looks for slow queries.
+type WatchdogOptions struct {
+	interval time.Duration                 // optional
+	onKill   func(query RunningQuery)      // optional
+	onError  func(err error)               // optional
+	filter   func(query RunningQuery) bool // optional
+}
+
+// WatchdogOption is a functional option for configuring WatchdogOptions.
+type WatchdogOption func(*WatchdogOptions)
+
+// WithWatchdogInterval sets how often the watchdog looks for slow queries, 10s by default.
+func WithWatchdogInterval(interval time.Duration) func(*WatchdogOptions) {
+	return func(opts *WatchdogOptions) {
+		opts.interval = interval
+	}
+}
+
+// WithWatchdogOnKill sets a function called with each query the watchdog kills, e.g. to log it.
+func WithWatchdogOnKill(onKill func(query RunningQuery)) func(*WatchdogOptions) {
+	return func(opts *WatchdogOptions) {
+		opts.onKill = onKill
+	}
+}
+
+// WithWatchdogOnError sets a function called with the errors the watchdog meets, which are ignored by default.
+func WithWatchdogOnError(onError func(err error)) func(*WatchdogOptions) {
+	return func(opts *WatchdogOptions) {
+		opts.onError = onError
+	}
+}
+
+// WithWatchdogFilter restricts the watchdog to the queries the filter returns true for,
+// e.g. to spare the queries of an admin user.
+func WithWatchdogFilter(filter func(query RunningQuery) bool) func(*WatchdogOptions) {
+	return func(opts *WatchdogOptions) {
+		opts.filter = filter
+	}
+}
+
+// Watchdog kills the queries running for longer than maxDuration, every interval, until ctx is done.
+// It returns the error of ctx, or an error right away if the interval is not positive.
+func (s *Sessions) Watchdog(ctx context.Context, maxDuration time.Duration, options ...WatchdogOption) error {
+	opts := WatchdogOptions{
+		interval: 10 * time.Second,
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.interval <= 0 {
+		return fmt.Errorf("invalid watchdog interval %s, it must be positive", opts.interval)
+	}
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+	for {
+		// The errors are reported to onError
+		_, _ = s.killSlowQueries(ctx, maxDuration, opts)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Sessions) killSlowQueries(ctx context.Context, maxDuration time.Duration, opts WatchdogOptions) ([]RunningQuery, error) {
+	var firstErr error
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+		if opts.onError != nil {
+			opts.onError(err)
+		}
+	}
+
+	queries, err := s.ListQueries(ctx)
+	if err != nil {
+		fail(err)
+		return nil, firstErr
+	}
+
+	now := s.now()
+	killed := make([]RunningQuery, 0)
+	for _, query := range queries {
+		if query.Status != meta.QueryStatus_RUNNING || query.Elapsed(now) <= maxDuration {
+			continue
+		}
+		if opts.filter != nil && !opts.filter(query) {
+			continue
+		}
+		if err := s.KillQuery(ctx, query.SessionID, query.PlanID); err != nil {
+			fail(err)
+			continue
+		}
+		killed = append(killed, query)
+		if opts.onKill != nil {
+			opts.onKill(query)
+		}
+	}
+	return killed, firstErr
+}
+
+func sessionInfo(session *meta.Session) SessionInfo {
+	info := SessionInfo{
+		ID:           int64(session.GetSessionID()),
+		User:         string(session.GetUserName()),
+		Space:        string(session.GetSpaceName()),
+		GraphAddress: hostAddress(session.GetGraphAddr()),
+		ClientIP:     string(session.GetClientIP()),
+		Timezone:     session.GetTimezone(),
+		CreatedAt:    sessionTime(session.GetCreateTime()),
+		UpdatedAt:    sessionTime(session.GetUpdateTime()),
+		Queries:      make([]RunningQuery, 0, len(session.GetQueries())),
+	}
+	for planID, desc := range session.GetQueries() {
+		info.Queries = append(info.Queries, RunningQuery{
+			SessionID:    info.ID,
+			PlanID:       int64(planID),
+			User:         info.User,
+			Space:        info.Space,
+			Query:        string(desc.GetQuery()),
+			Status:       desc.GetStatus(),
+			StartTime:    sessionTime(desc.GetStartTime()),
+			Duration:     time.Duration(desc.GetDuration()) * time.Microsecond,
+			GraphAddress: hostAddress(desc.GetGraphAddr()),
+		})
+	}
+	slices.SortFunc(info.Queries, func(a, b RunningQuery) int {
+		if c := a.StartTime.Compare(b.StartTime); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PlanID, b.PlanID)
+	})
+	return info
+}
+
+// sessionTime converts the microseconds since epoch of the sessions into a time, zero when not set.
+func sessionTime(microseconds nebula.Timestamp) time.Time {
+	if microseconds <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(microseconds))
+}
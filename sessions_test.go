@@ -0,0 +1,198 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+var sessionsNow = time.Date(2025, 2, 15, 14, 30, 0, 0, time.UTC)
+
+func listSessionsResp() *meta.ListSessionsResp {
+	graphAddr := &nebula.HostAddr{Host: "graphd0", Port: 9669}
+	return &meta.ListSessionsResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Sessions: []*meta.Session{
+			{
+				SessionID:  2,
+				CreateTime: nebula.Timestamp(sessionsNow.Add(-time.Hour).UnixMicro()),
+				UpdateTime: nebula.Timestamp(sessionsNow.UnixMicro()),
+				UserName:   []byte("tenant_1"),
+				SpaceName:  []byte("basketballplayer"),
+				GraphAddr:  graphAddr,
+				ClientIP:   []byte("10.0.0.1"),
+				Queries: map[nebula.ExecutionPlanID]*meta.QueryDesc{
+					21: {
+						StartTime: nebula.Timestamp(sessionsNow.Add(-10 * time.Minute).UnixMicro()),
+						Status:    meta.QueryStatus_RUNNING,
+						Duration:  int64(9 * time.Minute / time.Microsecond),
+						Query:     []byte("MATCH (v) RETURN v"),
+						GraphAddr: graphAddr,
+					},
+					22: {
+						StartTime: nebula.Timestamp(sessionsNow.Add(-time.Second).UnixMicro()),
+						Status:    meta.QueryStatus_RUNNING,
+						Query:     []byte("SHOW TAGS"),
+						GraphAddr: graphAddr,
+					},
+				},
+			},
+			{
+				SessionID:  1,
+				CreateTime: nebula.Timestamp(sessionsNow.Add(-2 * time.Hour).UnixMicro()),
+				UserName:   []byte("root"),
+				GraphAddr:  graphAddr,
+				Queries: map[nebula.ExecutionPlanID]*meta.QueryDesc{
+					11: {
+						StartTime: nebula.Timestamp(sessionsNow.Add(-20 * time.Minute).UnixMicro()),
+						Status:    meta.QueryStatus_KILLING,
+						Query:     []byte("GO 10 STEPS FROM 1 OVER *"),
+						GraphAddr: graphAddr,
+					},
+					12: {
+						StartTime: nebula.Timestamp(sessionsNow.Add(-5 * time.Minute).UnixMicro()),
+						Status:    meta.QueryStatus_RUNNING,
+						Query:     []byte("GO 5 STEPS FROM 1 OVER *"),
+						GraphAddr: graphAddr,
+					},
+				},
+			},
+		},
+	}
+}
+
+func newTestSessions(metaClient meta.MetaService) *Sessions {
+	sessions := NewSessions(metaClient)
+	sessions.now = func() time.Time { return sessionsNow }
+	return sessions
+}
+
+func killQueryReq(sessionID int64, planID int64) *meta.KillQueryReq {
+	return &meta.KillQueryReq{KillQueries: map[nebula.SessionID][]nebula.ExecutionPlanID{
+		nebula.SessionID(sessionID): {nebula.ExecutionPlanID(planID)},
+	}}
+}
+
+func TestSessions_List(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListSessions", ctx, &meta.ListSessionsReq{}).Return(listSessionsResp(), nil).Once()
+
+	sessions, err := newTestSessions(metaClient).List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, int64(1), sessions[0].ID)
+	assert.Equal(t, "root", sessions[0].User)
+
+	session := sessions[1]
+	assert.Equal(t, int64(2), session.ID)
+	assert.Equal(t, "tenant_1", session.User)
+	assert.Equal(t, "basketballplayer", session.Space)
+	assert.Equal(t, HostAddress{Host: "graphd0", Port: 9669}, session.GraphAddress)
+	assert.Equal(t, "10.0.0.1", session.ClientIP)
+	assert.True(t, session.CreatedAt.Equal(sessionsNow.Add(-time.Hour)))
+	assert.Equal(t, RunningQuery{
+		SessionID:    2,
+		PlanID:       21,
+		User:         "tenant_1",
+		Space:        "basketballplayer",
+		Query:        "MATCH (v) RETURN v",
+		Status:       meta.QueryStatus_RUNNING,
+		StartTime:    time.UnixMicro(sessionsNow.Add(-10 * time.Minute).UnixMicro()),
+		Duration:     9 * time.Minute,
+		GraphAddress: HostAddress{Host: "graphd0", Port: 9669},
+	}, session.Queries[0])
+	assert.Equal(t, 10*time.Minute, session.Queries[0].Elapsed(sessionsNow))
+}
+
+func TestSessions_Get_NotFound(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("GetSession", ctx, &meta.GetSessionReq{SessionID: 3}).Return(&meta.GetSessionResp{
+		Code: nebula.ErrorCode_E_SESSION_NOT_FOUND,
+	}, nil).Once()
+
+	_, err := newTestSessions(metaClient).Get(ctx, 3)
+	assert.EqualError(t, err, "session 3 not found")
+}
+
+func TestSessions_ListQueries(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListSessions", ctx, &meta.ListSessionsReq{}).Return(listSessionsResp(), nil).Once()
+
+	queries, err := newTestSessions(metaClient).ListQueries(ctx)
+	assert.NoError(t, err)
+	planIDs := make([]int64, 0, len(queries))
+	for _, query := range queries {
+		planIDs = append(planIDs, query.PlanID)
+	}
+	assert.Equal(t, []int64{11, 21, 12, 22}, planIDs)
+}
+
+func TestSessions_KillSessions(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("RemoveSession", ctx, &meta.RemoveSessionReq{SessionIds: []nebula.SessionID{1, 2}}).Return(&meta.RemoveSessionResp{
+		Code:              nebula.ErrorCode_SUCCEEDED,
+		RemovedSessionIds: []nebula.SessionID{1},
+	}, nil).Once()
+
+	removed, err := newTestSessions(metaClient).KillSessions(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, removed)
+}
+
+func TestSessions_KillSlowQueries(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListSessions", ctx, &meta.ListSessionsReq{}).Return(listSessionsResp(), nil).Once()
+	metaClient.On("KillQuery", ctx, killQueryReq(2, 21)).Return(succeededExecResp(), nil).Once()
+	metaClient.On("KillQuery", ctx, killQueryReq(1, 12)).Return(&meta.ExecResp{
+		Code: nebula.ErrorCode_E_QUERY_NOT_FOUND,
+	}, nil).Once()
+
+	killed, err := newTestSessions(metaClient).KillSlowQueries(ctx, time.Minute)
+	assert.ErrorContains(t, err, "kill query 12 of session 1")
+	assert.Len(t, killed, 1)
+	assert.Equal(t, int64(21), killed[0].PlanID)
+}
+
+func TestSessions_Watchdog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	metaClient := mocks.NewMetaService(t)
+
+	metaClient.On("ListSessions", ctx, &meta.ListSessionsReq{}).Return(listSessionsResp(), nil).Once()
+	metaClient.On("KillQuery", ctx, killQueryReq(2, 21)).Return(succeededExecResp(), nil).Once()
+
+	var killed []RunningQuery
+	err := newTestSessions(metaClient).Watchdog(ctx, time.Minute,
+		WithWatchdogInterval(time.Hour),
+		WithWatchdogFilter(func(query RunningQuery) bool { return query.User != "root" }),
+		WithWatchdogOnKill(func(query RunningQuery) {
+			killed = append(killed, query)
+			cancel()
+		}),
+	)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, killed, 1)
+	assert.Equal(t, int64(21), killed[0].PlanID)
+}
+
+func TestSessions_Watchdog_InvalidInterval(t *testing.T) {
+	metaClient := mocks.NewMetaService(t)
+
+	err := newTestSessions(metaClient).Watchdog(context.Background(), time.Minute, WithWatchdogInterval(0))
+	assert.EqualError(t, err, "invalid watchdog interval 0s, it must be positive")
+}
@@ -72,25 +72,26 @@ func WithTagComment(comment string) func(*AlterEdgeStatement) {
 
 // GenerateAlterEdgeStatement generates the ALTER EDGE statement based on the provided AlterEdgeStatement.
 // It checks for required fields and constructs the statement string.
+// The alteration definitions may be omitted when only TTL definitions or a comment are altered.
 // If any required fields are missing, it returns an error.
 func GenerateAlterEdgeStatement(input AlterEdgeStatement) (string, error) {
 	if input.edgeName == "" {
 		return "", fmt.Errorf("edge name is required")
 	}
 
-	if len(input.alterDef) == 0 {
-		return "", fmt.Errorf("at least one alter definition is required")
+	if len(input.alterDef) == 0 && len(input.ttlDef) == 0 && input.comment == "" {
+		return "", fmt.Errorf("at least one alter definition, TTL definition or comment is required")
 	}
 
 	var sb strings.Builder
 	sb.WriteString("ALTER EDGE ")
 	sb.WriteString(input.edgeName)
-	sb.WriteString(" ")
 
 	for i, alterDef := range input.alterDef {
 		if i > 0 {
-			sb.WriteString(", ")
+			sb.WriteString(",")
 		}
+		sb.WriteString(" ")
 		stmt, err := alterDef.GenerateStatement()
 		if err != nil {
 			return "", err
@@ -9,8 +9,10 @@ import (
 // AlterTypeAddDefinition represents an ALTER TAG statement for adding a new property.
 // It contains the property name, type, and optional attributes such as
 type AlterTypeAddDefinition struct {
-	PropName string                 // required
-	Type     statement.PropertyType // required
+	PropName    string                 // required
+	Type        statement.PropertyType // required
+	NotNullable bool                   // optional
+	Comment     string                 // optional
 }
 
 // AlterAddDefinitionOption is a functional option for configuring an AlterTypeAddDefinition.
@@ -41,6 +43,20 @@ func NewAlterTypeAddDefinition(propName string, propType statement.PropertyType,
 	return statement
 }
 
+// WithAlterTypeAddNotNullable sets the NotNullable attribute of the AlterTypeAddDefinition to the provided value.
+func WithAlterTypeAddNotNullable(notNullable bool) func(*AlterTypeAddDefinition) {
+	return func(stmt *AlterTypeAddDefinition) {
+		stmt.NotNullable = notNullable
+	}
+}
+
+// WithAlterTypeAddComment sets the Comment attribute of the AlterTypeAddDefinition to the provided value.
+func WithAlterTypeAddComment(comment string) func(*AlterTypeAddDefinition) {
+	return func(stmt *AlterTypeAddDefinition) {
+		stmt.Comment = comment
+	}
+}
+
 // GetAlterType returns the type of alteration being performed.
 func (def AlterTypeAddDefinition) GetAlterType() AlterType {
 	return AlterTypeAdd
@@ -61,6 +77,14 @@ func (def AlterTypeAddDefinition) GenerateStatement() (string, error) {
 	sb.WriteString(def.PropName)
 	sb.WriteString(" ")
 	sb.WriteString(string(def.Type))
+	if def.NotNullable {
+		sb.WriteString(" NOT NULL")
+	}
+	if def.Comment != "" {
+		sb.WriteString(" COMMENT '")
+		sb.WriteString(def.Comment)
+		sb.WriteString("'")
+	}
 	sb.WriteString(")")
 	return sb.String(), nil
 }
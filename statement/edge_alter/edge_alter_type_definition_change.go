@@ -8,8 +8,10 @@ import (
 
 // AlterTypeChangeDefinition represents an ALTER TAG statement for changing a property type.
 type AlterTypeChangeDefinition struct {
-	propName string                 // required
-	propType statement.PropertyType // required
+	propName    string                 // required
+	propType    statement.PropertyType // required
+	notNullable bool                   // optional
+	comment     string                 // optional
 }
 
 // AlterChangeDefinitionOption is a functional option for configuring an AlterTypeChangeDefinition.
@@ -40,6 +42,20 @@ func NewAlterTypeChangeDefinition(propName string, propType statement.PropertyTy
 	return statement
 }
 
+// WithAlterTypeChangeNotNullable sets the NotNullable attribute of the AlterTypeChangeDefinition to the provided value.
+func WithAlterTypeChangeNotNullable(notNullable bool) func(*AlterTypeChangeDefinition) {
+	return func(stmt *AlterTypeChangeDefinition) {
+		stmt.notNullable = notNullable
+	}
+}
+
+// WithAlterTypeChangeComment sets the Comment attribute of the AlterTypeChangeDefinition to the provided value.
+func WithAlterTypeChangeComment(comment string) func(*AlterTypeChangeDefinition) {
+	return func(stmt *AlterTypeChangeDefinition) {
+		stmt.comment = comment
+	}
+}
+
 // GetAlterType returns the type of alteration being performed.
 func (def AlterTypeChangeDefinition) GetAlterType() AlterType {
 	return AlterTypeChange
@@ -57,6 +73,14 @@ func (def AlterTypeChangeDefinition) GenerateStatement() (string, error) {
 	sb.WriteString(def.propName)
 	sb.WriteString(" ")
 	sb.WriteString(string(def.propType))
+	if def.notNullable {
+		sb.WriteString(" NOT NULL")
+	}
+	if def.comment != "" {
+		sb.WriteString(" COMMENT '")
+		sb.WriteString(def.comment)
+		sb.WriteString("'")
+	}
 	sb.WriteString(")")
 
 	return sb.String(), nil
@@ -14,6 +14,7 @@ type CreateEdgeStatement struct {
 	ttlDuration uint           // optional
 	ttlCol      string         // optional
 	ifNotExists bool           // optional
+	comment     string         // optional
 }
 
 // EdgeProperty represents a property in a edge.
@@ -22,14 +23,32 @@ type EdgeProperty struct {
 	field    string
 	ttype    statement.PropertyType
 	nullable bool
+	comment  string
 }
 
-func NewEdgeProperty(field string, propertyType statement.PropertyType, nullable bool) EdgeProperty {
-	return EdgeProperty{
+func NewEdgeProperty(field string, propertyType statement.PropertyType, nullable bool, options ...EdgePropertyOption) EdgeProperty {
+	property := EdgeProperty{
 		field:    field,
 		ttype:    propertyType,
 		nullable: nullable,
 	}
+
+	// Apply all the functional options to configure the property.
+	for _, opt := range options {
+		opt(&property)
+	}
+
+	return property
+}
+
+// EdgePropertyOption is a functional option for configuring a EdgeProperty.
+type EdgePropertyOption func(*EdgeProperty)
+
+// WithPropertyComment sets the comment of the EdgeProperty to the provided value.
+func WithPropertyComment(comment string) func(*EdgeProperty) {
+	return func(prop *EdgeProperty) {
+		prop.comment = comment
+	}
 }
 
 // CreateEdgeStatementOption is a functional option for configuring a CreateEdgeStatement.
@@ -79,6 +98,61 @@ func WithProperties(properties []EdgeProperty) func(*CreateEdgeStatement) {
 	}
 }
 
+// WithComment sets the comment of the CreateEdgeStatement to the provided value.
+func WithComment(comment string) func(*CreateEdgeStatement) {
+	return func(stmt *CreateEdgeStatement) {
+		stmt.comment = comment
+	}
+}
+
+// GetName returns the name of the edge.
+func (s CreateEdgeStatement) GetName() string {
+	return s.name
+}
+
+// GetProperties returns the properties of the edge.
+func (s CreateEdgeStatement) GetProperties() []EdgeProperty {
+	return s.properties
+}
+
+// GetTtlDuration returns the TTL duration of the edge.
+func (s CreateEdgeStatement) GetTtlDuration() uint {
+	return s.ttlDuration
+}
+
+// GetTtlCol returns the TTL column of the edge.
+func (s CreateEdgeStatement) GetTtlCol() string {
+	return s.ttlCol
+}
+
+// GetComment returns the comment of the edge.
+func (s CreateEdgeStatement) GetComment() string {
+	return s.comment
+}
+
+// GetField returns the name of the property.
+func (p EdgeProperty) GetField() string {
+	return p.field
+}
+
+// GetType returns the type of the property, string when it is not set.
+func (p EdgeProperty) GetType() statement.PropertyType {
+	if p.ttype == "" {
+		return statement.PropertyTypeString
+	}
+	return p.ttype
+}
+
+// IsNullable returns whether the property is nullable.
+func (p EdgeProperty) IsNullable() bool {
+	return p.nullable
+}
+
+// GetComment returns the comment of the property.
+func (p EdgeProperty) GetComment() string {
+	return p.comment
+}
+
 // GenerateCreateEdgeStatement generates a string representation of the CreateEdgeStatement.
 // The function checks if the TTL column exists in the properties and returns an error if it doesn't.
 // Otherwise, it returns a string representation of the CreateEdgeStatement.
@@ -117,15 +191,22 @@ func GenerateCreateEdgeStatement(edge CreateEdgeStatement) (string, error) {
 		sb.WriteString(t)
 		sb.WriteString(" ")
 		sb.WriteString(n)
+		if field.comment != "" {
+			sb.WriteString(fmt.Sprintf(` COMMENT '%s'`, field.comment))
+		}
 	}
 
+	sb.WriteString(")")
 	if edge.ttlCol != "" {
-		sb.WriteString(") ")
-		sb.WriteString(fmt.Sprintf(`TTL_DURATION = %d, TTL_COL = "%s"`, edge.ttlDuration, edge.ttlCol))
-		sb.WriteString(";")
-	} else {
-		sb.WriteString(");")
+		sb.WriteString(fmt.Sprintf(` TTL_DURATION = %d, TTL_COL = "%s"`, edge.ttlDuration, edge.ttlCol))
 	}
+	if edge.comment != "" {
+		if edge.ttlCol != "" {
+			sb.WriteString(",")
+		}
+		sb.WriteString(fmt.Sprintf(` COMMENT = '%s'`, edge.comment))
+	}
+	sb.WriteString(";")
 
 	return sb.String(), nil
 }
@@ -144,3 +225,37 @@ func isTTLColValid(ttlCol string, properties []EdgeProperty) error {
 
 	return fmt.Errorf("TTL column %s does not exist in the fields", ttlCol)
 }
+
+// SchemaFromStruct reflects the given struct, or pointer to struct, into a CreateEdgeStatement.
+// The edge name is taken from the GetEdgeTypeName() method when the struct implements it,
+// otherwise the name of the struct type is used.
+//
+// The properties, TTL and comments are read from the struct tags as described in
+// statement.ReflectStructSchema. Additional options are applied after the reflected ones,
+// so they can override them, e.g. WithIfNotExists(true).
+func SchemaFromStruct(v any, options ...CreateEdgeStatementOption) (CreateEdgeStatement, error) {
+	schema, err := statement.ReflectStructSchema(v)
+	if err != nil {
+		return CreateEdgeStatement{}, err
+	}
+
+	name := schema.Name
+	if named, ok := v.(interface{ GetEdgeTypeName() string }); ok {
+		name = named.GetEdgeTypeName()
+	}
+
+	properties := make([]EdgeProperty, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		properties = append(properties, NewEdgeProperty(field.Name, field.Type, field.Nullable, WithPropertyComment(field.Comment)))
+	}
+
+	reflectedOptions := []CreateEdgeStatementOption{
+		WithProperties(properties),
+		WithComment(schema.Comment),
+	}
+	if schema.TTLCol != "" {
+		reflectedOptions = append(reflectedOptions, WithTtlCol(schema.TTLCol), WithTtlDuration(schema.TTLDuration))
+	}
+
+	return NewCreateEdgeStatement(name, append(reflectedOptions, options...)...), nil
+}
@@ -0,0 +1,248 @@
+package edge_struct_insert
+
+import (
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// IInsertableEdge is an interface that must be implemented by all struct intended to store edge information
+// that are used to generate INSERT EDGE scripts
+type IInsertableEdge interface {
+	GetEdgeTypeName() string
+	InsertIfNotExists() bool
+}
+
+// nebulaInfoPerStruct is a struct that stores the Nebula fields and the corresponding struct fields
+type nebulaInfoPerStruct struct {
+	NebulaFieldAndStructFieldMap map[string]reflect.StructField
+	NebulaFields                 []string
+	SrcStructField               reflect.StructField
+	DstStructField               reflect.StructField
+	RankStructField              *reflect.StructField
+}
+
+var cachedNebulaInfoPerStruct sync.Map
+
+// GenerateInsertEdgeStatement takes a slice of struct edges and generates the corresponding
+// INSERT EDGE scripts
+//
+// The struct must have fields tagged with the "nebula_src" and "nebula_dst" tags, which are used
+// as the source and destination vertex IDs. A field tagged with the "nebula_rank" tag is optional
+// and is used as the edge rank, when it is missing or nil the rank is 0.
+//
+// The struct can also have fields tagged with the "nebula_field" and "nebula_field_type" tags,
+// which are handled the same way as for vertices, see vertex_insert.GenerateInsertVertexStatement.
+//
+// Consecutive edges sharing the same edge type, the same set of non-nil fields and the same
+// IF NOT EXISTS option are combined into a single multi-value statement, e.g.
+// INSERT EDGE follow (f1, f2) VALUES "a"->"b"@0:(...), "b"->"c"@0:(...).
+// The function returns a string containing the INSERT EDGE scripts separated by semicolons.
+// If an error occurs, the function returns an empty string and the error.
+func GenerateInsertEdgeStatement(edges []IInsertableEdge) (string, error) {
+	if len(edges) == 0 {
+		return "", fmt.Errorf("no edges provided")
+	}
+
+	var sb strings.Builder
+
+	prevHeader := ""
+	for _, edge := range edges {
+		header, value, err := encodeEdge(edge)
+		if err != nil {
+			return "", err
+		}
+
+		// Same edge type and same column set as the previous edge, append it to the running VALUES list
+		if header == prevHeader {
+			sb.WriteString(", ")
+			sb.WriteString(value)
+			continue
+		}
+
+		if prevHeader != "" {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(header)
+		sb.WriteString(value)
+		prevHeader = header
+	}
+
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
+
+// GenerateBatchedInsertEdgeStatements takes a slice of struct edges and generates the corresponding
+// INSERT EDGE scripts separated by semicolons. The function takes an additional parameter batchSize
+// which specifies the number of edges to process in each batch.
+func GenerateBatchedInsertEdgeStatements(edges []IInsertableEdge, batchSize int) ([]string, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive")
+	}
+
+	scripts := make([]string, 0)
+	for i := 0; i < len(edges); i = i + batchSize {
+		st := i
+		end := i + batchSize
+		if end > len(edges) {
+			end = len(edges)
+		}
+
+		script, err := GenerateInsertEdgeStatement(edges[st:end])
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, script)
+	}
+
+	return scripts, nil
+}
+
+// encodeEdge reflects over the given edge and returns the statement header
+// (INSERT EDGE ... (fields) VALUES ) and the encoded src->dst@rank:(values) part separately,
+// so that edges sharing the same header can be merged into one statement.
+func encodeEdge(edge IInsertableEdge) (string, string, error) {
+	// raise error if edge is nil
+	if edge == nil {
+		return "", "", fmt.Errorf("edge is nil")
+	}
+
+	v := reflect.ValueOf(edge)
+
+	if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct {
+		v = v.Elem()
+	} else {
+		return "", "", fmt.Errorf("edge is not a pointer to struct: %v", v.Kind())
+	}
+
+	var header strings.Builder
+
+	edgeTypeName := edge.GetEdgeTypeName()
+	if edge.InsertIfNotExists() {
+		header.WriteString(fmt.Sprintf("INSERT EDGE IF NOT EXISTS %s ", edgeTypeName))
+	} else {
+		header.WriteString(fmt.Sprintf("INSERT EDGE %s ", edgeTypeName))
+	}
+
+	nebulaInfo := readThroughCache(v.Type())
+
+	availableNebulaFields := make([]string, 0)
+	for _, nebulaField := range nebulaInfo.NebulaFields {
+		structField := nebulaInfo.NebulaFieldAndStructFieldMap[nebulaField]
+		structFieldVal := v.FieldByIndex(structField.Index)
+
+		if structFieldVal.Kind() == reflect.Pointer {
+			structFieldVal = structFieldVal.Elem()
+		}
+
+		if structFieldVal.IsValid() {
+			availableNebulaFields = append(availableNebulaFields, nebulaField)
+		}
+	}
+
+	header.WriteString("(" + strings.Join(availableNebulaFields, ", ") + ") VALUES ")
+
+	srcValue, err := statement.EncodeReflectedVidValue(statement.SRC_GO_TAG, fieldByStructField(v, nebulaInfo.SrcStructField))
+	if err != nil {
+		return "", "", err
+	}
+
+	dstValue, err := statement.EncodeReflectedVidValue(statement.DST_GO_TAG, fieldByStructField(v, nebulaInfo.DstStructField))
+	if err != nil {
+		return "", "", err
+	}
+
+	rank, err := readRank(v, nebulaInfo.RankStructField)
+	if err != nil {
+		return "", "", err
+	}
+
+	values := make([]string, 0, len(availableNebulaFields))
+	for _, nebulaField := range availableNebulaFields {
+		structField := nebulaInfo.NebulaFieldAndStructFieldMap[nebulaField]
+
+		value, err := statement.EncodeReflectedFieldValue(structField, v.FieldByIndex(structField.Index))
+		if err != nil {
+			return "", "", err
+		}
+		values = append(values, value)
+	}
+
+	return header.String(), fmt.Sprintf("%s->%s@%d:(%s)", srcValue, dstValue, rank, strings.Join(values, ", ")), nil
+}
+
+// readRank returns the value of the "nebula_rank" tagged field, or 0 when there is no such field or it is nil.
+func readRank(v reflect.Value, rankStructField *reflect.StructField) (int64, error) {
+	if rankStructField == nil {
+		return 0, nil
+	}
+
+	rankField := v.FieldByIndex(rankStructField.Index)
+	if rankField.Kind() == reflect.Pointer {
+		if rankField.IsNil() {
+			return 0, nil
+		}
+		rankField = rankField.Elem()
+	}
+
+	switch rankField.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rankField.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(rankField.Uint()), nil
+	default:
+		return 0, fmt.Errorf("`%s` tagged field of struct must be an integer, got: %v", statement.RANK_GO_TAG, rankField.Kind())
+	}
+}
+
+// fieldByStructField returns the field of v described by structField, or the zero Value
+// when the struct has no field tagged accordingly.
+func fieldByStructField(v reflect.Value, structField reflect.StructField) reflect.Value {
+	if structField.Index == nil {
+		return reflect.Value{}
+	}
+	return v.FieldByIndex(structField.Index)
+}
+
+func readThroughCache(edgeType reflect.Type) nebulaInfoPerStruct {
+	if result, ok := cachedNebulaInfoPerStruct.Load(edgeType); ok {
+		return result.(nebulaInfoPerStruct)
+	}
+
+	nebulaInfo := nebulaInfoPerStruct{
+		NebulaFieldAndStructFieldMap: make(map[string]reflect.StructField),
+	}
+
+	fieldCount := edgeType.NumField()
+	for i := 0; i < fieldCount; i++ {
+		structField := edgeType.Field(i)
+		nebulaField := structField.Tag.Get(statement.NEBULA_FIELD_GO_TAG)
+		if nebulaField != "" {
+			nebulaInfo.NebulaFieldAndStructFieldMap[nebulaField] = structField
+		}
+
+		if structField.Tag.Get(statement.SRC_GO_TAG) != "" {
+			nebulaInfo.SrcStructField = structField
+		}
+
+		if structField.Tag.Get(statement.DST_GO_TAG) != "" {
+			nebulaInfo.DstStructField = structField
+		}
+
+		if structField.Tag.Get(statement.RANK_GO_TAG) != "" {
+			nebulaInfo.RankStructField = &structField
+		}
+	}
+	nebulaInfo.NebulaFields = slices.Collect(maps.Keys(nebulaInfo.NebulaFieldAndStructFieldMap))
+	sort.Strings(nebulaInfo.NebulaFields)
+
+	cachedNebulaInfoPerStruct.Store(edgeType, nebulaInfo)
+
+	return nebulaInfo
+}
@@ -0,0 +1,178 @@
+package statement
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// INebulaFieldValue is implemented by typed values that know how to encode themselves
+// into an nGQL literal or expression. Values implementing it can be used as property
+// values of the map based statements, e.g. edge_insert and edge_upsert.
+type INebulaFieldValue interface {
+	EncodeNebulaFieldValue() (string, error)
+}
+
+// Null is the NULL value of any Nebula property type.
+var Null INebulaFieldValue = nullValue{}
+
+type nullValue struct{}
+
+func (nullValue) EncodeNebulaFieldValue() (string, error) {
+	return "NULL", nil
+}
+
+// ExprValue is a raw nGQL expression written into the statement as is, e.g. now() or $param.
+type ExprValue string
+
+// Expr returns the given raw nGQL expression as a field value.
+// The expression is not escaped nor validated.
+func Expr(raw string) ExprValue {
+	return ExprValue(raw)
+}
+
+func (e ExprValue) EncodeNebulaFieldValue() (string, error) {
+	if e == "" {
+		return "", fmt.Errorf("expression is empty")
+	}
+	return string(e), nil
+}
+
+// DateValue is a value of the date property type.
+type DateValue struct {
+	t time.Time
+}
+
+// Date returns a date field value built from the year, month and day of t.
+func Date(t time.Time) DateValue {
+	return DateValue{t: t}
+}
+
+func (d DateValue) EncodeNebulaFieldValue() (string, error) {
+	// date("2025-02-15")
+	return fmt.Sprintf(`date("%s")`, d.t.Format("2006-01-02")), nil
+}
+
+// TimeValue is a value of the time property type.
+type TimeValue struct {
+	t time.Time
+}
+
+// Time returns a time field value built from the wall clock of t.
+func Time(t time.Time) TimeValue {
+	return TimeValue{t: t}
+}
+
+func (t TimeValue) EncodeNebulaFieldValue() (string, error) {
+	// time("14:30:00.000000")
+	return fmt.Sprintf(`time("%s")`, t.t.Format("15:04:05.000000")), nil
+}
+
+// DateTimeValue is a value of the datetime property type.
+type DateTimeValue struct {
+	t time.Time
+}
+
+// DateTime returns a datetime field value built from the wall clock of t in its own location.
+// The server interprets the value in its configured timezone, so convert t with t.In(loc)
+// beforehand if the locations differ.
+func DateTime(t time.Time) DateTimeValue {
+	return DateTimeValue{t: t}
+}
+
+func (dt DateTimeValue) EncodeNebulaFieldValue() (string, error) {
+	// datetime("2017-03-04T22:30:40.003000")
+	return fmt.Sprintf(`datetime("%s")`, dt.t.Format("2006-01-02T15:04:05.000000")), nil
+}
+
+// TimestampValue is a value of the timestamp property type.
+type TimestampValue struct {
+	t time.Time
+}
+
+// Timestamp returns a timestamp field value holding the unix time of t.
+func Timestamp(t time.Time) TimestampValue {
+	return TimestampValue{t: t}
+}
+
+func (ts TimestampValue) EncodeNebulaFieldValue() (string, error) {
+	// timestamp(1559177216)
+	return fmt.Sprintf(`timestamp(%d)`, ts.t.Unix()), nil
+}
+
+// GeoValue is a value of the geography property type given in WKT format.
+type GeoValue string
+
+// Geo returns a geography field value from the given WKT, e.g. "POINT(1 1)".
+func Geo(wkt string) GeoValue {
+	return GeoValue(wkt)
+}
+
+func (g GeoValue) EncodeNebulaFieldValue() (string, error) {
+	if g == "" {
+		return "", fmt.Errorf("geography WKT is empty")
+	}
+	// ST_GeogFromText("POINT(1 1)")
+	return fmt.Sprintf(`ST_GeogFromText("%s")`, string(g)), nil
+}
+
+// DurationValue is a value of the duration property type.
+// Months and years are kept apart from the other components because their length in seconds
+// is not fixed, the same way Nebula stores them.
+type DurationValue struct {
+	Years        int64
+	Months       int64
+	Days         int64
+	Hours        int64
+	Minutes      int64
+	Seconds      int64
+	Microseconds int64
+}
+
+// Duration returns a duration field value equivalent to d, broken down into hours,
+// minutes, seconds and microseconds.
+func Duration(d time.Duration) DurationValue {
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	d -= seconds * time.Second
+
+	return DurationValue{
+		Hours:        int64(hours),
+		Minutes:      int64(minutes),
+		Seconds:      int64(seconds),
+		Microseconds: int64(d / time.Microsecond),
+	}
+}
+
+func (d DurationValue) EncodeNebulaFieldValue() (string, error) {
+	components := []struct {
+		key   string
+		value int64
+	}{
+		{"years", d.Years},
+		{"months", d.Months},
+		{"days", d.Days},
+		{"hours", d.Hours},
+		{"minutes", d.Minutes},
+		{"seconds", d.Seconds},
+		{"microseconds", d.Microseconds},
+	}
+
+	parts := make([]string, 0, len(components))
+	for _, c := range components {
+		if c.value != 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", c.key, c.value))
+		}
+	}
+
+	// an empty map is not accepted by duration(), zero duration is written as 0 seconds
+	if len(parts) == 0 {
+		parts = append(parts, "seconds: 0")
+	}
+
+	// duration({years: 12, days: 14, hours: 99, minutes: 12})
+	return fmt.Sprintf("duration({%s})", strings.Join(parts, ", ")), nil
+}
@@ -0,0 +1,112 @@
+package fulltext_index_create
+
+import (
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"strings"
+)
+
+// CreateFullTextIndexStatement represents a CREATE FULLTEXT TAG INDEX or CREATE FULLTEXT EDGE INDEX statement
+// in a graph database. Full-text indexes are built by the Elasticsearch listeners of the space, on string
+// or fixed_string properties of a tag or edge type.
+type CreateFullTextIndexStatement struct {
+	schemaType statement.SchemaType // required
+	name       string               // required
+	schemaName string               // required
+	fields     []string             // required
+	analyzer   string               // optional
+}
+
+// CreateFullTextIndexStatementOption is a functional option for configuring a CreateFullTextIndexStatement.
+// It takes a pointer to a CreateFullTextIndexStatement as its argument.
+type CreateFullTextIndexStatementOption func(*CreateFullTextIndexStatement)
+
+// NewCreateFullTextTagIndexStatement creates a new CreateFullTextIndexStatement of a tag full-text index
+// with the given options. It applies each provided option to the statement before returning it.
+//
+// Example usage:
+//
+//	```
+//	stmt := NewCreateFullTextTagIndexStatement("nebula_account_name", "account", []string{"name"},
+//		WithAnalyzer("standard"))
+//	```
+func NewCreateFullTextTagIndexStatement(name string, tagName string, fields []string, options ...CreateFullTextIndexStatementOption) CreateFullTextIndexStatement {
+	return newCreateFullTextIndexStatement(statement.SchemaTypeTag, name, tagName, fields, options)
+}
+
+// NewCreateFullTextEdgeIndexStatement creates a new CreateFullTextIndexStatement of an edge full-text index
+// with the given options. It applies each provided option to the statement before returning it.
+func NewCreateFullTextEdgeIndexStatement(name string, edgeName string, fields []string, options ...CreateFullTextIndexStatementOption) CreateFullTextIndexStatement {
+	return newCreateFullTextIndexStatement(statement.SchemaTypeEdge, name, edgeName, fields, options)
+}
+
+func newCreateFullTextIndexStatement(schemaType statement.SchemaType, name string, schemaName string, fields []string, options []CreateFullTextIndexStatementOption) CreateFullTextIndexStatement {
+	statement := CreateFullTextIndexStatement{
+		schemaType: schemaType,
+		name:       name,
+		schemaName: schemaName,
+		fields:     fields,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithAnalyzer sets the Elasticsearch analyzer of the CreateFullTextIndexStatement, e.g. standard.
+func WithAnalyzer(analyzer string) func(*CreateFullTextIndexStatement) {
+	return func(stmt *CreateFullTextIndexStatement) {
+		stmt.analyzer = analyzer
+	}
+}
+
+// GenerateCreateFullTextIndexStatement generates the CREATE FULLTEXT TAG/EDGE INDEX statement based on the
+// provided CreateFullTextIndexStatement. It returns an error if a name is empty, if no property is given,
+// or if a property is empty or duplicated. Nebula requires the index name to start with nebula_, it is
+// left to the server to check it.
+func GenerateCreateFullTextIndexStatement(index CreateFullTextIndexStatement) (string, error) {
+	if !index.schemaType.IsValid() {
+		return "", fmt.Errorf("invalid schema type %s", index.schemaType)
+	}
+	if index.name == "" {
+		return "", fmt.Errorf("index name cannot be empty")
+	}
+	if index.schemaName == "" {
+		return "", fmt.Errorf("%s name cannot be empty", strings.ToLower(string(index.schemaType)))
+	}
+	if len(index.fields) == 0 {
+		return "", fmt.Errorf("at least one property is required")
+	}
+
+	seen := make(map[string]bool, len(index.fields))
+	for _, field := range index.fields {
+		if field == "" {
+			return "", fmt.Errorf("index property name cannot be empty")
+		}
+		if seen[field] {
+			return "", fmt.Errorf("duplicate index property %s", field)
+		}
+		seen[field] = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("CREATE FULLTEXT ")
+	sb.WriteString(string(index.schemaType))
+	sb.WriteString(" INDEX ")
+	sb.WriteString(index.name)
+	sb.WriteString(" ON ")
+	sb.WriteString(index.schemaName)
+	sb.WriteString("(")
+	sb.WriteString(strings.Join(index.fields, ", "))
+	sb.WriteString(")")
+	if index.analyzer != "" {
+		sb.WriteString(fmt.Sprintf(` ANALYZER="%s"`, index.analyzer))
+	}
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
@@ -0,0 +1,26 @@
+package fulltext_index_drop
+
+import (
+	"fmt"
+)
+
+// DropFullTextIndexStatement represents a DROP FULLTEXT INDEX statement in a graph database.
+type DropFullTextIndexStatement struct {
+	name string // required
+}
+
+// NewDropFullTextIndexStatement creates a new DropFullTextIndexStatement of the given full-text index.
+func NewDropFullTextIndexStatement(name string) DropFullTextIndexStatement {
+	return DropFullTextIndexStatement{
+		name: name,
+	}
+}
+
+// GenerateDropFullTextIndexStatement generates the DROP FULLTEXT INDEX statement based on the provided DropFullTextIndexStatement.
+func GenerateDropFullTextIndexStatement(index DropFullTextIndexStatement) (string, error) {
+	if index.name == "" {
+		return "", fmt.Errorf("index name cannot be empty")
+	}
+
+	return fmt.Sprintf("DROP FULLTEXT INDEX %s;", index.name), nil
+}
@@ -0,0 +1,161 @@
+package index_create
+
+import (
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"strings"
+)
+
+// CreateIndexStatement represents a CREATE TAG INDEX or CREATE EDGE INDEX statement in a graph database.
+// It contains the name of the index, the tag or edge type it is built on and the indexed properties.
+type CreateIndexStatement struct {
+	schemaType  statement.SchemaType // required
+	name        string               // required
+	schemaName  string               // required
+	fields      []IndexField         // optional
+	ifNotExists bool                 // optional
+	comment     string               // optional
+}
+
+// IndexField represents an indexed property.
+// Nebula requires a prefix length for the properties of the string type, the fixed_string
+// properties are indexed on their whole length when no prefix length is given.
+type IndexField struct {
+	name   string
+	length int
+}
+
+// NewIndexField creates a new IndexField on the given property.
+func NewIndexField(name string) IndexField {
+	return IndexField{
+		name: name,
+	}
+}
+
+// NewPrefixIndexField creates a new IndexField indexing the first length characters of the given string property.
+func NewPrefixIndexField(name string, length int) IndexField {
+	return IndexField{
+		name:   name,
+		length: length,
+	}
+}
+
+// CreateIndexStatementOption is a functional option for configuring a CreateIndexStatement.
+// It takes a pointer to a CreateIndexStatement as its argument.
+type CreateIndexStatementOption func(*CreateIndexStatement)
+
+// NewCreateTagIndexStatement creates a new CreateIndexStatement of a tag index with the given options.
+// It applies each provided option to the statement before returning it.
+//
+// Example usage:
+//
+//	```
+//	stmt := NewCreateTagIndexStatement("account_name", "account",
+//		WithFields([]IndexField{NewPrefixIndexField("name", 10)}))
+//	```
+func NewCreateTagIndexStatement(name string, tagName string, options ...CreateIndexStatementOption) CreateIndexStatement {
+	return newCreateIndexStatement(statement.SchemaTypeTag, name, tagName, options)
+}
+
+// NewCreateEdgeIndexStatement creates a new CreateIndexStatement of an edge index with the given options.
+// It applies each provided option to the statement before returning it.
+func NewCreateEdgeIndexStatement(name string, edgeName string, options ...CreateIndexStatementOption) CreateIndexStatement {
+	return newCreateIndexStatement(statement.SchemaTypeEdge, name, edgeName, options)
+}
+
+func newCreateIndexStatement(schemaType statement.SchemaType, name string, schemaName string, options []CreateIndexStatementOption) CreateIndexStatement {
+	statement := CreateIndexStatement{
+		schemaType: schemaType,
+		name:       name,
+		schemaName: schemaName,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithFields sets the indexed properties of the CreateIndexStatement, in the order of the index.
+// An index without properties indexes the tag or edge type itself.
+func WithFields(fields []IndexField) func(*CreateIndexStatement) {
+	return func(stmt *CreateIndexStatement) {
+		stmt.fields = fields
+	}
+}
+
+// WithIfNotExists sets the ifNotExists flag of the CreateIndexStatement to the provided value.
+func WithIfNotExists(ifNotExists bool) func(*CreateIndexStatement) {
+	return func(stmt *CreateIndexStatement) {
+		stmt.ifNotExists = ifNotExists
+	}
+}
+
+// WithComment sets the comment of the CreateIndexStatement to the provided value.
+func WithComment(comment string) func(*CreateIndexStatement) {
+	return func(stmt *CreateIndexStatement) {
+		stmt.comment = comment
+	}
+}
+
+// GenerateCreateIndexStatement generates the CREATE TAG/EDGE INDEX statement based on the provided CreateIndexStatement.
+// It returns an error if a name is empty, or if a property is empty, duplicated or has a negative prefix length.
+func GenerateCreateIndexStatement(index CreateIndexStatement) (string, error) {
+	if !index.schemaType.IsValid() {
+		return "", fmt.Errorf("invalid schema type %s", index.schemaType)
+	}
+	if index.name == "" {
+		return "", fmt.Errorf("index name cannot be empty")
+	}
+	if index.schemaName == "" {
+		return "", fmt.Errorf("%s name cannot be empty", strings.ToLower(string(index.schemaType)))
+	}
+
+	seen := make(map[string]bool, len(index.fields))
+	for _, field := range index.fields {
+		if field.name == "" {
+			return "", fmt.Errorf("index property name cannot be empty")
+		}
+		if seen[field.name] {
+			return "", fmt.Errorf("duplicate index property %s", field.name)
+		}
+		if field.length < 0 {
+			return "", fmt.Errorf("prefix length of index property %s cannot be negative", field.name)
+		}
+		seen[field.name] = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("CREATE ")
+	sb.WriteString(string(index.schemaType))
+	sb.WriteString(" INDEX ")
+	if index.ifNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+
+	sb.WriteString(index.name)
+	sb.WriteString(" ON ")
+	sb.WriteString(index.schemaName)
+	sb.WriteString(" (")
+
+	for i, field := range index.fields {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(field.name)
+		if field.length > 0 {
+			sb.WriteString(fmt.Sprintf("(%d)", field.length))
+		}
+	}
+
+	sb.WriteString(")")
+	if index.comment != "" {
+		sb.WriteString(fmt.Sprintf(" COMMENT = '%s'", index.comment))
+	}
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
@@ -0,0 +1,74 @@
+package index_drop
+
+import (
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"strings"
+)
+
+// DropIndexStatement represents a DROP TAG INDEX or DROP EDGE INDEX statement in a graph database.
+type DropIndexStatement struct {
+	schemaType statement.SchemaType // required
+	name       string               // required
+	ifExists   bool                 // optional
+}
+
+// DropIndexStatementOption is a functional option for configuring a DropIndexStatement.
+// It takes a pointer to a DropIndexStatement as its argument.
+type DropIndexStatementOption func(*DropIndexStatement)
+
+// NewDropTagIndexStatement creates a new DropIndexStatement of a tag index with the given options.
+// It applies each provided option to the statement before returning it.
+func NewDropTagIndexStatement(name string, options ...DropIndexStatementOption) DropIndexStatement {
+	return newDropIndexStatement(statement.SchemaTypeTag, name, options)
+}
+
+// NewDropEdgeIndexStatement creates a new DropIndexStatement of an edge index with the given options.
+// It applies each provided option to the statement before returning it.
+func NewDropEdgeIndexStatement(name string, options ...DropIndexStatementOption) DropIndexStatement {
+	return newDropIndexStatement(statement.SchemaTypeEdge, name, options)
+}
+
+func newDropIndexStatement(schemaType statement.SchemaType, name string, options []DropIndexStatementOption) DropIndexStatement {
+	statement := DropIndexStatement{
+		schemaType: schemaType,
+		name:       name,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithIfExists sets the ifExists flag of the DropIndexStatement to the provided value.
+func WithIfExists(ifExists bool) func(*DropIndexStatement) {
+	return func(stmt *DropIndexStatement) {
+		stmt.ifExists = ifExists
+	}
+}
+
+// GenerateDropIndexStatement generates the DROP TAG/EDGE INDEX statement based on the provided DropIndexStatement.
+func GenerateDropIndexStatement(index DropIndexStatement) (string, error) {
+	if !index.schemaType.IsValid() {
+		return "", fmt.Errorf("invalid schema type %s", index.schemaType)
+	}
+	if index.name == "" {
+		return "", fmt.Errorf("index name cannot be empty")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("DROP ")
+	sb.WriteString(string(index.schemaType))
+	sb.WriteString(" INDEX ")
+	if index.ifExists {
+		sb.WriteString("IF EXISTS ")
+	}
+
+	sb.WriteString(index.name)
+	sb.WriteString(";")
+	return sb.String(), nil
+}
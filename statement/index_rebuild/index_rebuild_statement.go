@@ -0,0 +1,65 @@
+package index_rebuild
+
+import (
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"strings"
+)
+
+// RebuildIndexStatement represents a REBUILD TAG INDEX or REBUILD EDGE INDEX statement in a graph database.
+// The statement submits a job rebuilding the indexes on the data written before they were created,
+// its progress can be followed with SHOW JOB or SHOW TAG/EDGE INDEX STATUS.
+type RebuildIndexStatement struct {
+	schemaType statement.SchemaType // required
+	names      []string             // optional
+}
+
+// NewRebuildTagIndexStatement creates a new RebuildIndexStatement of the given tag indexes.
+// All the tag indexes of the space are rebuilt when no name is given.
+func NewRebuildTagIndexStatement(names ...string) RebuildIndexStatement {
+	return RebuildIndexStatement{
+		schemaType: statement.SchemaTypeTag,
+		names:      names,
+	}
+}
+
+// NewRebuildEdgeIndexStatement creates a new RebuildIndexStatement of the given edge indexes.
+// All the edge indexes of the space are rebuilt when no name is given.
+func NewRebuildEdgeIndexStatement(names ...string) RebuildIndexStatement {
+	return RebuildIndexStatement{
+		schemaType: statement.SchemaTypeEdge,
+		names:      names,
+	}
+}
+
+// GenerateRebuildIndexStatement generates the REBUILD TAG/EDGE INDEX statement based on the provided RebuildIndexStatement.
+// It returns an error if an index name is empty or duplicated.
+func GenerateRebuildIndexStatement(index RebuildIndexStatement) (string, error) {
+	if !index.schemaType.IsValid() {
+		return "", fmt.Errorf("invalid schema type %s", index.schemaType)
+	}
+
+	seen := make(map[string]bool, len(index.names))
+	for _, name := range index.names {
+		if name == "" {
+			return "", fmt.Errorf("index name cannot be empty")
+		}
+		if seen[name] {
+			return "", fmt.Errorf("duplicate index %s", name)
+		}
+		seen[name] = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("REBUILD ")
+	sb.WriteString(string(index.schemaType))
+	sb.WriteString(" INDEX")
+	if len(index.names) > 0 {
+		sb.WriteString(" ")
+		sb.WriteString(strings.Join(index.names, ", "))
+	}
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
@@ -0,0 +1,35 @@
+package index_status
+
+import (
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+)
+
+// ShowIndexStatusStatement represents a SHOW TAG INDEX STATUS or SHOW EDGE INDEX STATUS statement in a graph database.
+// The statement lists the rebuild status of the tag or edge indexes of the current space.
+type ShowIndexStatusStatement struct {
+	schemaType statement.SchemaType // required
+}
+
+// NewShowTagIndexStatusStatement creates a new ShowIndexStatusStatement of the tag indexes.
+func NewShowTagIndexStatusStatement() ShowIndexStatusStatement {
+	return ShowIndexStatusStatement{
+		schemaType: statement.SchemaTypeTag,
+	}
+}
+
+// NewShowEdgeIndexStatusStatement creates a new ShowIndexStatusStatement of the edge indexes.
+func NewShowEdgeIndexStatusStatement() ShowIndexStatusStatement {
+	return ShowIndexStatusStatement{
+		schemaType: statement.SchemaTypeEdge,
+	}
+}
+
+// GenerateShowIndexStatusStatement generates the SHOW TAG/EDGE INDEX STATUS statement based on the provided ShowIndexStatusStatement.
+func GenerateShowIndexStatusStatement(status ShowIndexStatusStatement) (string, error) {
+	if !status.schemaType.IsValid() {
+		return "", fmt.Errorf("invalid schema type %s", status.schemaType)
+	}
+
+	return fmt.Sprintf("SHOW %s INDEX STATUS;", status.schemaType), nil
+}
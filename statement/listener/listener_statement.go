@@ -0,0 +1,51 @@
+package listener
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AddListenerStatement represents an ADD LISTENER ELASTICSEARCH statement in a graph database.
+// The listeners replicate the data of the current space to the Elasticsearch clients for
+// the full-text indexes.
+type AddListenerStatement struct {
+	hosts []string // required
+}
+
+// NewAddListenerStatement creates a new AddListenerStatement of the given listener addresses,
+// e.g. "192.168.8.100:9789".
+func NewAddListenerStatement(hosts ...string) AddListenerStatement {
+	return AddListenerStatement{
+		hosts: hosts,
+	}
+}
+
+// GenerateAddListenerStatement generates the ADD LISTENER ELASTICSEARCH statement based on the provided AddListenerStatement.
+// It returns an error if no host is given, or if a host is empty, duplicated or has no port.
+func GenerateAddListenerStatement(listener AddListenerStatement) (string, error) {
+	if len(listener.hosts) == 0 {
+		return "", fmt.Errorf("at least one listener host is required")
+	}
+
+	seen := make(map[string]bool, len(listener.hosts))
+	for _, host := range listener.hosts {
+		if host == "" {
+			return "", fmt.Errorf("listener host cannot be empty")
+		}
+		if !strings.Contains(host, ":") {
+			return "", fmt.Errorf("listener host %s has no port", host)
+		}
+		if seen[host] {
+			return "", fmt.Errorf("duplicate listener host %s", host)
+		}
+		seen[host] = true
+	}
+
+	return fmt.Sprintf("ADD LISTENER ELASTICSEARCH %s;", strings.Join(listener.hosts, ", ")), nil
+}
+
+// GenerateRemoveListenerStatement generates the REMOVE LISTENER ELASTICSEARCH statement, removing all the
+// listeners of the current space.
+func GenerateRemoveListenerStatement() string {
+	return "REMOVE LISTENER ELASTICSEARCH;"
+}
@@ -0,0 +1,96 @@
+package role
+
+import (
+	"fmt"
+)
+
+// Role is a built-in role of a user in a graph space.
+type Role string
+
+const (
+	RoleGod   Role = "GOD"
+	RoleAdmin Role = "ADMIN"
+	RoleDBA   Role = "DBA"
+	RoleUser  Role = "USER"
+	RoleGuest Role = "GUEST"
+)
+
+// IsGrantable returns whether the role can be granted. The GOD role belongs to the root user only.
+func (r Role) IsGrantable() bool {
+	return r == RoleAdmin || r == RoleDBA || r == RoleUser || r == RoleGuest
+}
+
+// GrantRoleStatement represents a GRANT ROLE statement in a graph database.
+// A user has at most one role in a space, granting a role replaces the previous one.
+type GrantRoleStatement struct {
+	role      Role   // required
+	spaceName string // required
+	userName  string // required
+}
+
+// NewGrantRoleStatement creates a new GrantRoleStatement granting the role on the space to the user.
+func NewGrantRoleStatement(role Role, spaceName string, userName string) GrantRoleStatement {
+	return GrantRoleStatement{
+		role:      role,
+		spaceName: spaceName,
+		userName:  userName,
+	}
+}
+
+// GenerateGrantRoleStatement generates the GRANT ROLE statement based on the provided GrantRoleStatement.
+// It returns an error if a name is empty or if the role cannot be granted.
+func GenerateGrantRoleStatement(grant GrantRoleStatement) (string, error) {
+	if err := validate(grant.role, grant.spaceName, grant.userName); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("GRANT ROLE %s ON %s TO %s;", grant.role, grant.spaceName, grant.userName), nil
+}
+
+// RevokeRoleStatement represents a REVOKE ROLE statement in a graph database.
+type RevokeRoleStatement struct {
+	role      Role   // required
+	spaceName string // required
+	userName  string // required
+}
+
+// NewRevokeRoleStatement creates a new RevokeRoleStatement revoking the role on the space from the user.
+func NewRevokeRoleStatement(role Role, spaceName string, userName string) RevokeRoleStatement {
+	return RevokeRoleStatement{
+		role:      role,
+		spaceName: spaceName,
+		userName:  userName,
+	}
+}
+
+// GenerateRevokeRoleStatement generates the REVOKE ROLE statement based on the provided RevokeRoleStatement.
+// It returns an error if a name is empty or if the role cannot be granted, hence revoked.
+func GenerateRevokeRoleStatement(revoke RevokeRoleStatement) (string, error) {
+	if err := validate(revoke.role, revoke.spaceName, revoke.userName); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("REVOKE ROLE %s ON %s FROM %s;", revoke.role, revoke.spaceName, revoke.userName), nil
+}
+
+// GenerateShowRolesStatement generates the SHOW ROLES IN statement listing the roles of the users in the space.
+func GenerateShowRolesStatement(spaceName string) (string, error) {
+	if spaceName == "" {
+		return "", fmt.Errorf("space name cannot be empty")
+	}
+
+	return fmt.Sprintf("SHOW ROLES IN %s;", spaceName), nil
+}
+
+func validate(role Role, spaceName string, userName string) error {
+	if !role.IsGrantable() {
+		return fmt.Errorf("role %s cannot be granted", role)
+	}
+	if spaceName == "" {
+		return fmt.Errorf("space name cannot be empty")
+	}
+	if userName == "" {
+		return fmt.Errorf("user name cannot be empty")
+	}
+	return nil
+}
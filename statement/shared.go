@@ -1,6 +1,10 @@
 package statement
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
 
 type PropertyType string
 
@@ -28,6 +32,19 @@ const (
 	PropertyTypeGeography PropertyType = "geography"
 )
 
+// SchemaType is the type of schema an index or a statement is built on.
+type SchemaType string
+
+const (
+	SchemaTypeTag  SchemaType = "TAG"
+	SchemaTypeEdge SchemaType = "EDGE"
+)
+
+// IsValid returns whether the schema type is either TAG or EDGE.
+func (t SchemaType) IsValid() bool {
+	return t == SchemaTypeTag || t == SchemaTypeEdge
+}
+
 type IEdgeStatementOperation[T VidType] interface {
 	GetSrcVid() T
 	GetDstVid() T
@@ -54,6 +71,9 @@ const (
 	VID_GO_TAG               = "nebula_vid"
 	NEBULA_FIELD_GO_TAG      = "nebula_field"
 	NEBULA_FIELD_TYPE_GO_TAG = "nebula_field_type"
+	SRC_GO_TAG               = "nebula_src"
+	DST_GO_TAG               = "nebula_dst"
+	RANK_GO_TAG              = "nebula_rank"
 )
 
 // EncodeVidFieldValueAsStr encodes a vertex ID field value into a string representation.
@@ -82,8 +102,27 @@ func EncodeVidFieldValueAsStr(vidValue any) (string, error) {
 // - int (and its variants)
 // - float (and its variants)
 // - bool
+// - time.Time, encoded as datetime
+// - time.Duration, encoded as duration
+// - nil, encoded as NULL
+// - INebulaFieldValue, e.g. Date, Time, DateTime, Timestamp, Geo, Duration, Null and Expr
+// - pointers to any of the above, where a nil pointer is encoded as NULL
+// - named types whose underlying type is one of string, int, float or bool
 func EncodeNebulaFieldValue(fieldValue any) (string, error) {
+	// a nil pointer to a type implementing INebulaFieldValue with value receivers would be dereferenced by its method
+	if rv := reflect.ValueOf(fieldValue); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return Null.EncodeNebulaFieldValue()
+	}
+
 	switch v := fieldValue.(type) {
+	case nil:
+		return Null.EncodeNebulaFieldValue()
+	case INebulaFieldValue:
+		return v.EncodeNebulaFieldValue()
+	case time.Time:
+		return DateTime(v).EncodeNebulaFieldValue()
+	case time.Duration:
+		return Duration(v).EncodeNebulaFieldValue()
 	case string:
 		return fmt.Sprintf(`"%s"`, v), nil
 	case int, int8, int16, int32, int64,
@@ -93,7 +132,95 @@ func EncodeNebulaFieldValue(fieldValue any) (string, error) {
 		return fmt.Sprintf(`%v`, v), nil
 	case bool:
 		return fmt.Sprintf(`%v`, v), nil
+	}
+
+	rv := reflect.ValueOf(fieldValue)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return Null.EncodeNebulaFieldValue()
+		}
+		return EncodeNebulaFieldValue(rv.Elem().Interface())
+	case reflect.String:
+		return EncodeNebulaFieldValue(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return EncodeNebulaFieldValue(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return EncodeNebulaFieldValue(rv.Uint())
+	case reflect.Float32:
+		return EncodeNebulaFieldValue(float32(rv.Float()))
+	case reflect.Float64:
+		return EncodeNebulaFieldValue(rv.Float())
+	case reflect.Bool:
+		return EncodeNebulaFieldValue(rv.Bool())
 	default:
-		return "", fmt.Errorf("unsupported vid type: %T", v)
+		return "", fmt.Errorf("unsupported field value type: %T", fieldValue)
+	}
+}
+
+// EncodeReflectedVidValue encodes a reflected struct field holding a vertex ID into a string representation.
+// Pointer fields are dereferenced, string values are double-quoted and integer values are written as is.
+// The goTag is the name of the go tag the field is tagged with, it is only used to build the error message
+// returned when the field is nil or of an unsupported type.
+func EncodeReflectedVidValue(goTag string, vidField reflect.Value) (string, error) {
+	if vidField.Kind() == reflect.Pointer {
+		vidField = vidField.Elem()
+	}
+
+	switch vidField.Kind() {
+	case reflect.String:
+		return fmt.Sprintf(`"%v"`, vidField), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%v", vidField), nil
+	case reflect.Pointer:
+		return fmt.Sprintf("%v", vidField.Elem()), nil
+	default:
+		return "", fmt.Errorf("`%s` tagged field of struct is either nil or not supported", goTag)
+	}
+}
+
+// EncodeReflectedFieldValue encodes a reflected struct field tagged with "nebula_field" into a string representation.
+// Pointer fields are dereferenced. String fields are wrapped into the corresponding nGQL function
+// when the "nebula_field_type" tag is one of date, time, datetime, timestamp, geography or duration,
+// otherwise they are double-quoted. Numeric and bool fields are written as is.
+func EncodeReflectedFieldValue(structField reflect.StructField, structFieldVal reflect.Value) (string, error) {
+	if structFieldVal.Kind() == reflect.Pointer {
+		structFieldVal = structFieldVal.Elem()
+	}
+
+	switch structFieldVal.Kind() {
+	case reflect.String:
+		switch structField.Tag.Get(NEBULA_FIELD_TYPE_GO_TAG) {
+		case string(PropertyTypeDate):
+			//		 date("2025-02-15"),
+			return fmt.Sprintf("date(\"%v\")", structFieldVal), nil
+		case string(PropertyTypeTime):
+			//		  time("14:30:00"),
+			return fmt.Sprintf("time(\"%v\")", structFieldVal), nil
+		case string(PropertyTypeDateTime):
+			//        datetime("2017-03-04T22:30:40.003000[Asia/Shanghai]"),
+			return fmt.Sprintf("datetime(\"%v\")", structFieldVal), nil
+		case string(PropertyTypeTimestamp):
+			//        timestamp("1988-03-01T08:00:00"),
+			return fmt.Sprintf("timestamp(\"%v\")", structFieldVal), nil
+		case string(PropertyTypeGeography):
+			//        ST_GeogFromText("POINT(1 1)"),
+			return fmt.Sprintf("ST_GeogFromText(\"%v\")", structFieldVal), nil
+		case string(PropertyTypeDuration):
+			//        duration({years: 12, days: 14, hours: 99, minutes: 12})
+			return fmt.Sprintf("duration(%v)", structFieldVal), nil
+		default:
+			return fmt.Sprintf("\"%v\"", structFieldVal), nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%v", structFieldVal), nil
+	case reflect.Float32, reflect.Float64:
+		return fmt.Sprintf("%v", structFieldVal), nil
+	case reflect.Bool:
+		return fmt.Sprintf("%v", structFieldVal), nil
+	default:
+		return "", fmt.Errorf("field type not supported: %v", structFieldVal.Kind())
 	}
 }
@@ -0,0 +1,78 @@
+package space_alter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AlterSpaceStatement represents an ALTER SPACE statement in a graph database.
+// Nebula only supports adding zones to a space, the partitions, replicas and vid type
+// of a space cannot be altered once it is created.
+type AlterSpaceStatement struct {
+	name     string   // required
+	addZones []string // required
+}
+
+// AlterSpaceStatementOption is a functional option for configuring an AlterSpaceStatement.
+// It takes a pointer to an AlterSpaceStatement as its argument.
+type AlterSpaceStatementOption func(*AlterSpaceStatement)
+
+// NewAlterSpaceStatement creates a new AlterSpaceStatement with the given options.
+// It applies each provided option to the statement before returning it.
+//
+// Example usage:
+//
+//	```
+//	stmt := NewAlterSpaceStatement("bank", WithAddZones([]string{"zone_1", "zone_2"}))
+//	```
+func NewAlterSpaceStatement(name string, options ...AlterSpaceStatementOption) AlterSpaceStatement {
+	statement := AlterSpaceStatement{
+		name: name,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithAddZones sets the zones to add to the space.
+func WithAddZones(zones []string) func(*AlterSpaceStatement) {
+	return func(stmt *AlterSpaceStatement) {
+		stmt.addZones = zones
+	}
+}
+
+// GenerateAlterSpaceStatement generates the ALTER SPACE statement based on the provided AlterSpaceStatement.
+// It returns an error if the name is empty, or if no zone, an empty zone name or a duplicate zone is given.
+func GenerateAlterSpaceStatement(space AlterSpaceStatement) (string, error) {
+	if space.name == "" {
+		return "", fmt.Errorf("space name cannot be empty")
+	}
+	if len(space.addZones) == 0 {
+		return "", fmt.Errorf("at least one zone to add is required")
+	}
+
+	seen := make(map[string]bool, len(space.addZones))
+	for _, zone := range space.addZones {
+		if zone == "" {
+			return "", fmt.Errorf("zone name cannot be empty")
+		}
+		if seen[zone] {
+			return "", fmt.Errorf("duplicate zone %s", zone)
+		}
+		seen[zone] = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("ALTER SPACE ")
+	sb.WriteString(space.name)
+	sb.WriteString(" ADD ZONE ")
+	sb.WriteString(strings.Join(space.addZones, ", "))
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
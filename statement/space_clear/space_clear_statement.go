@@ -0,0 +1,56 @@
+package space_clear
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClearSpaceStatement represents a CLEAR SPACE statement in a graph database.
+// Clearing a space deletes its data, vertices and edges, but keeps its schema.
+type ClearSpaceStatement struct {
+	name     string // required
+	ifExists bool   // optional
+}
+
+// ClearSpaceStatementOption is a functional option for configuring a ClearSpaceStatement.
+// It takes a pointer to a ClearSpaceStatement as its argument.
+type ClearSpaceStatementOption func(*ClearSpaceStatement)
+
+// NewClearSpaceStatement creates a new ClearSpaceStatement with the given options.
+// It applies each provided option to the statement before returning it.
+func NewClearSpaceStatement(name string, options ...ClearSpaceStatementOption) ClearSpaceStatement {
+	statement := ClearSpaceStatement{
+		name: name,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithIfExists sets the ifExists flag of the ClearSpaceStatement to the provided value.
+func WithIfExists(ifExists bool) func(*ClearSpaceStatement) {
+	return func(stmt *ClearSpaceStatement) {
+		stmt.ifExists = ifExists
+	}
+}
+
+// GenerateClearSpaceStatement generates the CLEAR SPACE statement based on the provided ClearSpaceStatement.
+func GenerateClearSpaceStatement(space ClearSpaceStatement) (string, error) {
+	if space.name == "" {
+		return "", fmt.Errorf("space name cannot be empty")
+	}
+	var sb strings.Builder
+
+	sb.WriteString("CLEAR SPACE ")
+	if space.ifExists {
+		sb.WriteString("IF EXISTS ")
+	}
+
+	sb.WriteString(space.name)
+	sb.WriteString(";")
+	return sb.String(), nil
+}
@@ -0,0 +1,67 @@
+package space_create
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CreateSpaceAsStatement represents a CREATE SPACE ... AS statement, which clones the schema of an
+// existing space, i.e. its partitions, replicas, vid type, tags, edge types and indexes, but not its data.
+type CreateSpaceAsStatement struct {
+	name        string // required
+	sourceName  string // required
+	ifNotExists bool   // optional
+}
+
+// CreateSpaceAsStatementOption is a functional option for configuring a CreateSpaceAsStatement.
+type CreateSpaceAsStatementOption func(*CreateSpaceAsStatement)
+
+// NewCreateSpaceAsStatement creates a new CreateSpaceAsStatement creating the space name as a clone of sourceName.
+// It applies each provided option to the statement before returning it.
+func NewCreateSpaceAsStatement(name string, sourceName string, options ...CreateSpaceAsStatementOption) CreateSpaceAsStatement {
+	statement := CreateSpaceAsStatement{
+		name:       name,
+		sourceName: sourceName,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithAsIfNotExists sets the ifNotExists flag of the CreateSpaceAsStatement to the provided value.
+func WithAsIfNotExists(ifNotExists bool) func(*CreateSpaceAsStatement) {
+	return func(stmt *CreateSpaceAsStatement) {
+		stmt.ifNotExists = ifNotExists
+	}
+}
+
+// GenerateCreateSpaceAsStatement generates the CREATE SPACE ... AS statement based on the provided CreateSpaceAsStatement.
+func GenerateCreateSpaceAsStatement(space CreateSpaceAsStatement) (string, error) {
+	if space.name == "" {
+		return "", fmt.Errorf("space name cannot be empty")
+	}
+	if space.sourceName == "" {
+		return "", fmt.Errorf("source space name cannot be empty")
+	}
+	if space.name == space.sourceName {
+		return "", fmt.Errorf("space %s cannot be cloned into itself", space.name)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("CREATE SPACE ")
+	if space.ifNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+
+	sb.WriteString(space.name)
+	sb.WriteString(" AS ")
+	sb.WriteString(space.sourceName)
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
@@ -0,0 +1,164 @@
+package space_create
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// VidType is the type of the vertex IDs of a space, either FIXED_STRING(<N>) or INT64.
+type VidType string
+
+const (
+	VidTypeInt64 VidType = "INT64"
+)
+
+// VidTypeFixedString returns the FIXED_STRING(<length>) vertex ID type.
+func VidTypeFixedString(length uint) VidType {
+	return VidType(fmt.Sprintf("FIXED_STRING(%d)", length))
+}
+
+var fixedStringVidTypeRegexp = regexp.MustCompile(`^FIXED_STRING\(([1-9][0-9]*)\)$`)
+
+// validateVidType checks that the vid type is either INT64, INT or FIXED_STRING(<N>) with N > 0.
+func validateVidType(vidType VidType) error {
+	if vidType == "" {
+		return fmt.Errorf("vid type is required")
+	}
+
+	upper := VidType(strings.ToUpper(string(vidType)))
+	if upper == VidTypeInt64 || upper == "INT" || fixedStringVidTypeRegexp.MatchString(string(upper)) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid vid type %s, expected INT64 or FIXED_STRING(<N>) with N > 0", vidType)
+}
+
+// CreateSpaceStatement represents a CREATE SPACE statement in a graph database.
+type CreateSpaceStatement struct {
+	name          string  // required
+	vidType       VidType // required
+	ifNotExists   bool    // optional
+	partitionNum  uint    // optional, the server default is used when 0
+	replicaFactor uint    // optional, the server default is used when 0
+	charset       string  // optional
+	collate       string  // optional
+	comment       string  // optional
+}
+
+// CreateSpaceStatementOption is a functional option for configuring a CreateSpaceStatement.
+// It takes a pointer to a CreateSpaceStatement as its argument.
+type CreateSpaceStatementOption func(*CreateSpaceStatement)
+
+// NewCreateSpaceStatement creates a new CreateSpaceStatement with the given name, vid type and options.
+// It applies each provided option to the statement before returning it.
+//
+// Example usage:
+//
+//	```
+//	stmt := NewCreateSpaceStatement("bank", VidTypeFixedString(32),
+//	    WithPartitionNum(10),
+//	    WithReplicaFactor(3))
+//	```
+func NewCreateSpaceStatement(name string, vidType VidType, options ...CreateSpaceStatementOption) CreateSpaceStatement {
+	statement := CreateSpaceStatement{
+		name:    name,
+		vidType: vidType,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithIfNotExists sets the ifNotExists flag of the CreateSpaceStatement to the provided value.
+func WithIfNotExists(ifNotExists bool) func(*CreateSpaceStatement) {
+	return func(stmt *CreateSpaceStatement) {
+		stmt.ifNotExists = ifNotExists
+	}
+}
+
+// WithPartitionNum sets the number of partitions of the space.
+func WithPartitionNum(partitionNum uint) func(*CreateSpaceStatement) {
+	return func(stmt *CreateSpaceStatement) {
+		stmt.partitionNum = partitionNum
+	}
+}
+
+// WithReplicaFactor sets the number of replicas of each partition of the space.
+func WithReplicaFactor(replicaFactor uint) func(*CreateSpaceStatement) {
+	return func(stmt *CreateSpaceStatement) {
+		stmt.replicaFactor = replicaFactor
+	}
+}
+
+// WithCharset sets the charset of the space, e.g. utf8.
+func WithCharset(charset string) func(*CreateSpaceStatement) {
+	return func(stmt *CreateSpaceStatement) {
+		stmt.charset = charset
+	}
+}
+
+// WithCollate sets the collation of the space, e.g. utf8_bin.
+func WithCollate(collate string) func(*CreateSpaceStatement) {
+	return func(stmt *CreateSpaceStatement) {
+		stmt.collate = collate
+	}
+}
+
+// WithComment sets the comment of the space.
+func WithComment(comment string) func(*CreateSpaceStatement) {
+	return func(stmt *CreateSpaceStatement) {
+		stmt.comment = comment
+	}
+}
+
+// GenerateCreateSpaceStatement generates the CREATE SPACE statement based on the provided CreateSpaceStatement.
+// It returns an error if the name is empty, the vid type is invalid, or the collation does not belong to the charset.
+func GenerateCreateSpaceStatement(space CreateSpaceStatement) (string, error) {
+	if space.name == "" {
+		return "", fmt.Errorf("space name cannot be empty")
+	}
+
+	if err := validateVidType(space.vidType); err != nil {
+		return "", err
+	}
+
+	if space.charset != "" && space.collate != "" && !strings.HasPrefix(space.collate, space.charset+"_") {
+		return "", fmt.Errorf("collate %s does not belong to charset %s", space.collate, space.charset)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("CREATE SPACE ")
+	if space.ifNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+
+	sb.WriteString(space.name)
+	sb.WriteString(" (")
+
+	if space.partitionNum > 0 {
+		sb.WriteString(fmt.Sprintf("partition_num = %d, ", space.partitionNum))
+	}
+	if space.replicaFactor > 0 {
+		sb.WriteString(fmt.Sprintf("replica_factor = %d, ", space.replicaFactor))
+	}
+	if space.charset != "" {
+		sb.WriteString(fmt.Sprintf("charset = %s, ", space.charset))
+	}
+	if space.collate != "" {
+		sb.WriteString(fmt.Sprintf("collate = %s, ", space.collate))
+	}
+	sb.WriteString(fmt.Sprintf("vid_type = %s)", space.vidType))
+
+	if space.comment != "" {
+		sb.WriteString(fmt.Sprintf(` COMMENT = '%s'`, space.comment))
+	}
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
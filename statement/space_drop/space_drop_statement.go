@@ -0,0 +1,56 @@
+package space_drop
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DropSpaceStatement represents a DROP SPACE statement in a graph database.
+// Dropping a space deletes the space along with all of its schema and data.
+type DropSpaceStatement struct {
+	name     string // required
+	ifExists bool   // optional
+}
+
+// DropSpaceStatementOption is a functional option for configuring a DropSpaceStatement.
+// It takes a pointer to a DropSpaceStatement as its argument.
+type DropSpaceStatementOption func(*DropSpaceStatement)
+
+// NewDropSpaceStatement creates a new DropSpaceStatement with the given options.
+// It applies each provided option to the statement before returning it.
+func NewDropSpaceStatement(name string, options ...DropSpaceStatementOption) DropSpaceStatement {
+	statement := DropSpaceStatement{
+		name: name,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithIfExists sets the ifExists flag of the DropSpaceStatement to the provided value.
+func WithIfExists(ifExists bool) func(*DropSpaceStatement) {
+	return func(stmt *DropSpaceStatement) {
+		stmt.ifExists = ifExists
+	}
+}
+
+// GenerateDropSpaceStatement generates the DROP SPACE statement based on the provided DropSpaceStatement.
+func GenerateDropSpaceStatement(space DropSpaceStatement) (string, error) {
+	if space.name == "" {
+		return "", fmt.Errorf("space name cannot be empty")
+	}
+	var sb strings.Builder
+
+	sb.WriteString("DROP SPACE ")
+	if space.ifExists {
+		sb.WriteString("IF EXISTS ")
+	}
+
+	sb.WriteString(space.name)
+	sb.WriteString(";")
+	return sb.String(), nil
+}
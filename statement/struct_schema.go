@@ -0,0 +1,178 @@
+package statement
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Go tags used when reflecting a struct into a CREATE TAG / CREATE EDGE schema
+const (
+	NEBULA_TTL_GO_TAG     = "nebula_ttl"
+	NEBULA_COMMENT_GO_TAG = "nebula_comment"
+)
+
+// StructSchema is the schema of a struct reflected by ReflectStructSchema.
+type StructSchema struct {
+	Name        string
+	Fields      []StructFieldSchema
+	TTLDuration uint
+	TTLCol      string
+	Comment     string
+}
+
+// StructFieldSchema is the schema of a single struct field tagged with the "nebula_field" tag.
+type StructFieldSchema struct {
+	Name     string
+	Type     PropertyType
+	Nullable bool
+	Comment  string
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// ReflectStructSchema reflects the given struct, or pointer to struct, into a StructSchema.
+//
+// Only fields tagged with the "nebula_field" tag are taken into account, in declaration order.
+// The property type is taken from the "nebula_field_type" tag when it is set, otherwise it is
+// inferred from the go type of the field:
+// - string -> string
+// - int, int64 -> int64
+// - int32, int16, int8 -> int32, int16, int8
+// - uint32, uint16, uint8 -> int64, int32, int16
+// - float64 -> double
+// - float32 -> float
+// - bool -> bool
+// - time.Time -> datetime
+// - time.Duration -> duration
+// Pointer fields are nullable, all other fields are NOT NULL.
+//
+// A field tagged with "nebula_ttl", e.g. `nebula_ttl:"86400"`, becomes the TTL column with the given
+// TTL duration in seconds. The "nebula_comment" tag sets the comment of a property, and when set
+// on a blank field (_ struct{} `nebula_comment:"..."`) the comment of the tag or edge itself.
+//
+// The schema name is the name of the struct type.
+func ReflectStructSchema(v any) (StructSchema, error) {
+	if v == nil {
+		return StructSchema{}, fmt.Errorf("struct is nil")
+	}
+
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return StructSchema{}, fmt.Errorf("%v is not a struct", t)
+	}
+
+	schema := StructSchema{
+		Name: t.Name(),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+
+		if structField.Name == "_" {
+			if comment := structField.Tag.Get(NEBULA_COMMENT_GO_TAG); comment != "" {
+				schema.Comment = comment
+			}
+			continue
+		}
+
+		nebulaField := structField.Tag.Get(NEBULA_FIELD_GO_TAG)
+		if nebulaField == "" {
+			continue
+		}
+
+		fieldType := structField.Type
+		nullable := false
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+			nullable = true
+		}
+
+		propertyType := PropertyType(structField.Tag.Get(NEBULA_FIELD_TYPE_GO_TAG))
+		if propertyType == "" {
+			inferred, err := InferPropertyType(fieldType)
+			if err != nil {
+				return StructSchema{}, fmt.Errorf("field %s: %w", structField.Name, err)
+			}
+			propertyType = inferred
+		}
+
+		if ttl := structField.Tag.Get(NEBULA_TTL_GO_TAG); ttl != "" {
+			if schema.TTLCol != "" {
+				return StructSchema{}, fmt.Errorf("only one field can be tagged with `%s`, found %s and %s", NEBULA_TTL_GO_TAG, schema.TTLCol, nebulaField)
+			}
+			if !isTTLColType(propertyType) {
+				return StructSchema{}, fmt.Errorf("TTL column %s must be of an integer or timestamp type, got %s", nebulaField, propertyType)
+			}
+			ttlDuration, err := strconv.ParseUint(ttl, 10, 0)
+			if err != nil {
+				return StructSchema{}, fmt.Errorf("invalid `%s` value of field %s: %w", NEBULA_TTL_GO_TAG, structField.Name, err)
+			}
+			schema.TTLCol = nebulaField
+			schema.TTLDuration = uint(ttlDuration)
+		}
+
+		schema.Fields = append(schema.Fields, StructFieldSchema{
+			Name:     nebulaField,
+			Type:     propertyType,
+			Nullable: nullable,
+			Comment:  structField.Tag.Get(NEBULA_COMMENT_GO_TAG),
+		})
+	}
+
+	return schema, nil
+}
+
+// InferPropertyType returns the Nebula property type corresponding to the given go type.
+// It returns an error if there is no lossless mapping for the type.
+func InferPropertyType(t reflect.Type) (PropertyType, error) {
+	switch t {
+	case timeType:
+		return PropertyTypeDateTime, nil
+	case durationType:
+		return PropertyTypeDuration, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return PropertyTypeString, nil
+	case reflect.Int, reflect.Int64:
+		return PropertyTypeInt64, nil
+	case reflect.Int32:
+		return PropertyTypeInt32, nil
+	case reflect.Int16:
+		return PropertyTypeInt16, nil
+	case reflect.Int8:
+		return PropertyTypeInt8, nil
+	case reflect.Uint32:
+		return PropertyTypeInt64, nil
+	case reflect.Uint16:
+		return PropertyTypeInt32, nil
+	case reflect.Uint8:
+		return PropertyTypeInt16, nil
+	case reflect.Float64:
+		return PropertyTypeDouble, nil
+	case reflect.Float32:
+		return PropertyTypeFloat, nil
+	case reflect.Bool:
+		return PropertyTypeBoolean, nil
+	default:
+		return "", fmt.Errorf("cannot infer nebula property type from go type %v, use `%s` tag", t, NEBULA_FIELD_TYPE_GO_TAG)
+	}
+}
+
+func isTTLColType(propertyType PropertyType) bool {
+	switch propertyType {
+	case PropertyTypeInt, PropertyTypeInt64, PropertyTypeInt32, PropertyTypeInt16, PropertyTypeInt8, PropertyTypeTimestamp:
+		return true
+	default:
+		return false
+	}
+}
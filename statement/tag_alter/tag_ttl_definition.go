@@ -11,6 +11,7 @@ type TTLDefinition struct {
 }
 
 // NewTTLDefinition creates a new TTLDefinition with the specified duration and column name.
+// A zero duration with an empty column name removes the TTL, i.e. TTL_DURATION = 0, TTL_COL = "".
 func NewTTLDefinition(ttlDuration int64, ttlCol string) TTLDefinition {
 	return TTLDefinition{
 		ttlDuration: ttlDuration,
@@ -23,7 +24,7 @@ func GenerateTTlDefinitionStatement(ttl TTLDefinition) (string, error) {
 	if ttl.ttlDuration < 0 {
 		return "", fmt.Errorf("TTL duration is required to be non zero")
 	}
-	if ttl.ttlCol == "" {
+	if ttl.ttlCol == "" && ttl.ttlDuration != 0 {
 		return "", fmt.Errorf("TTL column name is required")
 	}
 	
@@ -14,6 +14,7 @@ type CreateTagStatement struct {
 	ttlDuration uint          // optional
 	ttlCol      string        // optional
 	ifNotExists bool          // optional
+	comment     string        // optional
 }
 
 // TagProperty represents a property in a tag.
@@ -22,15 +23,33 @@ type TagProperty struct {
 	field    string
 	ttype    statement.PropertyType
 	nullable bool
+	comment  string
 }
 
 // NewTagProperty creates a new TagProperty with the given field name, property type, and nullable flag.
-func NewTagProperty(field string, propertyType statement.PropertyType, nullable bool) TagProperty {
-	return TagProperty{
+func NewTagProperty(field string, propertyType statement.PropertyType, nullable bool, options ...TagPropertyOption) TagProperty {
+	property := TagProperty{
 		field:    field,
 		ttype:    propertyType,
 		nullable: nullable,
 	}
+
+	// Apply all the functional options to configure the property.
+	for _, opt := range options {
+		opt(&property)
+	}
+
+	return property
+}
+
+// TagPropertyOption is a functional option for configuring a TagProperty.
+type TagPropertyOption func(*TagProperty)
+
+// WithPropertyComment sets the comment of the TagProperty to the provided value.
+func WithPropertyComment(comment string) func(*TagProperty) {
+	return func(prop *TagProperty) {
+		prop.comment = comment
+	}
 }
 
 // CreateTagStatementOption is a functional option for configuring a CreateTagStatement.
@@ -80,6 +99,61 @@ func WithProperties(properties []TagProperty) func(*CreateTagStatement) {
 	}
 }
 
+// WithComment sets the comment of the CreateTagStatement to the provided value.
+func WithComment(comment string) func(*CreateTagStatement) {
+	return func(stmt *CreateTagStatement) {
+		stmt.comment = comment
+	}
+}
+
+// GetName returns the name of the tag.
+func (s CreateTagStatement) GetName() string {
+	return s.name
+}
+
+// GetProperties returns the properties of the tag.
+func (s CreateTagStatement) GetProperties() []TagProperty {
+	return s.properties
+}
+
+// GetTtlDuration returns the TTL duration of the tag.
+func (s CreateTagStatement) GetTtlDuration() uint {
+	return s.ttlDuration
+}
+
+// GetTtlCol returns the TTL column of the tag.
+func (s CreateTagStatement) GetTtlCol() string {
+	return s.ttlCol
+}
+
+// GetComment returns the comment of the tag.
+func (s CreateTagStatement) GetComment() string {
+	return s.comment
+}
+
+// GetField returns the name of the property.
+func (p TagProperty) GetField() string {
+	return p.field
+}
+
+// GetType returns the type of the property, string when it is not set.
+func (p TagProperty) GetType() statement.PropertyType {
+	if p.ttype == "" {
+		return statement.PropertyTypeString
+	}
+	return p.ttype
+}
+
+// IsNullable returns whether the property is nullable.
+func (p TagProperty) IsNullable() bool {
+	return p.nullable
+}
+
+// GetComment returns the comment of the property.
+func (p TagProperty) GetComment() string {
+	return p.comment
+}
+
 // GenerateCreateTagStatement generates a string representation of the CreateTagStatement.
 // The function checks if the TTL column exists in the properties and returns an error if it doesn't.
 // Otherwise, it returns a string representation of the CreateTagStatement.
@@ -118,15 +192,22 @@ func GenerateCreateTagStatement(tag CreateTagStatement) (string, error) {
 		sb.WriteString(t)
 		sb.WriteString(" ")
 		sb.WriteString(n)
+		if field.comment != "" {
+			sb.WriteString(fmt.Sprintf(` COMMENT '%s'`, field.comment))
+		}
 	}
 
+	sb.WriteString(")")
 	if tag.ttlCol != "" {
-		sb.WriteString(") ")
-		sb.WriteString(fmt.Sprintf(`TTL_DURATION = %d, TTL_COL = "%s"`, tag.ttlDuration, tag.ttlCol))
-		sb.WriteString(";")
-	} else {
-		sb.WriteString(");")
+		sb.WriteString(fmt.Sprintf(` TTL_DURATION = %d, TTL_COL = "%s"`, tag.ttlDuration, tag.ttlCol))
 	}
+	if tag.comment != "" {
+		if tag.ttlCol != "" {
+			sb.WriteString(",")
+		}
+		sb.WriteString(fmt.Sprintf(` COMMENT = '%s'`, tag.comment))
+	}
+	sb.WriteString(";")
 
 	return sb.String(), nil
 }
@@ -145,3 +226,37 @@ func isTTLColValid(ttlCol string, properties []TagProperty) error {
 
 	return fmt.Errorf("TTL column %s does not exist in the fields", ttlCol)
 }
+
+// SchemaFromStruct reflects the given struct, or pointer to struct, into a CreateTagStatement.
+// The tag name is taken from the GetTagName() method when the struct implements it,
+// otherwise the name of the struct type is used.
+//
+// The properties, TTL and comments are read from the struct tags as described in
+// statement.ReflectStructSchema. Additional options are applied after the reflected ones,
+// so they can override them, e.g. WithIfNotExists(true).
+func SchemaFromStruct(v any, options ...CreateTagStatementOption) (CreateTagStatement, error) {
+	schema, err := statement.ReflectStructSchema(v)
+	if err != nil {
+		return CreateTagStatement{}, err
+	}
+
+	name := schema.Name
+	if named, ok := v.(interface{ GetTagName() string }); ok {
+		name = named.GetTagName()
+	}
+
+	properties := make([]TagProperty, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		properties = append(properties, NewTagProperty(field.Name, field.Type, field.Nullable, WithPropertyComment(field.Comment)))
+	}
+
+	reflectedOptions := []CreateTagStatementOption{
+		WithProperties(properties),
+		WithComment(schema.Comment),
+	}
+	if schema.TTLCol != "" {
+		reflectedOptions = append(reflectedOptions, WithTtlCol(schema.TTLCol), WithTtlDuration(schema.TTLDuration))
+	}
+
+	return NewCreateTagStatement(name, append(reflectedOptions, options...)...), nil
+}
@@ -33,6 +33,13 @@ func GetTestCasesForGenerateEdgeAlterTypeDefinitionAddStatement() []TestCaseEdge
 			Given:       edge_alter.NewAlterTypeAddDefinition("prop1", statement.PropertyTypeString),
 			Expected:    `ADD (prop1 string)`,
 		},
+		{
+			Description: "An add statement with not null and comment",
+			Given: edge_alter.NewAlterTypeAddDefinition("prop1", statement.PropertyTypeString,
+				edge_alter.WithAlterTypeAddNotNullable(true),
+				edge_alter.WithAlterTypeAddComment("the first property")),
+			Expected: `ADD (prop1 string NOT NULL COMMENT 'the first property')`,
+		},
 		{
 			Description:   "A error case for empty property name",
 			Given:         edge_alter.NewAlterTypeAddDefinition("", statement.PropertyTypeString),
@@ -49,6 +56,13 @@ func GetTestCasesForGenerateEdgeAlterTypeDefinitionChangeStatement() []TestCaseE
 			Given:       edge_alter.NewAlterTypeChangeDefinition("prop1", statement.PropertyTypeString),
 			Expected:    `CHANGE (prop1 string)`,
 		},
+		{
+			Description: "A change statement with not null and comment",
+			Given: edge_alter.NewAlterTypeChangeDefinition("prop1", statement.PropertyTypeInt64,
+				edge_alter.WithAlterTypeChangeNotNullable(true),
+				edge_alter.WithAlterTypeChangeComment("the first property")),
+			Expected: `CHANGE (prop1 int64 NOT NULL COMMENT 'the first property')`,
+		},
 		{
 			Description:   "A error case for empty property name",
 			Given:         edge_alter.NewAlterTypeChangeDefinition("", statement.PropertyTypeString),
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_struct_insert"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateInsertEdgeStructStatement(t *testing.T) {
+	testCases := GetTestCasesForGenerateInsertEdgeStructStatement()
+	for _, testcase := range testCases {
+		actual, err := edge_struct_insert.GenerateInsertEdgeStatement(testcase.GivenEdgesArray)
+
+		if err != nil {
+			if !testcase.IsErrExpected {
+				t.Errorf("For %s, expected no error, got %v", testcase.Description, err)
+			}
+			continue
+		}
+
+		if testcase.IsErrExpected {
+			t.Errorf("For %s, expected error, got none", testcase.Description)
+		}
+
+		if !reflect.DeepEqual(actual, testcase.Expected) {
+			t.Errorf("For Case: %s "+
+				"\n Given: %+v, arr len: %d "+
+				"\n Expected: %s, len: %d"+
+				"\n Got: %s, len: %d",
+				testcase.Description,
+				testcase.GivenEdgesArray, len(testcase.GivenEdgesArray),
+				testcase.Expected, len(testcase.Expected),
+				actual, len(actual))
+		}
+	}
+}
+
+func TestGenerateBatchedInsertEdgeStructStatements(t *testing.T) {
+	testCases := GetTestCasesForGenerateBatchedInsertEdgeStructStatements()
+	for _, testcase := range testCases {
+		actual, err := edge_struct_insert.GenerateBatchedInsertEdgeStatements(testcase.GivenEdgesArray, testcase.GivenBatchSize)
+
+		if err != nil {
+			if !testcase.IsErrExpected {
+				t.Errorf("For %s, expected no error, got %v", testcase.Description, err)
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(actual, testcase.Expected) {
+			t.Errorf("For Case: %s "+
+				"\n Given: %v, batchSize: %d "+
+				"\n Expected: %s, len: %d"+
+				"\n Got: %s, len: %d",
+				testcase.Description,
+				testcase.GivenEdgesArray, testcase.GivenBatchSize,
+				testcase.Expected, len(testcase.Expected),
+				actual, len(actual))
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_struct_insert"
+)
+
+type FollowV1 struct {
+	Src        *string  `nebula_src:"true"`
+	Dst        *string  `nebula_dst:"true"`
+	Rank       *int64   `nebula_rank:"true"`
+	f_degree   *float64 `nebula_field:"f_degree"`
+	f_since    *string  `nebula_field:"f_since" nebula_field_type:"date"`
+	f_at       *string  `nebula_field:"f_at" nebula_field_type:"datetime"`
+	f_geo      *string  `nebula_field:"f_geo" nebula_field_type:"geography"`
+	f_duration *string  `nebula_field:"f_duration" nebula_field_type:"duration"`
+}
+
+func (e *FollowV1) GetEdgeTypeName() string {
+	return "FollowV1"
+}
+
+func (e *FollowV1) InsertIfNotExists() bool {
+	return false
+}
+
+type FollowV2 struct {
+	Src      int64  `nebula_src:"true"`
+	Dst      int64  `nebula_dst:"true"`
+	f_bool   bool   `nebula_field:"f_bool"`
+	f_string string `nebula_field:"f_string"`
+	f_time   string `nebula_field:"f_time" nebula_field_type:"time"`
+}
+
+func (e *FollowV2) GetEdgeTypeName() string {
+	return "FollowV2"
+}
+
+func (e *FollowV2) InsertIfNotExists() bool {
+	return true
+}
+
+var (
+	srcVid      = "1001"
+	dstVid      = "1002"
+	edgeRank    = int64(7)
+	fDegree     = 0.95
+	fSince      = "2020-01-01"
+	fAtDatetime = "2017-03-04T22:30:40.003000[Asia/Shanghai]"
+)
+
+type TestCaseGenerateInsertEdgeStructStatement struct {
+	Description     string
+	GivenEdgesArray []edge_struct_insert.IInsertableEdge
+	Expected        string
+	IsErrExpected   bool
+}
+
+type TestCaseGenerateBatchedInsertEdgeStructStatements struct {
+	Description     string
+	GivenEdgesArray []edge_struct_insert.IInsertableEdge
+	GivenBatchSize  int
+	Expected        []string
+	IsErrExpected   bool
+}
+
+func GetTestCasesForGenerateInsertEdgeStructStatement() []TestCaseGenerateInsertEdgeStructStatement {
+	return []TestCaseGenerateInsertEdgeStructStatement{
+		{
+			Description: "Given Struct with all reference fields, expect insert script containing all fields",
+			GivenEdgesArray: []edge_struct_insert.IInsertableEdge{
+				&FollowV1{
+					Src:        &srcVid,
+					Dst:        &dstVid,
+					Rank:       &edgeRank,
+					f_degree:   &fDegree,
+					f_since:    &fSince,
+					f_at:       &fAtDatetime,
+					f_geo:      &fGeo,
+					f_duration: &fDuration,
+				},
+			},
+			Expected:      `INSERT EDGE FollowV1 (f_at, f_degree, f_duration, f_geo, f_since) VALUES "1001"->"1002"@7:(datetime("2017-03-04T22:30:40.003000[Asia/Shanghai]"), 0.95, duration({years: 12, days: 14, hours: 99, minutes: 12}), ST_GeogFromText("POINT(1 1)"), date("2020-01-01"));`,
+			IsErrExpected: false,
+		},
+		{
+			Description: "Given Struct without rank and with nil fields, expect rank 0 and only non-nil fields",
+			GivenEdgesArray: []edge_struct_insert.IInsertableEdge{
+				&FollowV1{Src: &srcVid, Dst: &dstVid, f_degree: &fDegree},
+			},
+			Expected:      `INSERT EDGE FollowV1 (f_degree) VALUES "1001"->"1002"@0:(0.95);`,
+			IsErrExpected: false,
+		},
+		{
+			Description: "Given consecutive edges of the same type and fields, expect a single multi-value insert script",
+			GivenEdgesArray: []edge_struct_insert.IInsertableEdge{
+				&FollowV2{Src: 1, Dst: 2, f_bool: true, f_string: "a", f_time: "14:30:00"},
+				&FollowV2{Src: 2, Dst: 3, f_bool: false, f_string: "b", f_time: "15:30:00"},
+			},
+			Expected:      `INSERT EDGE IF NOT EXISTS FollowV2 (f_bool, f_string, f_time) VALUES 1->2@0:(true, "a", time("14:30:00")), 2->3@0:(false, "b", time("15:30:00"));`,
+			IsErrExpected: false,
+		},
+		{
+			Description: "Given edges of different types, expect one insert script per edge type",
+			GivenEdgesArray: []edge_struct_insert.IInsertableEdge{
+				&FollowV1{Src: &srcVid, Dst: &dstVid, f_degree: &fDegree},
+				&FollowV1{Src: &dstVid, Dst: &srcVid, f_degree: &fDegree},
+				&FollowV2{Src: 1, Dst: 2, f_bool: true, f_string: "a", f_time: "14:30:00"},
+			},
+			Expected:      `INSERT EDGE FollowV1 (f_degree) VALUES "1001"->"1002"@0:(0.95), "1002"->"1001"@0:(0.95); INSERT EDGE IF NOT EXISTS FollowV2 (f_bool, f_string, f_time) VALUES 1->2@0:(true, "a", time("14:30:00"));`,
+			IsErrExpected: false,
+		},
+		{
+			Description: "Given Struct with nil destination, return error",
+			GivenEdgesArray: []edge_struct_insert.IInsertableEdge{
+				&FollowV1{Src: &srcVid},
+			},
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:     "Given no edges, return error",
+			GivenEdgesArray: []edge_struct_insert.IInsertableEdge{},
+			Expected:        "",
+			IsErrExpected:   true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateBatchedInsertEdgeStructStatements() []TestCaseGenerateBatchedInsertEdgeStructStatements {
+	edges := []edge_struct_insert.IInsertableEdge{
+		&FollowV2{Src: 1, Dst: 2, f_bool: true, f_string: "a", f_time: "14:30:00"},
+		&FollowV2{Src: 2, Dst: 3, f_bool: true, f_string: "b", f_time: "14:30:00"},
+		&FollowV2{Src: 3, Dst: 4, f_bool: true, f_string: "c", f_time: "14:30:00"},
+	}
+
+	return []TestCaseGenerateBatchedInsertEdgeStructStatements{
+		{
+			Description:     "Given batch size is 2 and 3 edges, expect 2 insert scripts",
+			GivenEdgesArray: edges,
+			GivenBatchSize:  2,
+			Expected: []string{
+				`INSERT EDGE IF NOT EXISTS FollowV2 (f_bool, f_string, f_time) VALUES 1->2@0:(true, "a", time("14:30:00")), 2->3@0:(true, "b", time("14:30:00"));`,
+				`INSERT EDGE IF NOT EXISTS FollowV2 (f_bool, f_string, f_time) VALUES 3->4@0:(true, "c", time("14:30:00"));`,
+			},
+			IsErrExpected: false,
+		},
+		{
+			Description:     "Given batch size is 0, return error",
+			GivenEdgesArray: edges,
+			GivenBatchSize:  0,
+			Expected:        nil,
+			IsErrExpected:   true,
+		},
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/nebula-contrib/nebula-sirius/statement/edge_delete"
 	"github.com/nebula-contrib/nebula-sirius/statement/edge_insert"
 	"github.com/nebula-contrib/nebula-sirius/statement/edge_upsert"
+	"time"
 )
 
 type TestCaseGenerateInsertEdgeStatement[TVidType string | int64] struct {
@@ -61,6 +62,26 @@ func GetTestCasesForGenerateInsertEdgeStatementWhereVidString() []TestCaseGenera
 			Expected:      `INSERT EDGE IF NOT EXISTS Friend (key1,key2) VALUES "John"->"Alive"@100:("strval1",121);`,
 			IsErrExpected: false,
 		},
+		{
+			Description: "An insert edge statement with typed date, datetime, geography, duration and NULL properties",
+			Given: edge_insert.NewInsertEdgeStatement[string]("John", "Alive", "Friend",
+				edge_insert.WithProperties[string](map[string]interface{}{
+					"since":    statement.Date(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+					"at":       time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC),
+					"location": statement.Geo("POINT(1 1)"),
+					"lasts":    90 * time.Minute,
+					"note":     nil,
+				})),
+			Expected:      `INSERT EDGE Friend (at,lasts,location,note,since) VALUES "John"->"Alive"@0:(datetime("2020-01-02T03:04:05.000006"),duration({hours: 1, minutes: 30}),ST_GeogFromText("POINT(1 1)"),NULL,date("2020-01-02"));`,
+			IsErrExpected: false,
+		},
+		{
+			Description: "An insert edge statement with unsupported property type",
+			Given: edge_insert.NewInsertEdgeStatement[string]("John", "Alive", "Friend",
+				edge_insert.WithProperties[string](map[string]interface{}{"key1": []string{"a"}})),
+			Expected:      "",
+			IsErrExpected: true,
+		},
 	}
 }
 
@@ -436,6 +457,20 @@ func GetTestCasesForGenerateEdgeAlterStatement() []TestCaseGenerateEdgeAlterStat
 				}),
 			Expected: `ALTER EDGE tag1 DROP (prop1), DROP (prop2);`,
 		},
+		{
+			Description: "An edge alter statement with only a ttl definition removing the ttl",
+			Given: edge_alter.NewAlterEdgeStatement("edge1", nil,
+				edge_alter.WithTtlDefinitions([]edge_alter.TTLDefinition{
+					edge_alter.NewTTLDefinition(0, ""),
+				})),
+			Expected: `ALTER EDGE edge1 TTL_DURATION = 0, TTL_COL = "";`,
+		},
+		{
+			Description:   "An error case without alter, ttl definitions nor comment",
+			Given:         edge_alter.NewAlterEdgeStatement("edge1", nil),
+			Expected:      "",
+			IsErrExpected: true,
+		},
 		{
 			Description: "A tag alter statement with add, change and drop definitions",
 			Given: edge_alter.NewAlterEdgeStatement("tag1",
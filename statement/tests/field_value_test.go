@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"testing"
+)
+
+func TestEncodeNebulaFieldValue(t *testing.T) {
+	testCases := GetTestCasesForEncodeNebulaFieldValue()
+	for _, testcase := range testCases {
+		actual, err := statement.EncodeNebulaFieldValue(testcase.Given)
+
+		if err != nil {
+			if !testcase.IsErrExpected {
+				t.Errorf("For %s, expected no error, got %v", testcase.Description, err)
+			}
+			continue
+		}
+
+		if testcase.IsErrExpected {
+			t.Errorf("For %s, expected error, got none", testcase.Description)
+		}
+
+		if actual != testcase.Expected {
+			t.Errorf("For Case: %s "+
+				"\n Given: %+v "+
+				"\n Expected: %s"+
+				"\n Got: %s",
+				testcase.Description,
+				testcase.Given,
+				testcase.Expected,
+				actual)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"time"
+)
+
+type status string
+
+type TestCaseEncodeNebulaFieldValue struct {
+	Description   string
+	Given         any
+	Expected      string
+	IsErrExpected bool
+}
+
+func GetTestCasesForEncodeNebulaFieldValue() []TestCaseEncodeNebulaFieldValue {
+	at := time.Date(2017, 3, 4, 22, 30, 40, 3000000, time.UTC)
+	str := "text"
+	var nilStr *string
+	var nilTime *time.Time
+	var nilGeo *statement.GeoValue
+
+	return []TestCaseEncodeNebulaFieldValue{
+		{Description: "string", Given: "text", Expected: `"text"`},
+		{Description: "int", Given: 42, Expected: `42`},
+		{Description: "float", Given: 3.5, Expected: `3.5`},
+		{Description: "bool", Given: true, Expected: `true`},
+		{Description: "nil", Given: nil, Expected: `NULL`},
+		{Description: "Null", Given: statement.Null, Expected: `NULL`},
+		{Description: "pointer to string", Given: &str, Expected: `"text"`},
+		{Description: "nil pointer to string", Given: nilStr, Expected: `NULL`},
+		{Description: "nil pointer to time", Given: nilTime, Expected: `NULL`},
+		{Description: "nil pointer to GeoValue", Given: nilGeo, Expected: `NULL`},
+		{Description: "pointer to time", Given: &at, Expected: `datetime("2017-03-04T22:30:40.003000")`},
+		{Description: "named string type", Given: status("active"), Expected: `"active"`},
+		{Description: "time.Time", Given: at, Expected: `datetime("2017-03-04T22:30:40.003000")`},
+		{Description: "time.Duration", Given: 26*time.Hour + 3*time.Second + 5*time.Microsecond, Expected: `duration({hours: 26, seconds: 3, microseconds: 5})`},
+		{Description: "zero time.Duration", Given: time.Duration(0), Expected: `duration({seconds: 0})`},
+		{Description: "Date", Given: statement.Date(at), Expected: `date("2017-03-04")`},
+		{Description: "Time", Given: statement.Time(at), Expected: `time("22:30:40.003000")`},
+		{Description: "DateTime", Given: statement.DateTime(at), Expected: `datetime("2017-03-04T22:30:40.003000")`},
+		{Description: "Timestamp", Given: statement.Timestamp(at), Expected: `timestamp(1488666640)`},
+		{Description: "Geo", Given: statement.Geo("LINESTRING(0 0, 1 1)"), Expected: `ST_GeogFromText("LINESTRING(0 0, 1 1)")`},
+		{Description: "empty Geo", Given: statement.Geo(""), IsErrExpected: true},
+		{Description: "DurationValue", Given: statement.DurationValue{Years: 12, Days: 14, Hours: 99, Minutes: 12}, Expected: `duration({years: 12, days: 14, hours: 99, minutes: 12})`},
+		{Description: "Expr", Given: statement.Expr("now()"), Expected: `now()`},
+		{Description: "empty Expr", Given: statement.Expr(""), IsErrExpected: true},
+		{Description: "unsupported type", Given: struct{}{}, IsErrExpected: true},
+	}
+}
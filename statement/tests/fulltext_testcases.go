@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement/fulltext_index_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/fulltext_index_drop"
+	"github.com/nebula-contrib/nebula-sirius/statement/listener"
+	"github.com/nebula-contrib/nebula-sirius/statement/text_service"
+)
+
+type TestCaseGenerateCreateFullTextIndexStatement struct {
+	Description   string
+	Given         fulltext_index_create.CreateFullTextIndexStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateDropFullTextIndexStatement struct {
+	Description   string
+	Given         fulltext_index_drop.DropFullTextIndexStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateSignInTextServiceStatement struct {
+	Description   string
+	Given         text_service.SignInTextServiceStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateAddListenerStatement struct {
+	Description   string
+	Given         listener.AddListenerStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+func GetTestCasesForGenerateCreateFullTextIndexStatement() []TestCaseGenerateCreateFullTextIndexStatement {
+	return []TestCaseGenerateCreateFullTextIndexStatement{
+		{
+			Description: "A full-text tag index on one property",
+			Given:       fulltext_index_create.NewCreateFullTextTagIndexStatement("nebula_account_name", "account", []string{"name"}),
+			Expected:    `CREATE FULLTEXT TAG INDEX nebula_account_name ON account(name);`,
+		},
+		{
+			Description: "A full-text edge index on two properties with an analyzer",
+			Given: fulltext_index_create.NewCreateFullTextEdgeIndexStatement("nebula_transfer_memo", "transfer", []string{"memo", "reference"},
+				fulltext_index_create.WithAnalyzer("standard")),
+			Expected: `CREATE FULLTEXT EDGE INDEX nebula_transfer_memo ON transfer(memo, reference) ANALYZER="standard";`,
+		},
+		{
+			Description:   "An error case without properties",
+			Given:         fulltext_index_create.NewCreateFullTextTagIndexStatement("nebula_account_name", "account", nil),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with empty tag name",
+			Given:         fulltext_index_create.NewCreateFullTextTagIndexStatement("nebula_account_name", "", []string{"name"}),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with duplicate properties",
+			Given:         fulltext_index_create.NewCreateFullTextTagIndexStatement("nebula_account_name", "account", []string{"name", "name"}),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateDropFullTextIndexStatement() []TestCaseGenerateDropFullTextIndexStatement {
+	return []TestCaseGenerateDropFullTextIndexStatement{
+		{
+			Description: "A simple drop full-text index statement",
+			Given:       fulltext_index_drop.NewDropFullTextIndexStatement("nebula_account_name"),
+			Expected:    `DROP FULLTEXT INDEX nebula_account_name;`,
+		},
+		{
+			Description:   "An error case with empty index name",
+			Given:         fulltext_index_drop.NewDropFullTextIndexStatement(""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateSignInTextServiceStatement() []TestCaseGenerateSignInTextServiceStatement {
+	return []TestCaseGenerateSignInTextServiceStatement{
+		{
+			Description: "A single HTTP client without credentials",
+			Given:       text_service.NewSignInTextServiceStatement(text_service.NewTextServiceClient("192.168.8.100", 9200)),
+			Expected:    `SIGN IN TEXT SERVICE (192.168.8.100:9200, HTTP);`,
+		},
+		{
+			Description: "Two clients, one of them HTTPS with credentials",
+			Given: text_service.NewSignInTextServiceStatement(
+				text_service.NewTextServiceClient("192.168.8.100", 9200),
+				text_service.NewTextServiceClient("192.168.8.101", 9200,
+					text_service.WithProtocol(text_service.ProtocolHTTPS),
+					text_service.WithCredentials("elastic", "secret"))),
+			Expected: `SIGN IN TEXT SERVICE (192.168.8.100:9200, HTTP), (192.168.8.101:9200, HTTPS, "elastic", "secret");`,
+		},
+		{
+			Description:   "An error case without clients",
+			Given:         text_service.NewSignInTextServiceStatement(),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with an invalid port",
+			Given:         text_service.NewSignInTextServiceStatement(text_service.NewTextServiceClient("192.168.8.100", 0)),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description: "An error case with an unsupported protocol",
+			Given: text_service.NewSignInTextServiceStatement(text_service.NewTextServiceClient("192.168.8.100", 9200,
+				text_service.WithProtocol("FTP"))),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description: "An error case with a password but no username",
+			Given: text_service.NewSignInTextServiceStatement(text_service.NewTextServiceClient("192.168.8.100", 9200,
+				text_service.WithCredentials("", "secret"))),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateAddListenerStatement() []TestCaseGenerateAddListenerStatement {
+	return []TestCaseGenerateAddListenerStatement{
+		{
+			Description: "A single listener",
+			Given:       listener.NewAddListenerStatement("192.168.8.100:9789"),
+			Expected:    `ADD LISTENER ELASTICSEARCH 192.168.8.100:9789;`,
+		},
+		{
+			Description: "Two listeners",
+			Given:       listener.NewAddListenerStatement("192.168.8.100:9789", "192.168.8.101:9789"),
+			Expected:    `ADD LISTENER ELASTICSEARCH 192.168.8.100:9789, 192.168.8.101:9789;`,
+		},
+		{
+			Description:   "An error case without listeners",
+			Given:         listener.NewAddListenerStatement(),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with a host without port",
+			Given:         listener.NewAddListenerStatement("192.168.8.100"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with duplicate hosts",
+			Given:         listener.NewAddListenerStatement("192.168.8.100:9789", "192.168.8.100:9789"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement/index_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/index_drop"
+	"github.com/nebula-contrib/nebula-sirius/statement/index_rebuild"
+	"github.com/nebula-contrib/nebula-sirius/statement/index_status"
+)
+
+type TestCaseGenerateCreateIndexStatement struct {
+	Description   string
+	Given         index_create.CreateIndexStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateDropIndexStatement struct {
+	Description   string
+	Given         index_drop.DropIndexStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateRebuildIndexStatement struct {
+	Description   string
+	Given         index_rebuild.RebuildIndexStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateShowIndexStatusStatement struct {
+	Description   string
+	Given         index_status.ShowIndexStatusStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+func GetTestCasesForGenerateCreateIndexStatement() []TestCaseGenerateCreateIndexStatement {
+	return []TestCaseGenerateCreateIndexStatement{
+		{
+			Description: "A tag index without properties",
+			Given:       index_create.NewCreateTagIndexStatement("account_index", "account"),
+			Expected:    `CREATE TAG INDEX account_index ON account ();`,
+		},
+		{
+			Description: "A tag index on a string property with a prefix length and IfNotExists",
+			Given: index_create.NewCreateTagIndexStatement("account_name", "account",
+				index_create.WithIfNotExists(true),
+				index_create.WithFields([]index_create.IndexField{
+					index_create.NewPrefixIndexField("name", 10),
+				})),
+			Expected: `CREATE TAG INDEX IF NOT EXISTS account_name ON account (name(10));`,
+		},
+		{
+			Description: "A composite tag index with a comment",
+			Given: index_create.NewCreateTagIndexStatement("account_name_age", "account",
+				index_create.WithFields([]index_create.IndexField{
+					index_create.NewPrefixIndexField("name", 10),
+					index_create.NewIndexField("age"),
+				}),
+				index_create.WithComment("accounts by name and age")),
+			Expected: `CREATE TAG INDEX account_name_age ON account (name(10), age) COMMENT = 'accounts by name and age';`,
+		},
+		{
+			Description: "An edge index on a double property",
+			Given: index_create.NewCreateEdgeIndexStatement("transfer_amount", "transfer",
+				index_create.WithFields([]index_create.IndexField{
+					index_create.NewIndexField("amount"),
+				})),
+			Expected: `CREATE EDGE INDEX transfer_amount ON transfer (amount);`,
+		},
+		{
+			Description:   "An error case with empty index name",
+			Given:         index_create.NewCreateTagIndexStatement("", "account"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with empty edge name",
+			Given:         index_create.NewCreateEdgeIndexStatement("transfer_index", ""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description: "An error case with duplicate properties",
+			Given: index_create.NewCreateTagIndexStatement("account_name", "account",
+				index_create.WithFields([]index_create.IndexField{
+					index_create.NewPrefixIndexField("name", 10),
+					index_create.NewIndexField("name"),
+				})),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description: "An error case with a negative prefix length",
+			Given: index_create.NewCreateTagIndexStatement("account_name", "account",
+				index_create.WithFields([]index_create.IndexField{
+					index_create.NewPrefixIndexField("name", -1),
+				})),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateDropIndexStatement() []TestCaseGenerateDropIndexStatement {
+	return []TestCaseGenerateDropIndexStatement{
+		{
+			Description: "A simple drop tag index statement",
+			Given:       index_drop.NewDropTagIndexStatement("account_name"),
+			Expected:    `DROP TAG INDEX account_name;`,
+		},
+		{
+			Description: "A drop edge index statement with IfExists",
+			Given:       index_drop.NewDropEdgeIndexStatement("transfer_amount", index_drop.WithIfExists(true)),
+			Expected:    `DROP EDGE INDEX IF EXISTS transfer_amount;`,
+		},
+		{
+			Description:   "An error case with empty index name",
+			Given:         index_drop.NewDropTagIndexStatement(""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateRebuildIndexStatement() []TestCaseGenerateRebuildIndexStatement {
+	return []TestCaseGenerateRebuildIndexStatement{
+		{
+			Description: "A rebuild of all the tag indexes",
+			Given:       index_rebuild.NewRebuildTagIndexStatement(),
+			Expected:    `REBUILD TAG INDEX;`,
+		},
+		{
+			Description: "A rebuild of two edge indexes",
+			Given:       index_rebuild.NewRebuildEdgeIndexStatement("transfer_amount", "transfer_index"),
+			Expected:    `REBUILD EDGE INDEX transfer_amount, transfer_index;`,
+		},
+		{
+			Description:   "An error case with an empty index name",
+			Given:         index_rebuild.NewRebuildTagIndexStatement("account_name", ""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with duplicate index names",
+			Given:         index_rebuild.NewRebuildTagIndexStatement("account_name", "account_name"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateShowIndexStatusStatement() []TestCaseGenerateShowIndexStatusStatement {
+	return []TestCaseGenerateShowIndexStatusStatement{
+		{
+			Description: "The status of the tag indexes",
+			Given:       index_status.NewShowTagIndexStatusStatement(),
+			Expected:    `SHOW TAG INDEX STATUS;`,
+		},
+		{
+			Description: "The status of the edge indexes",
+			Given:       index_status.NewShowEdgeIndexStatusStatement(),
+			Expected:    `SHOW EDGE INDEX STATUS;`,
+		},
+		{
+			Description:   "An error case with a zero value statement",
+			Given:         index_status.ShowIndexStatusStatement{},
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
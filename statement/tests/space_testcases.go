@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement/space_alter"
+	"github.com/nebula-contrib/nebula-sirius/statement/space_clear"
+	"github.com/nebula-contrib/nebula-sirius/statement/space_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/space_drop"
+)
+
+type TestCaseGenerateCreateSpaceStatement struct {
+	Description   string
+	Given         space_create.CreateSpaceStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateCreateSpaceAsStatement struct {
+	Description   string
+	Given         space_create.CreateSpaceAsStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateDropSpaceStatement struct {
+	Description   string
+	Given         space_drop.DropSpaceStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateClearSpaceStatement struct {
+	Description   string
+	Given         space_clear.ClearSpaceStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateAlterSpaceStatement struct {
+	Description   string
+	Given         space_alter.AlterSpaceStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+func GetTestCasesForGenerateCreateSpaceStatement() []TestCaseGenerateCreateSpaceStatement {
+	return []TestCaseGenerateCreateSpaceStatement{
+		{
+			Description: "A simple create space statement with fixed string vid type",
+			Given:       space_create.NewCreateSpaceStatement("bank", space_create.VidTypeFixedString(30)),
+			Expected:    `CREATE SPACE bank (vid_type = FIXED_STRING(30));`,
+		},
+		{
+			Description: "A create space statement with int64 vid type and IfNotExists",
+			Given: space_create.NewCreateSpaceStatement("bank", space_create.VidTypeInt64,
+				space_create.WithIfNotExists(true)),
+			Expected: `CREATE SPACE IF NOT EXISTS bank (vid_type = INT64);`,
+		},
+		{
+			Description: "A create space statement with all options",
+			Given: space_create.NewCreateSpaceStatement("bank", space_create.VidTypeFixedString(32),
+				space_create.WithIfNotExists(true),
+				space_create.WithPartitionNum(10),
+				space_create.WithReplicaFactor(3),
+				space_create.WithCharset("utf8"),
+				space_create.WithCollate("utf8_bin"),
+				space_create.WithComment("bank accounts")),
+			Expected: `CREATE SPACE IF NOT EXISTS bank (partition_num = 10, replica_factor = 3, charset = utf8, collate = utf8_bin, vid_type = FIXED_STRING(32)) COMMENT = 'bank accounts';`,
+		},
+		{
+			Description: "A create space statement with a lower case int vid type",
+			Given:       space_create.NewCreateSpaceStatement("bank", "int"),
+			Expected:    `CREATE SPACE bank (vid_type = int);`,
+		},
+		{
+			Description:   "An error case with empty space name",
+			Given:         space_create.NewCreateSpaceStatement("", space_create.VidTypeInt64),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with missing vid type",
+			Given:         space_create.NewCreateSpaceStatement("bank", ""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with zero length fixed string vid type",
+			Given:         space_create.NewCreateSpaceStatement("bank", space_create.VidTypeFixedString(0)),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with unsupported vid type",
+			Given:         space_create.NewCreateSpaceStatement("bank", "STRING"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description: "An error case with collate not belonging to the charset",
+			Given: space_create.NewCreateSpaceStatement("bank", space_create.VidTypeInt64,
+				space_create.WithCharset("utf8"),
+				space_create.WithCollate("latin1_bin")),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateCreateSpaceAsStatement() []TestCaseGenerateCreateSpaceAsStatement {
+	return []TestCaseGenerateCreateSpaceAsStatement{
+		{
+			Description: "A simple create space as statement",
+			Given:       space_create.NewCreateSpaceAsStatement("bank_copy", "bank"),
+			Expected:    `CREATE SPACE bank_copy AS bank;`,
+		},
+		{
+			Description: "A create space as statement with IfNotExists",
+			Given:       space_create.NewCreateSpaceAsStatement("bank_copy", "bank", space_create.WithAsIfNotExists(true)),
+			Expected:    `CREATE SPACE IF NOT EXISTS bank_copy AS bank;`,
+		},
+		{
+			Description:   "An error case with empty source space name",
+			Given:         space_create.NewCreateSpaceAsStatement("bank_copy", ""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case cloning a space into itself",
+			Given:         space_create.NewCreateSpaceAsStatement("bank", "bank"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateDropSpaceStatement() []TestCaseGenerateDropSpaceStatement {
+	return []TestCaseGenerateDropSpaceStatement{
+		{
+			Description: "A simple drop space statement without IfExists",
+			Given:       space_drop.NewDropSpaceStatement("bank"),
+			Expected:    `DROP SPACE bank;`,
+		},
+		{
+			Description: "A simple drop space statement with IfExists",
+			Given:       space_drop.NewDropSpaceStatement("bank", space_drop.WithIfExists(true)),
+			Expected:    `DROP SPACE IF EXISTS bank;`,
+		},
+		{
+			Description:   "An error case with empty space name",
+			Given:         space_drop.NewDropSpaceStatement(""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateClearSpaceStatement() []TestCaseGenerateClearSpaceStatement {
+	return []TestCaseGenerateClearSpaceStatement{
+		{
+			Description: "A simple clear space statement without IfExists",
+			Given:       space_clear.NewClearSpaceStatement("bank"),
+			Expected:    `CLEAR SPACE bank;`,
+		},
+		{
+			Description: "A simple clear space statement with IfExists",
+			Given:       space_clear.NewClearSpaceStatement("bank", space_clear.WithIfExists(true)),
+			Expected:    `CLEAR SPACE IF EXISTS bank;`,
+		},
+		{
+			Description:   "An error case with empty space name",
+			Given:         space_clear.NewClearSpaceStatement(""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateAlterSpaceStatement() []TestCaseGenerateAlterSpaceStatement {
+	return []TestCaseGenerateAlterSpaceStatement{
+		{
+			Description: "An alter space statement adding a zone",
+			Given:       space_alter.NewAlterSpaceStatement("bank", space_alter.WithAddZones([]string{"zone_1"})),
+			Expected:    `ALTER SPACE bank ADD ZONE zone_1;`,
+		},
+		{
+			Description: "An alter space statement adding two zones",
+			Given:       space_alter.NewAlterSpaceStatement("bank", space_alter.WithAddZones([]string{"zone_1", "zone_2"})),
+			Expected:    `ALTER SPACE bank ADD ZONE zone_1, zone_2;`,
+		},
+		{
+			Description:   "An error case without zones",
+			Given:         space_alter.NewAlterSpaceStatement("bank"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with duplicate zones",
+			Given:         space_alter.NewAlterSpaceStatement("bank", space_alter.WithAddZones([]string{"zone_1", "zone_1"})),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with empty space name",
+			Given:         space_alter.NewAlterSpaceStatement("", space_alter.WithAddZones([]string{"zone_1"})),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
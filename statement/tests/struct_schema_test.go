@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement/edge_create"
+	"github.com/nebula-contrib/nebula-sirius/statement/tag_create"
+	"testing"
+)
+
+func TestTagSchemaFromStruct(t *testing.T) {
+	testCases := GetTestCasesForTagSchemaFromStruct()
+	for _, testcase := range testCases {
+		stmt, err := tag_create.SchemaFromStruct(testcase.Given)
+		if err != nil {
+			if !testcase.IsErrExpected {
+				t.Errorf("For %s, expected no error, got %v", testcase.Description, err)
+			}
+			continue
+		}
+
+		if testcase.IsErrExpected {
+			t.Errorf("For %s, expected error, got none", testcase.Description)
+			continue
+		}
+
+		actual, err := tag_create.GenerateCreateTagStatement(stmt)
+		if err != nil {
+			t.Errorf("For %s, expected no error, got %v", testcase.Description, err)
+			continue
+		}
+
+		if actual != testcase.Expected {
+			t.Errorf("For Case: %s "+
+				"\n Expected: %s"+
+				"\n Got:      %s",
+				testcase.Description,
+				testcase.Expected,
+				actual)
+		}
+	}
+}
+
+func TestEdgeSchemaFromStruct(t *testing.T) {
+	testCases := GetTestCasesForEdgeSchemaFromStruct()
+	for _, testcase := range testCases {
+		stmt, err := edge_create.SchemaFromStruct(testcase.Given, edge_create.WithIfNotExists(true))
+		if err != nil {
+			if !testcase.IsErrExpected {
+				t.Errorf("For %s, expected no error, got %v", testcase.Description, err)
+			}
+			continue
+		}
+
+		if testcase.IsErrExpected {
+			t.Errorf("For %s, expected error, got none", testcase.Description)
+			continue
+		}
+
+		actual, err := edge_create.GenerateCreateEdgeStatement(stmt)
+		if err != nil {
+			t.Errorf("For %s, expected no error, got %v", testcase.Description, err)
+			continue
+		}
+
+		if actual != testcase.Expected {
+			t.Errorf("For Case: %s "+
+				"\n Expected: %s"+
+				"\n Got:      %s",
+				testcase.Description,
+				testcase.Expected,
+				actual)
+		}
+	}
+}
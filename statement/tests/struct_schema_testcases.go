@@ -0,0 +1,115 @@
+package tests
+
+import "time"
+
+type AccountSchema struct {
+	_         struct{}   `nebula_comment:"user accounts"`
+	Vid       string     `nebula_vid:"true"`
+	Name      string     `nebula_field:"name" nebula_comment:"display name"`
+	Email     *string    `nebula_field:"email"`
+	Age       int64      `nebula_field:"age"`
+	Level     int8       `nebula_field:"level"`
+	Score     float64    `nebula_field:"score"`
+	Ratio     *float32   `nebula_field:"ratio"`
+	Active    bool       `nebula_field:"active"`
+	CreatedAt time.Time  `nebula_field:"created_at"`
+	DeletedAt *time.Time `nebula_field:"deleted_at"`
+	Birthday  string     `nebula_field:"birthday" nebula_field_type:"date"`
+	Code      string     `nebula_field:"code" nebula_field_type:"fixed_string(8)"`
+	ExpiresAt int64      `nebula_field:"expires_at" nebula_ttl:"86400"`
+	internal  string
+}
+
+func (a *AccountSchema) GetTagName() string {
+	return "account"
+}
+
+type TransferSchema struct {
+	Src     string        `nebula_src:"true"`
+	Dst     string        `nebula_dst:"true"`
+	Amount  float64       `nebula_field:"amount"`
+	Note    *string       `nebula_field:"note" nebula_comment:"free text"`
+	Took    time.Duration `nebula_field:"took"`
+	Created int64         `nebula_field:"created" nebula_field_type:"timestamp" nebula_ttl:"3600"`
+}
+
+func (t *TransferSchema) GetEdgeTypeName() string {
+	return "transfer"
+}
+
+type PlainSchema struct {
+	Name string `nebula_field:"name"`
+}
+
+type UnsupportedSchema struct {
+	Tags []string `nebula_field:"tags"`
+}
+
+type InvalidTTLSchema struct {
+	Name string `nebula_field:"name" nebula_ttl:"100"`
+}
+
+type DuplicateTTLSchema struct {
+	A int64 `nebula_field:"a" nebula_ttl:"100"`
+	B int64 `nebula_field:"b" nebula_ttl:"200"`
+}
+
+type TestCaseSchemaFromStruct struct {
+	Description   string
+	Given         any
+	Expected      string
+	IsErrExpected bool
+}
+
+func GetTestCasesForTagSchemaFromStruct() []TestCaseSchemaFromStruct {
+	return []TestCaseSchemaFromStruct{
+		{
+			Description:   "Given a struct implementing GetTagName, expect inferred types, nullability, TTL and comments",
+			Given:         &AccountSchema{},
+			Expected:      `CREATE TAG account (name string NOT NULL COMMENT 'display name', email string NULL, age int64 NOT NULL, level int8 NOT NULL, score double NOT NULL, ratio float NULL, active bool NOT NULL, created_at datetime NOT NULL, deleted_at datetime NULL, birthday date NOT NULL, code fixed_string(8) NOT NULL, expires_at int64 NOT NULL) TTL_DURATION = 86400, TTL_COL = "expires_at", COMMENT = 'user accounts';`,
+			IsErrExpected: false,
+		},
+		{
+			Description:   "Given a plain struct value, expect the struct type name as tag name",
+			Given:         PlainSchema{},
+			Expected:      `CREATE TAG PlainSchema (name string NOT NULL);`,
+			IsErrExpected: false,
+		},
+		{
+			Description:   "Given a field with a go type that cannot be inferred, return error",
+			Given:         UnsupportedSchema{},
+			IsErrExpected: true,
+		},
+		{
+			Description:   "Given a TTL column of string type, return error",
+			Given:         InvalidTTLSchema{},
+			IsErrExpected: true,
+		},
+		{
+			Description:   "Given two TTL columns, return error",
+			Given:         DuplicateTTLSchema{},
+			IsErrExpected: true,
+		},
+		{
+			Description:   "Given a non struct, return error",
+			Given:         "account",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForEdgeSchemaFromStruct() []TestCaseSchemaFromStruct {
+	return []TestCaseSchemaFromStruct{
+		{
+			Description:   "Given a struct implementing GetEdgeTypeName, expect inferred types, nullability, TTL and comments",
+			Given:         &TransferSchema{},
+			Expected:      `CREATE EDGE IF NOT EXISTS transfer (amount double NOT NULL, note string NULL COMMENT 'free text', took duration NOT NULL, created timestamp NOT NULL) TTL_DURATION = 3600, TTL_COL = "created";`,
+			IsErrExpected: false,
+		},
+		{
+			Description:   "Given a field with a go type that cannot be inferred, return error",
+			Given:         &UnsupportedSchema{},
+			IsErrExpected: true,
+		},
+	}
+}
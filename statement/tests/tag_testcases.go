@@ -96,6 +96,17 @@ func GetTestCasesForGenerateCreateTagStatement() []TestCaseGenerateCreateTagStat
 			Expected:      `CREATE TAG IF NOT EXISTS account (name string NOT NULL, email string NULL, phone string NULL, created_at timestamp NULL) TTL_DURATION = 100, TTL_COL = "created_at";`,
 			IsErrExpected: false,
 		},
+		{
+			Description: "A create tag statement with property and tag comments",
+			Given: tag_create.NewCreateTagStatement("account",
+				tag_create.WithComment("user accounts"),
+				tag_create.WithProperties([]tag_create.TagProperty{
+					tag_create.NewTagProperty("name", statement.PropertyTypeString, false, tag_create.WithPropertyComment("display name")),
+					tag_create.NewTagProperty("email", statement.PropertyTypeString, true),
+				})),
+			Expected:      `CREATE TAG account (name string NOT NULL COMMENT 'display name', email string NULL) COMMENT = 'user accounts';`,
+			IsErrExpected: false,
+		},
 		{
 			Description: "A simple create tag statement with invalid ttl column",
 			Given: tag_create.NewCreateTagStatement("account",
@@ -229,6 +240,11 @@ func GetTestCasesForGenerateTagTTLDefinitionStatement() []TestCaseGenerateTagTTL
 			Expected:      "",
 			IsErrExpected: true,
 		},
+		{
+			Description: "A tag ttl definition removing the ttl",
+			Given:       tag_alter.NewTTLDefinition(0, ""),
+			Expected:    `TTL_DURATION = 0, TTL_COL = ""`,
+		},
 	}
 }
 
@@ -317,6 +333,20 @@ func GetTestCasesForGenerateTagAlterStatement() []TestCaseGenerateTagAlterStatem
 				}),
 			Expected: `ALTER TAG tag1 DROP (prop1), DROP (prop2);`,
 		},
+		{
+			Description: "A tag alter statement with only a ttl definition",
+			Given: tag_alter.NewAlterTagStatement("tag1", nil,
+				tag_alter.WithTtlDefinitions([]tag_alter.TTLDefinition{
+					tag_alter.NewTTLDefinition(100, "created_at"),
+				})),
+			Expected: `ALTER TAG tag1 TTL_DURATION = 100, TTL_COL = "created_at";`,
+		},
+		{
+			Description:   "An error case without alter, ttl definitions nor comment",
+			Given:         tag_alter.NewAlterTagStatement("tag1", nil),
+			Expected:      "",
+			IsErrExpected: true,
+		},
 		{
 			Description: "A tag alter statement with add, change and drop definitions",
 			Given: tag_alter.NewAlterTagStatement("tag1",
@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"github.com/nebula-contrib/nebula-sirius/statement/role"
+	"github.com/nebula-contrib/nebula-sirius/statement/user"
+)
+
+type TestCaseGenerateCreateUserStatement struct {
+	Description   string
+	Given         user.CreateUserStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateDropUserStatement struct {
+	Description   string
+	Given         user.DropUserStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateAlterUserStatement struct {
+	Description   string
+	Given         user.AlterUserStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateChangePasswordStatement struct {
+	Description   string
+	Given         user.ChangePasswordStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateGrantRoleStatement struct {
+	Description   string
+	Given         role.GrantRoleStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+type TestCaseGenerateRevokeRoleStatement struct {
+	Description   string
+	Given         role.RevokeRoleStatement
+	Expected      string
+	IsErrExpected bool
+}
+
+func GetTestCasesForGenerateCreateUserStatement() []TestCaseGenerateCreateUserStatement {
+	return []TestCaseGenerateCreateUserStatement{
+		{
+			Description: "A create user statement without password",
+			Given:       user.NewCreateUserStatement("tenant_1"),
+			Expected:    `CREATE USER tenant_1;`,
+		},
+		{
+			Description: "A create user statement with a password and IfNotExists",
+			Given:       user.NewCreateUserStatement("tenant_1", user.WithPassword("secret"), user.WithIfNotExists(true)),
+			Expected:    `CREATE USER IF NOT EXISTS tenant_1 WITH PASSWORD 'secret';`,
+		},
+		{
+			Description: "A create user statement with a password to escape",
+			Given:       user.NewCreateUserStatement("tenant_1", user.WithPassword(`it's\secret`)),
+			Expected:    `CREATE USER tenant_1 WITH PASSWORD 'it\'s\\secret';`,
+		},
+		{
+			Description:   "An error case with empty user name",
+			Given:         user.NewCreateUserStatement(""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateDropUserStatement() []TestCaseGenerateDropUserStatement {
+	return []TestCaseGenerateDropUserStatement{
+		{
+			Description: "A simple drop user statement",
+			Given:       user.NewDropUserStatement("tenant_1"),
+			Expected:    `DROP USER tenant_1;`,
+		},
+		{
+			Description: "A drop user statement with IfExists",
+			Given:       user.NewDropUserStatement("tenant_1", user.WithIfExists(true)),
+			Expected:    `DROP USER IF EXISTS tenant_1;`,
+		},
+		{
+			Description:   "An error case with empty user name",
+			Given:         user.NewDropUserStatement(""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateAlterUserStatement() []TestCaseGenerateAlterUserStatement {
+	return []TestCaseGenerateAlterUserStatement{
+		{
+			Description: "An alter user statement",
+			Given:       user.NewAlterUserStatement("tenant_1", "new_secret"),
+			Expected:    `ALTER USER tenant_1 WITH PASSWORD 'new_secret';`,
+		},
+		{
+			Description:   "An error case with empty password",
+			Given:         user.NewAlterUserStatement("tenant_1", ""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateChangePasswordStatement() []TestCaseGenerateChangePasswordStatement {
+	return []TestCaseGenerateChangePasswordStatement{
+		{
+			Description: "A change password statement",
+			Given:       user.NewChangePasswordStatement("tenant_1", "secret", "new_secret"),
+			Expected:    `CHANGE PASSWORD tenant_1 FROM 'secret' TO 'new_secret';`,
+		},
+		{
+			Description:   "An error case with empty old password",
+			Given:         user.NewChangePasswordStatement("tenant_1", "", "new_secret"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateGrantRoleStatement() []TestCaseGenerateGrantRoleStatement {
+	return []TestCaseGenerateGrantRoleStatement{
+		{
+			Description: "A grant role statement",
+			Given:       role.NewGrantRoleStatement(role.RoleDBA, "bank", "tenant_1"),
+			Expected:    `GRANT ROLE DBA ON bank TO tenant_1;`,
+		},
+		{
+			Description:   "An error case granting the GOD role",
+			Given:         role.NewGrantRoleStatement(role.RoleGod, "bank", "tenant_1"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with an unknown role",
+			Given:         role.NewGrantRoleStatement("OWNER", "bank", "tenant_1"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+		{
+			Description:   "An error case with empty space name",
+			Given:         role.NewGrantRoleStatement(role.RoleUser, "", "tenant_1"),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
+
+func GetTestCasesForGenerateRevokeRoleStatement() []TestCaseGenerateRevokeRoleStatement {
+	return []TestCaseGenerateRevokeRoleStatement{
+		{
+			Description: "A revoke role statement",
+			Given:       role.NewRevokeRoleStatement(role.RoleGuest, "bank", "tenant_1"),
+			Expected:    `REVOKE ROLE GUEST ON bank FROM tenant_1;`,
+		},
+		{
+			Description:   "An error case with empty user name",
+			Given:         role.NewRevokeRoleStatement(role.RoleGuest, "bank", ""),
+			Expected:      "",
+			IsErrExpected: true,
+		},
+	}
+}
@@ -61,3 +61,43 @@ func TestGenerateBatchedInsertVertexStatements(t *testing.T) {
 		}
 	}
 }
+
+// BenchmarkGenerateInsertVertexStatement compares the script generated for a batch of vertices
+// sharing one tag and field set (combined into a single multi-value INSERT) with a batch whose
+// field sets alternate, which falls back to one INSERT clause per vertex.
+func BenchmarkGenerateInsertVertexStatement(b *testing.B) {
+	const batchSize = 1000
+
+	combined := make([]vertex_insert.IInsertableVertex, 0, batchSize)
+	separate := make([]vertex_insert.IInsertableVertex, 0, batchSize)
+	for i := 0; i < batchSize; i++ {
+		combined = append(combined, &PersonV1{Vid: &vid, f_bool: &fBool, f_int64: &fInt64, f_string: &fString})
+		if i%2 == 0 {
+			separate = append(separate, &PersonV1{Vid: &vid, f_bool: &fBool, f_int64: &fInt64, f_string: &fString})
+		} else {
+			separate = append(separate, &PersonV1{Vid: &vid, f_bool: &fBool, f_int32: &fInt32, f_string: &fString})
+		}
+	}
+
+	benchmarks := []struct {
+		name     string
+		vertices []vertex_insert.IInsertableVertex
+	}{
+		{name: "multi-value", vertices: combined},
+		{name: "per-vertex", vertices: separate},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			var script string
+			for i := 0; i < b.N; i++ {
+				var err error
+				script, err = vertex_insert.GenerateInsertVertexStatement(bm.vertices)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(script)), "script-bytes")
+		})
+	}
+}
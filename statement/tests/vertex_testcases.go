@@ -338,6 +338,37 @@ func GetTestCasesForGenerateInsertVertexStatement() []TestCaseGenerateInsertVert
 			Expected:      `INSERT VERTEX PersonV1 (f_bool, f_duration, f_geo, f_geo_polygon) VALUES "4001":(true, duration({years: 12, days: 14, hours: 99, minutes: 12}), ST_GeogFromText("POINT(1 1)"), ST_GeogFromText("POLYGON((0 0, 0 1, 1 1, 1 0, 0 0))")); INSERT VERTEX PersonV1 (f_duration) VALUES "4001":(duration({years: 12, days: 14, hours: 99, minutes: 12}));`,
 			IsErrExpected: false,
 		},
+		{
+			Description: "Given consecutive vertices with the same tag and fields, expect a single multi-value insert script",
+			GivenVerticesArray: []vertex_insert.IInsertableVertex{
+				&PersonV2{Vid: "4001", f_bool: true, f_int64: 1},
+				&PersonV2{Vid: "4002", f_bool: false, f_int64: 2},
+				&PersonV2{Vid: "4003", f_bool: true, f_int64: 3},
+			},
+			Expected:      `INSERT VERTEX PersonV2 (f_bool, f_date, f_datetime, f_double, f_duration, f_fixed_string, f_geo, f_geo_linestring, f_geo_polygon, f_int16, f_int32, f_int64, f_int8, f_string, f_time, f_ts) VALUES "4001":(true, date(""), datetime(""), 0, duration(), "", ST_GeogFromText(""), ST_GeogFromText(""), ST_GeogFromText(""), 0, 0, 1, 0, "", time(""), timestamp("")), "4002":(false, date(""), datetime(""), 0, duration(), "", ST_GeogFromText(""), ST_GeogFromText(""), ST_GeogFromText(""), 0, 0, 2, 0, "", time(""), timestamp("")), "4003":(true, date(""), datetime(""), 0, duration(), "", ST_GeogFromText(""), ST_GeogFromText(""), ST_GeogFromText(""), 0, 0, 3, 0, "", time(""), timestamp(""));`,
+			IsErrExpected: false,
+		},
+		{
+			Description: "Given vertices with the same tag but interleaved field sets, expect only consecutive equal field sets to be combined",
+			GivenVerticesArray: []vertex_insert.IInsertableVertex{
+				&PersonV1{Vid: &vid, f_bool: &fBool},
+				&PersonV1{Vid: &vid, f_bool: &fBool},
+				&PersonV1{Vid: &vid, f_int8: &fInt8},
+				&PersonV1{Vid: &vid, f_bool: &fBool},
+			},
+			Expected:      `INSERT VERTEX PersonV1 (f_bool) VALUES "4001":(true), "4001":(true); INSERT VERTEX PersonV1 (f_int8) VALUES "4001":(127); INSERT VERTEX PersonV1 (f_bool) VALUES "4001":(true);`,
+			IsErrExpected: false,
+		},
+		{
+			Description: "Given consecutive vertices of different tags, expect one insert script per tag",
+			GivenVerticesArray: []vertex_insert.IInsertableVertex{
+				&PersonV3{Vid: &vid},
+				&PersonV3{Vid: &vid},
+				&PersonV1{Vid: &vid, f_int8: &fInt8},
+			},
+			Expected:      `INSERT VERTEX IF NOT EXISTS PersonV3 () VALUES "4001":(), "4001":(); INSERT VERTEX PersonV1 (f_int8) VALUES "4001":(127);`,
+			IsErrExpected: false,
+		},
 		{
 			Description: "Given Struct with no fields except vid field, expect insert script with no fields and insert if exists option",
 			GivenVerticesArray: []vertex_insert.IInsertableVertex{
@@ -0,0 +1,125 @@
+package text_service
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Protocol is the protocol used to connect to an Elasticsearch client.
+type Protocol string
+
+const (
+	ProtocolHTTP  Protocol = "HTTP"
+	ProtocolHTTPS Protocol = "HTTPS"
+)
+
+// SignInTextServiceStatement represents a SIGN IN TEXT SERVICE statement in a graph database.
+// It registers the Elasticsearch clients used by the full-text indexes, replacing the ones
+// registered before.
+type SignInTextServiceStatement struct {
+	clients []TextServiceClient // required
+}
+
+// TextServiceClient represents an Elasticsearch client of the text search service.
+type TextServiceClient struct {
+	host     string
+	port     int
+	protocol Protocol
+	username string
+	password string
+}
+
+// NewTextServiceClient creates a new TextServiceClient with the given address and options.
+// The client is connected to with HTTP unless another protocol is given.
+func NewTextServiceClient(host string, port int, options ...TextServiceClientOption) TextServiceClient {
+	client := TextServiceClient{
+		host:     host,
+		port:     port,
+		protocol: ProtocolHTTP,
+	}
+
+	// Apply all the functional options to configure the client.
+	for _, opt := range options {
+		opt(&client)
+	}
+
+	return client
+}
+
+// TextServiceClientOption is a functional option for configuring a TextServiceClient.
+type TextServiceClientOption func(*TextServiceClient)
+
+// WithProtocol sets the protocol of the TextServiceClient to the provided value.
+func WithProtocol(protocol Protocol) func(*TextServiceClient) {
+	return func(client *TextServiceClient) {
+		client.protocol = protocol
+	}
+}
+
+// WithCredentials sets the username and the password of the TextServiceClient.
+func WithCredentials(username, password string) func(*TextServiceClient) {
+	return func(client *TextServiceClient) {
+		client.username = username
+		client.password = password
+	}
+}
+
+// NewSignInTextServiceStatement creates a new SignInTextServiceStatement registering the given clients.
+//
+// Example usage:
+//
+//	```
+//	stmt := NewSignInTextServiceStatement(
+//		NewTextServiceClient("192.168.8.100", 9200),
+//		NewTextServiceClient("192.168.8.101", 9200, WithProtocol(ProtocolHTTPS), WithCredentials("elastic", "secret")))
+//	```
+func NewSignInTextServiceStatement(clients ...TextServiceClient) SignInTextServiceStatement {
+	return SignInTextServiceStatement{
+		clients: clients,
+	}
+}
+
+// GenerateSignInTextServiceStatement generates the SIGN IN TEXT SERVICE statement based on the provided
+// SignInTextServiceStatement. It returns an error if no client is given, or if a client has an empty host,
+// an invalid port, an unsupported protocol or a password without username.
+func GenerateSignInTextServiceStatement(service SignInTextServiceStatement) (string, error) {
+	if len(service.clients) == 0 {
+		return "", fmt.Errorf("at least one text service client is required")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("SIGN IN TEXT SERVICE ")
+	for i, client := range service.clients {
+		if client.host == "" {
+			return "", fmt.Errorf("text service client host cannot be empty")
+		}
+		if client.port <= 0 || client.port > 65535 {
+			return "", fmt.Errorf("invalid port %d of text service client %s", client.port, client.host)
+		}
+		if client.protocol != ProtocolHTTP && client.protocol != ProtocolHTTPS {
+			return "", fmt.Errorf("unsupported protocol %s of text service client %s", client.protocol, client.host)
+		}
+		if client.username == "" && client.password != "" {
+			return "", fmt.Errorf("text service client %s has a password but no username", client.host)
+		}
+
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(fmt.Sprintf("(%s:%d, %s", client.host, client.port, client.protocol))
+		if client.username != "" {
+			sb.WriteString(fmt.Sprintf(`, "%s", "%s"`, client.username, client.password))
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
+
+// GenerateSignOutTextServiceStatement generates the SIGN OUT TEXT SERVICE statement, unregistering all the
+// Elasticsearch clients.
+func GenerateSignOutTextServiceStatement() string {
+	return "SIGN OUT TEXT SERVICE;"
+}
@@ -0,0 +1,185 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CreateUserStatement represents a CREATE USER statement in a graph database.
+type CreateUserStatement struct {
+	name        string // required
+	password    string // optional
+	ifNotExists bool   // optional
+}
+
+// CreateUserStatementOption is a functional option for configuring a CreateUserStatement.
+// It takes a pointer to a CreateUserStatement as its argument.
+type CreateUserStatementOption func(*CreateUserStatement)
+
+// NewCreateUserStatement creates a new CreateUserStatement with the given options.
+// It applies each provided option to the statement before returning it.
+//
+// Example usage:
+//
+//	```
+//	stmt := NewCreateUserStatement("tenant_1", WithPassword("secret"), WithIfNotExists(true))
+//	```
+func NewCreateUserStatement(name string, options ...CreateUserStatementOption) CreateUserStatement {
+	statement := CreateUserStatement{
+		name: name,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithPassword sets the password of the CreateUserStatement to the provided value.
+func WithPassword(password string) func(*CreateUserStatement) {
+	return func(stmt *CreateUserStatement) {
+		stmt.password = password
+	}
+}
+
+// WithIfNotExists sets the ifNotExists flag of the CreateUserStatement to the provided value.
+func WithIfNotExists(ifNotExists bool) func(*CreateUserStatement) {
+	return func(stmt *CreateUserStatement) {
+		stmt.ifNotExists = ifNotExists
+	}
+}
+
+// GenerateCreateUserStatement generates the CREATE USER statement based on the provided CreateUserStatement.
+func GenerateCreateUserStatement(user CreateUserStatement) (string, error) {
+	if user.name == "" {
+		return "", fmt.Errorf("user name cannot be empty")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("CREATE USER ")
+	if user.ifNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+	sb.WriteString(user.name)
+	if user.password != "" {
+		sb.WriteString(" WITH PASSWORD ")
+		sb.WriteString(quotePassword(user.password))
+	}
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
+
+// DropUserStatement represents a DROP USER statement in a graph database.
+type DropUserStatement struct {
+	name     string // required
+	ifExists bool   // optional
+}
+
+// DropUserStatementOption is a functional option for configuring a DropUserStatement.
+// It takes a pointer to a DropUserStatement as its argument.
+type DropUserStatementOption func(*DropUserStatement)
+
+// NewDropUserStatement creates a new DropUserStatement with the given options.
+// It applies each provided option to the statement before returning it.
+func NewDropUserStatement(name string, options ...DropUserStatementOption) DropUserStatement {
+	statement := DropUserStatement{
+		name: name,
+	}
+
+	// Apply all the functional options to configure the statement.
+	for _, opt := range options {
+		opt(&statement)
+	}
+
+	return statement
+}
+
+// WithIfExists sets the ifExists flag of the DropUserStatement to the provided value.
+func WithIfExists(ifExists bool) func(*DropUserStatement) {
+	return func(stmt *DropUserStatement) {
+		stmt.ifExists = ifExists
+	}
+}
+
+// GenerateDropUserStatement generates the DROP USER statement based on the provided DropUserStatement.
+func GenerateDropUserStatement(user DropUserStatement) (string, error) {
+	if user.name == "" {
+		return "", fmt.Errorf("user name cannot be empty")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("DROP USER ")
+	if user.ifExists {
+		sb.WriteString("IF EXISTS ")
+	}
+	sb.WriteString(user.name)
+	sb.WriteString(";")
+
+	return sb.String(), nil
+}
+
+// AlterUserStatement represents an ALTER USER statement in a graph database, setting the password of a user.
+type AlterUserStatement struct {
+	name     string // required
+	password string // required
+}
+
+// NewAlterUserStatement creates a new AlterUserStatement setting the password of the given user.
+func NewAlterUserStatement(name string, password string) AlterUserStatement {
+	return AlterUserStatement{
+		name:     name,
+		password: password,
+	}
+}
+
+// GenerateAlterUserStatement generates the ALTER USER statement based on the provided AlterUserStatement.
+func GenerateAlterUserStatement(user AlterUserStatement) (string, error) {
+	if user.name == "" {
+		return "", fmt.Errorf("user name cannot be empty")
+	}
+	if user.password == "" {
+		return "", fmt.Errorf("password cannot be empty")
+	}
+
+	return fmt.Sprintf("ALTER USER %s WITH PASSWORD %s;", user.name, quotePassword(user.password)), nil
+}
+
+// ChangePasswordStatement represents a CHANGE PASSWORD statement in a graph database.
+// Unlike ALTER USER, it requires the current password and can be run by the user itself.
+type ChangePasswordStatement struct {
+	name        string // required
+	oldPassword string // required
+	newPassword string // required
+}
+
+// NewChangePasswordStatement creates a new ChangePasswordStatement of the given user.
+func NewChangePasswordStatement(name string, oldPassword string, newPassword string) ChangePasswordStatement {
+	return ChangePasswordStatement{
+		name:        name,
+		oldPassword: oldPassword,
+		newPassword: newPassword,
+	}
+}
+
+// GenerateChangePasswordStatement generates the CHANGE PASSWORD statement based on the provided ChangePasswordStatement.
+func GenerateChangePasswordStatement(user ChangePasswordStatement) (string, error) {
+	if user.name == "" {
+		return "", fmt.Errorf("user name cannot be empty")
+	}
+	if user.oldPassword == "" || user.newPassword == "" {
+		return "", fmt.Errorf("old and new passwords cannot be empty")
+	}
+
+	return fmt.Sprintf("CHANGE PASSWORD %s FROM %s TO %s;",
+		user.name, quotePassword(user.oldPassword), quotePassword(user.newPassword)), nil
+}
+
+// quotePassword returns the password as a single quoted nGQL string literal.
+func quotePassword(password string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(password) + "'"
+}
@@ -45,6 +45,11 @@ var cachedNebulaInfoPerStruct sync.Map
 // - geography
 // - duration
 // For other nebula field types, it will infer the golang type and convert it to the appropriate nebula type.
+//
+// Consecutive vertices sharing the same tag, the same set of non-nil fields and the same
+// IF NOT EXISTS option are combined into a single multi-value statement, e.g.
+// INSERT VERTEX tag (f1, f2) VALUES "v1":(...), "v2":(...). Fields are always listed in
+// alphabetical order, so the generated script is deterministic for a given input.
 // The function returns a string containing the INSERT VERTEX scripts separated by semicolons.
 // If an error occurs, the function returns an empty string and the error.
 func GenerateInsertVertexStatement(vertices []IInsertableVertex) (string, error) {
@@ -54,137 +59,111 @@ func GenerateInsertVertexStatement(vertices []IInsertableVertex) (string, error)
 
 	var sb strings.Builder
 
-	for i, vertex := range vertices {
-		// raise error if vertex is nil
-		if vertex == nil {
-			return "", fmt.Errorf("vertex is nil")
+	prevHeader := ""
+	for _, vertex := range vertices {
+		header, value, err := encodeVertex(vertex)
+		if err != nil {
+			return "", err
 		}
 
-		v := reflect.ValueOf(vertex)
+		// Same tag and same column set as the previous vertex, append it to the running VALUES list
+		if header == prevHeader {
+			sb.WriteString(", ")
+			sb.WriteString(value)
+			continue
+		}
 
-		if v.Elem().Kind() == reflect.Struct {
-			v = v.Elem()
-		} else {
-			return "", fmt.Errorf("vertex is not a struct: %v", v.Kind())
+		if prevHeader != "" {
+			sb.WriteString("; ")
 		}
+		sb.WriteString(header)
+		sb.WriteString(value)
+		prevHeader = header
+	}
 
-		// Get the type of the first vertex to extract the struct name
-		vertexType := v.Type()
+	sb.WriteString(";")
 
-		// Get the type of the first vertex to extract the struct name
-		vertexTypeName := vertex.GetTagName()
-		if vertex.InsertIfNotExists() {
-			sb.WriteString(fmt.Sprintf("INSERT VERTEX IF NOT EXISTS %s ", vertexTypeName))
-		} else {
-			sb.WriteString(fmt.Sprintf("INSERT VERTEX %s ", vertexTypeName))
-		}
+	return sb.String(), nil
+}
 
-		nebulaInfoPerStructt, err := readThroughCache(vertexTypeName, vertexType)
-		if err != nil {
-			return "", err
-		}
+// encodeVertex reflects over the given vertex and returns the statement header
+// (INSERT VERTEX ... (fields) VALUES ) and the encoded vid:(values) part separately,
+// so that vertices sharing the same header can be merged into one statement.
+func encodeVertex(vertex IInsertableVertex) (string, string, error) {
+	// raise error if vertex is nil
+	if vertex == nil {
+		return "", "", fmt.Errorf("vertex is nil")
+	}
 
-		nebulaFields := nebulaInfoPerStructt.NebulaFields
-		nebulaVidStructField := nebulaInfoPerStructt.VidStructField
-		nebulaFieldAndStructFieldMap := nebulaInfoPerStructt.NebulaFieldAndStructFieldMap
+	v := reflect.ValueOf(vertex)
 
-		availableNebulaFields := make([]string, 0)
-		for _, nebulaField := range nebulaFields {
-			structField := nebulaFieldAndStructFieldMap[nebulaField]
-			structFieldVal := reflect.Indirect(v).FieldByName(structField.Name)
+	if v.Elem().Kind() == reflect.Struct {
+		v = v.Elem()
+	} else {
+		return "", "", fmt.Errorf("vertex is not a struct: %v", v.Kind())
+	}
 
-			if structFieldVal.Kind() == reflect.Pointer {
-				structFieldVal = structFieldVal.Elem()
-			}
+	// Get the type of the vertex to extract the struct name
+	vertexType := v.Type()
 
-			if structFieldVal.IsValid() {
-				availableNebulaFields = append(availableNebulaFields, nebulaField)
-			}
-		}
+	var header strings.Builder
 
-		sb.WriteString("(" + strings.Join(availableNebulaFields, ", ") + ") VALUES ")
+	vertexTypeName := vertex.GetTagName()
+	if vertex.InsertIfNotExists() {
+		header.WriteString(fmt.Sprintf("INSERT VERTEX IF NOT EXISTS %s ", vertexTypeName))
+	} else {
+		header.WriteString(fmt.Sprintf("INSERT VERTEX %s ", vertexTypeName))
+	}
+
+	nebulaInfoPerStructt, err := readThroughCache(vertexTypeName, vertexType)
+	if err != nil {
+		return "", "", err
+	}
+
+	nebulaFields := nebulaInfoPerStructt.NebulaFields
+	nebulaVidStructField := nebulaInfoPerStructt.VidStructField
+	nebulaFieldAndStructFieldMap := nebulaInfoPerStructt.NebulaFieldAndStructFieldMap
 
-		vidField := reflect.Indirect(v).FieldByName(nebulaVidStructField.Name)
+	availableNebulaFields := make([]string, 0)
+	for _, nebulaField := range nebulaFields {
+		structField := nebulaFieldAndStructFieldMap[nebulaField]
+		structFieldVal := reflect.Indirect(v).FieldByName(structField.Name)
 
-		vidFieldValue := ""
-		if vidField.Kind() == reflect.Pointer {
-			vidField = vidField.Elem()
+		if structFieldVal.Kind() == reflect.Pointer {
+			structFieldVal = structFieldVal.Elem()
 		}
-		switch vidField.Kind() {
-		case reflect.String:
-			vidFieldValue = fmt.Sprintf(`"%v"`, vidField)
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			vidFieldValue = fmt.Sprintf("%v", vidField)
-		case reflect.Pointer:
-			vidFieldValue = fmt.Sprintf("%v", vidField.Elem())
-		default:
-			return "", fmt.Errorf("`%s` tagged field of struct is either nil or not supported", statement.VID_GO_TAG)
+
+		if structFieldVal.IsValid() {
+			availableNebulaFields = append(availableNebulaFields, nebulaField)
 		}
+	}
 
-		var values []string
-		for _, nebulaField := range availableNebulaFields {
-			structField, found := nebulaFieldAndStructFieldMap[nebulaField]
-			if !found {
-				return "", fmt.Errorf("field not found")
-			}
-
-			structFieldVal := reflect.Indirect(v).FieldByName(structField.Name)
-
-			if structFieldVal.Kind() == reflect.Pointer {
-				structFieldVal = structFieldVal.Elem()
-			}
-
-			switch structFieldVal.Kind() {
-			case reflect.String:
-				{
-					switch structField.Tag.Get(statement.NEBULA_FIELD_TYPE_GO_TAG) {
-					case string(statement.PropertyTypeDate):
-						//		 date("2025-02-15"),
-						values = append(values, fmt.Sprintf("date(\"%v\")", structFieldVal))
-					case string(statement.PropertyTypeTime):
-						//		  time("14:30:00"),
-						values = append(values, fmt.Sprintf("time(\"%v\")", structFieldVal))
-					case string(statement.PropertyTypeDateTime):
-						//        datetime("2017-03-04T22:30:40.003000[Asia/Shanghai]"),
-						values = append(values, fmt.Sprintf("datetime(\"%v\")", structFieldVal))
-					case string(statement.PropertyTypeTimestamp):
-						//        timestamp("1988-03-01T08:00:00"),
-						values = append(values, fmt.Sprintf("timestamp(\"%v\")", structFieldVal))
-					case string(statement.PropertyTypeGeography):
-						//        ST_GeogFromText("POINT(1 1)"),
-						values = append(values, fmt.Sprintf("ST_GeogFromText(\"%v\")", structFieldVal))
-					case string(statement.PropertyTypeDuration):
-						//        duration({years: 12, days: 14, hours: 99, minutes: 12})
-						values = append(values, fmt.Sprintf("duration(%v)", structFieldVal))
-					default:
-						values = append(values, fmt.Sprintf("\"%v\"", structFieldVal))
-					}
-				}
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				values = append(values, fmt.Sprintf("%v", structFieldVal))
-			case reflect.Float32, reflect.Float64:
-				values = append(values, fmt.Sprintf("%v", structFieldVal))
-			case reflect.Bool:
-				values = append(values, fmt.Sprintf("%v", structFieldVal))
-			default:
-				return "", fmt.Errorf("field type not supported: %v", structFieldVal.Kind())
-			}
+	header.WriteString("(" + strings.Join(availableNebulaFields, ", ") + ") VALUES ")
 
-		}
+	vidField := reflect.Indirect(v).FieldByName(nebulaVidStructField.Name)
 
-		sb.WriteString(fmt.Sprintf("%v:(%s)", vidFieldValue, strings.Join(values, ", ")))
+	vidFieldValue, err := statement.EncodeReflectedVidValue(statement.VID_GO_TAG, vidField)
+	if err != nil {
+		return "", "", err
+	}
 
-		if i < len(vertices)-1 {
-			sb.WriteString("; ")
+	var values []string
+	for _, nebulaField := range availableNebulaFields {
+		structField, found := nebulaFieldAndStructFieldMap[nebulaField]
+		if !found {
+			return "", "", fmt.Errorf("field not found")
 		}
 
-	}
+		structFieldVal := reflect.Indirect(v).FieldByName(structField.Name)
 
-	sb.WriteString(";")
+		value, err := statement.EncodeReflectedFieldValue(structField, structFieldVal)
+		if err != nil {
+			return "", "", err
+		}
+		values = append(values, value)
+	}
 
-	return sb.String(), nil
+	return header.String(), fmt.Sprintf("%v:(%s)", vidFieldValue, strings.Join(values, ", ")), nil
 }
 
 // GenerateBatchedInsertVertexStatements takes a slice of struct vertices and generates the corresponding
@@ -0,0 +1,126 @@
+package nebula_sirius
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"slices"
+)
+
+// SpaceStats are the statistics of a space computed by its last STATS job.
+type SpaceStats struct {
+	Status        meta.JobStatus   // status of the STATS job the statistics come from
+	TagVertices   map[string]int64 // number of vertices by tag
+	Edges         map[string]int64 // number of edges by edge type
+	TotalVertices int64
+	TotalEdges    int64
+
+	// PositivePartCorrelativity and NegativePartCorrelativity give, by partition, the proportion of its
+	// outgoing and incoming edges connected to each partition. They are empty when the server does
+	// not compute them.
+	PositivePartCorrelativity map[int32][]PartCorrelativity
+	NegativePartCorrelativity map[int32][]PartCorrelativity
+}
+
+// PartCorrelativity is the proportion of the edges of a partition connected to another partition.
+type PartCorrelativity struct {
+	PartID     int32
+	Proportion float64
+}
+
+// SpaceStatsOptions configures how GetSpaceStats reads the statistics.
+type SpaceStatsOptions struct {
+	refresh     bool         // optional
+	waitOptions []WaitOption // optional
+}
+
+// SpaceStatsOption is a functional option for configuring SpaceStatsOptions.
+type SpaceStatsOption func(*SpaceStatsOptions)
+
+// WithStatsRefresh submits a STATS job and waits for it with the given options before reading the
+// statistics, instead of reading the ones of the last STATS job.
+func WithStatsRefresh(waitOptions ...WaitOption) func(*SpaceStatsOptions) {
+	return func(opts *SpaceStatsOptions) {
+		opts.refresh = true
+		opts.waitOptions = waitOptions
+	}
+}
+
+// GetSpaceStats returns the vertex and edge counts of the space through the meta service, the
+// equivalent of SHOW STATS. It returns an error if no STATS job ever finished on the space, unless
+// WithStatsRefresh is given.
+func GetSpaceStats(ctx context.Context, metaClient meta.MetaService, spaceName string, options ...SpaceStatsOption) (*SpaceStats, error) {
+	opts := SpaceStatsOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	jobs := NewJobClient(metaClient, spaceName)
+	if opts.refresh {
+		jobID, err := jobs.Submit(ctx, meta.JobType_STATS)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := jobs.Wait(ctx, jobID, opts.waitOptions...); err != nil {
+			return nil, err
+		}
+	}
+
+	spaceID, err := jobs.getSpaceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := metaClient.GetStats(ctx, &meta.GetStatsReq{SpaceID: spaceID})
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetCode() == nebula.ErrorCode_E_STATS_NOT_FOUND {
+		return nil, fmt.Errorf("no statistics of space %s, a STATS job must be run first", spaceName)
+	}
+	if resp.GetCode() != nebula.ErrorCode_SUCCEEDED {
+		return nil, fmt.Errorf("failed to get statistics of space %s, error code: %s", spaceName, resp.GetCode())
+	}
+
+	if !resp.IsSetStats() {
+		return nil, fmt.Errorf("no statistics of space %s in the response", spaceName)
+	}
+
+	item := resp.GetStats()
+	stats := &SpaceStats{
+		Status:                    item.GetStatus(),
+		TagVertices:               make(map[string]int64, len(item.GetTagVertices())),
+		Edges:                     make(map[string]int64, len(item.GetEdges())),
+		TotalVertices:             item.GetSpaceVertices(),
+		TotalEdges:                item.GetSpaceEdges(),
+		PositivePartCorrelativity: partCorrelativity(item.GetPositivePartCorrelativity()),
+		NegativePartCorrelativity: partCorrelativity(item.GetNegativePartCorrelativity()),
+	}
+	for tag, count := range item.GetTagVertices() {
+		stats.TagVertices[tag] = count
+	}
+	for edge, count := range item.GetEdges() {
+		stats.Edges[edge] = count
+	}
+	return stats, nil
+}
+
+func partCorrelativity(correlativity map[nebula.PartitionID][]*meta.Correlativity) map[int32][]PartCorrelativity {
+	parts := make(map[int32][]PartCorrelativity, len(correlativity))
+	for partID, items := range correlativity {
+		related := make([]PartCorrelativity, 0, len(items))
+		for _, item := range items {
+			related = append(related, PartCorrelativity{
+				PartID:     int32(item.GetPartID()),
+				Proportion: item.GetProportion(),
+			})
+		}
+		slices.SortFunc(related, func(a, b PartCorrelativity) int {
+			return cmp.Compare(a.PartID, b.PartID)
+		})
+		parts[int32(partID)] = related
+	}
+	return parts
+}
@@ -0,0 +1,94 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func getStatsResp() *meta.GetStatsResp {
+	return &meta.GetStatsResp{
+		Code: nebula.ErrorCode_SUCCEEDED,
+		Stats: &meta.StatsItem{
+			TagVertices:   map[string]int64{"account": 90, "bank": 10},
+			Edges:         map[string]int64{"transfer": 250},
+			SpaceVertices: 100,
+			SpaceEdges:    250,
+			PositivePartCorrelativity: map[nebula.PartitionID][]*meta.Correlativity{
+				1: {{PartID: 2, Proportion: 0.25}, {PartID: 1, Proportion: 0.75}},
+			},
+			Status: meta.JobStatus_FINISHED,
+		},
+	}
+}
+
+func TestGetSpaceStats(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("GetStats", ctx, &meta.GetStatsReq{SpaceID: 1}).Return(getStatsResp(), nil).Once()
+
+	stats, err := GetSpaceStats(ctx, metaClient, "bank")
+	assert.NoError(t, err)
+	assert.Equal(t, &SpaceStats{
+		Status:        meta.JobStatus_FINISHED,
+		TagVertices:   map[string]int64{"account": 90, "bank": 10},
+		Edges:         map[string]int64{"transfer": 250},
+		TotalVertices: 100,
+		TotalEdges:    250,
+		PositivePartCorrelativity: map[int32][]PartCorrelativity{
+			1: {{PartID: 1, Proportion: 0.75}, {PartID: 2, Proportion: 0.25}},
+		},
+		NegativePartCorrelativity: map[int32][]PartCorrelativity{},
+	}, stats)
+}
+
+func TestGetSpaceStats_Refresh(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	jobID := int32(4)
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("RunAdminJob", ctx, &meta.AdminJobReq{SpaceID: 1, Op: meta.JobOp_ADD, Type: meta.JobType_STATS, Paras: [][]byte{}}).
+		Return(&meta.AdminJobResp{
+			Code:    nebula.ErrorCode_SUCCEEDED,
+			Result_: &meta.AdminJobResult_{JobID: &jobID},
+		}, nil).Once()
+	metaClient.On("RunAdminJob", ctx, showJobReq("4")).
+		Return(showJobResp(jobID, meta.JobType_STATS, meta.JobStatus_FINISHED), nil).Once()
+	metaClient.On("GetStats", ctx, &meta.GetStatsReq{SpaceID: 1}).Return(getStatsResp(), nil).Once()
+
+	stats, err := GetSpaceStats(ctx, metaClient, "bank", WithStatsRefresh(fastWait))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), stats.TotalVertices)
+	assert.Equal(t, int64(90), stats.TagVertices["account"])
+}
+
+func TestGetSpaceStats_NotFound(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("GetStats", ctx, &meta.GetStatsReq{SpaceID: 1}).
+		Return(&meta.GetStatsResp{Code: nebula.ErrorCode_E_STATS_NOT_FOUND}, nil).Once()
+
+	_, err := GetSpaceStats(ctx, metaClient, "bank")
+	assert.EqualError(t, err, "no statistics of space bank, a STATS job must be run first")
+}
+
+func TestGetSpaceStats_MissingStats(t *testing.T) {
+	ctx := context.Background()
+	metaClient := mocks.NewMetaService(t)
+
+	expectGetSpace(metaClient, ctx, "bank", 1)
+	metaClient.On("GetStats", ctx, &meta.GetStatsReq{SpaceID: 1}).
+		Return(&meta.GetStatsResp{Code: nebula.ErrorCode_SUCCEEDED}, nil).Once()
+
+	_, err := GetSpaceStats(ctx, metaClient, "bank")
+	assert.EqualError(t, err, "no statistics of space bank in the response")
+}
@@ -0,0 +1,176 @@
+package nebula_sirius
+
+import (
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"time"
+)
+
+// DurationWrapper is a duration value, in months, seconds and microseconds.
+type DurationWrapper struct {
+	duration *nebula.Duration
+}
+
+// GenResultSetWithTimezone generates the result set of the response like GenResultSet, with the times and
+// datetimes converted to the timezone of the server, as given by the AuthResponse of its session.
+func GenResultSetWithTimezone(resp *graph.ExecutionResponse, timezoneOffsetSeconds int32, timezoneName string) (*ResultSet, error) {
+	return genResultSet(resp, timezoneInfo{timezoneOffsetSeconds, []byte(timezoneName)})
+}
+
+// ToTime returns the time in the timezone of the server, on January 1st, 1970 in that timezone.
+func (t TimeWrapper) ToTime() time.Time {
+	return t.In(t.timezoneInfo.location())
+}
+
+// In returns the time in the given location, on January 1st, 1970 in that location.
+// Times have no date, so the date is set after converting the clock, even when the conversion
+// crosses midnight, e.g. 02:00 UTC is 1970-01-01 18:00 in a location 8 hours behind UTC.
+func (t TimeWrapper) In(loc *time.Location) time.Time {
+	local := t.utcTime().In(loc)
+	return time.Date(1970, time.January, 1, local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), loc)
+}
+
+func (t TimeWrapper) utcTime() time.Time {
+	return time.Date(1970, time.January, 1,
+		int(t.getHour()), int(t.getMinute()), int(t.getSecond()), int(t.getMicrosec())*1000, time.UTC)
+}
+
+// ToTime returns the midnight of the date in UTC.
+func (d DateWrapper) ToTime() time.Time {
+	return d.In(time.UTC)
+}
+
+// In returns the midnight of the date in the given location. Dates have no timezone, so the day is kept.
+func (d DateWrapper) In(loc *time.Location) time.Time {
+	return time.Date(int(d.getYear()), time.Month(d.getMonth()), int(d.getDay()), 0, 0, 0, 0, loc)
+}
+
+// ToTime returns the datetime in the timezone of the server.
+func (dt DateTimeWrapper) ToTime() time.Time {
+	return dt.utcTime().In(dt.timezoneInfo.location())
+}
+
+// In returns the datetime in the given location.
+func (dt DateTimeWrapper) In(loc *time.Location) time.Time {
+	return dt.utcTime().In(loc)
+}
+
+func (dt DateTimeWrapper) utcTime() time.Time {
+	return time.Date(int(dt.getYear()), time.Month(dt.getMonth()), int(dt.getDay()),
+		int(dt.getHour()), int(dt.getMinute()), int(dt.getSecond()), int(dt.getMicrosec())*1000, time.UTC)
+}
+
+func genDurationWrapper(duration *nebula.Duration) (*DurationWrapper, error) {
+	if duration == nil {
+		return nil, fmt.Errorf("failed to generate duration: invalid duration")
+	}
+	return &DurationWrapper{duration: duration}, nil
+}
+
+// GetMonths returns the months of the duration.
+func (d DurationWrapper) GetMonths() int32 {
+	return d.duration.GetMonths()
+}
+
+// ToDuration returns the duration as a time.Duration, which fails for the durations counting months,
+// whose length depends on the time they are added to.
+func (d DurationWrapper) ToDuration() (time.Duration, error) {
+	if d.duration.GetMonths() != 0 {
+		return 0, fmt.Errorf("duration of %d months has no fixed length", d.duration.GetMonths())
+	}
+	return d.fixedDuration(), nil
+}
+
+// AddTo adds the duration to the time, the months first.
+func (d DurationWrapper) AddTo(t time.Time) time.Time {
+	return t.AddDate(0, int(d.duration.GetMonths()), 0).Add(d.fixedDuration())
+}
+
+func (d DurationWrapper) fixedDuration() time.Duration {
+	return time.Duration(d.duration.GetSeconds())*time.Second + time.Duration(d.duration.GetMicroseconds())*time.Microsecond
+}
+
+// AsGoTime converts a date, time or datetime value to a time.Time, in the timezone of the server for times and
+// datetimes, as by ToTime of DateWrapper, TimeWrapper and DateTimeWrapper.
+func (valWrap ValueWrapper) AsGoTime() (time.Time, error) {
+	switch {
+	case valWrap.value.IsSetDVal():
+		return DateWrapper{valWrap.value.GetDVal()}.ToTime(), nil
+	case valWrap.value.IsSetTVal():
+		return TimeWrapper{valWrap.value.GetTVal(), valWrap.timezoneInfo}.ToTime(), nil
+	case valWrap.value.IsSetDtVal():
+		return DateTimeWrapper{valWrap.value.GetDtVal(), valWrap.timezoneInfo}.ToTime(), nil
+	}
+	return time.Time{}, fmt.Errorf("failed to convert value %s to time.Time, value is not a date, time or datetime", valWrap.GetType())
+}
+
+// AsDurationWrapper converts the ValueWrapper to a DurationWrapper
+func (valWrap ValueWrapper) AsDurationWrapper() (*DurationWrapper, error) {
+	if valWrap.value.IsSetDuVal() {
+		return genDurationWrapper(valWrap.value.GetDuVal())
+	}
+	return nil, fmt.Errorf("failed to convert value %s to DurationWrapper", valWrap.GetType())
+}
+
+// NewDateTime converts the time into a datetime, in UTC as stored by the server.
+func NewDateTime(t time.Time) *nebula.DateTime {
+	t = t.UTC()
+	return &nebula.DateTime{
+		Year:     int16(t.Year()),
+		Month:    int8(t.Month()),
+		Day:      int8(t.Day()),
+		Hour:     int8(t.Hour()),
+		Minute:   int8(t.Minute()),
+		Sec:      int8(t.Second()),
+		Microsec: int32(t.Nanosecond() / 1000),
+	}
+}
+
+// NewDate converts the day of the time, in its location, into a date.
+func NewDate(t time.Time) *nebula.Date {
+	return &nebula.Date{
+		Year:  int16(t.Year()),
+		Month: int8(t.Month()),
+		Day:   int8(t.Day()),
+	}
+}
+
+// NewTime converts the time of day into a time, in UTC as stored by the server.
+func NewTime(t time.Time) *nebula.Time {
+	t = t.UTC()
+	return &nebula.Time{
+		Hour:     int8(t.Hour()),
+		Minute:   int8(t.Minute()),
+		Sec:      int8(t.Second()),
+		Microsec: int32(t.Nanosecond() / 1000),
+	}
+}
+
+// NewDuration converts the time.Duration into a duration, truncated to microseconds.
+func NewDuration(d time.Duration) *nebula.Duration {
+	return &nebula.Duration{
+		Seconds:      int64(d / time.Second),
+		Microseconds: int32(d % time.Second / time.Microsecond),
+	}
+}
+
+// NewDateTimeValue creates the datetime value of the time, e.g. for the parameters of ExecuteWithParameter.
+func NewDateTimeValue(t time.Time) *nebula.Value {
+	return &nebula.Value{DtVal: NewDateTime(t)}
+}
+
+// NewDateValue creates the date value of the day of the time, e.g. for the parameters of ExecuteWithParameter.
+func NewDateValue(t time.Time) *nebula.Value {
+	return &nebula.Value{DVal: NewDate(t)}
+}
+
+// NewTimeValue creates the time value of the time of day, e.g. for the parameters of ExecuteWithParameter.
+func NewTimeValue(t time.Time) *nebula.Value {
+	return &nebula.Value{TVal: NewTime(t)}
+}
+
+// NewDurationValue creates the duration value of the time.Duration, e.g. for the parameters of ExecuteWithParameter.
+func NewDurationValue(d time.Duration) *nebula.Value {
+	return &nebula.Value{DuVal: NewDuration(d)}
+}
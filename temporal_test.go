@@ -0,0 +1,136 @@
+package nebula_sirius
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemporalWrappers_ToTime(t *testing.T) {
+	shanghai := timezoneInfo{28800, []byte("CST")}
+	newYork := time.FixedZone("EST", -5*3600)
+
+	dateTime := DateTimeWrapper{&nebula.DateTime{Year: 2025, Month: 12, Day: 31, Hour: 20, Minute: 30, Sec: 5, Microsec: 123}, shanghai}
+	assert.Equal(t, "2026-01-01T04:30:05.000123+08:00", dateTime.ToTime().Format(time.RFC3339Nano))
+	assert.Equal(t, "2025-12-31T15:30:05.000123-05:00", dateTime.In(newYork).Format(time.RFC3339Nano))
+	assert.True(t, dateTime.ToTime().Equal(dateTime.In(time.UTC)))
+
+	tm := TimeWrapper{&nebula.Time{Hour: 20, Minute: 30, Sec: 5, Microsec: 500000}, shanghai}
+	assert.Equal(t, "1970-01-01T04:30:05.5+08:00", tm.ToTime().Format(time.RFC3339Nano))
+	assert.Equal(t, "1970-01-01T15:30:05.5-05:00", tm.In(newYork).Format(time.RFC3339Nano))
+	early := TimeWrapper{&nebula.Time{Hour: 2}, timezoneInfo{-8 * 3600, []byte("PST")}}
+	assert.Equal(t, "1970-01-01T18:00:00-08:00", early.ToTime().Format(time.RFC3339Nano))
+	assert.Equal(t, 1, early.ToTime().YearDay())
+
+	date := DateWrapper{&nebula.Date{Year: 2025, Month: 2, Day: 28}}
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), date.ToTime())
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, newYork), date.In(newYork))
+}
+
+func TestDurationWrapper(t *testing.T) {
+	duration := DurationWrapper{&nebula.Duration{Seconds: 3661, Microseconds: 500000}}
+	d, err := duration.ToDuration()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour+time.Minute+time.Second+500*time.Millisecond, d)
+
+	withMonths := DurationWrapper{&nebula.Duration{Seconds: 60, Months: 1}}
+	_, err = withMonths.ToDuration()
+	assert.EqualError(t, err, "duration of 1 months has no fixed length")
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 1, 0, 0, time.UTC), withMonths.AddTo(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, int32(1), withMonths.GetMonths())
+
+	value := ValueWrapper{&nebula.Value{DuVal: NewDuration(90*time.Second + 3*time.Microsecond)}, testTimezone}
+	wrapper, err := value.AsDurationWrapper()
+	assert.NoError(t, err)
+	d, err = wrapper.ToDuration()
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second+3*time.Microsecond, d)
+
+	_, err = ValueWrapper{setIVal(1), testTimezone}.AsDurationWrapper()
+	assert.EqualError(t, err, "failed to convert value int to DurationWrapper")
+}
+
+func TestValueWrapper_AsGoTime(t *testing.T) {
+	at := time.Date(2025, 3, 9, 23, 15, 30, 250000000, time.FixedZone("X", 3600))
+	tz := timezoneInfo{-7200, []byte("Y")}
+
+	got, err := ValueWrapper{NewDateTimeValue(at), tz}.AsGoTime()
+	assert.NoError(t, err)
+	assert.True(t, at.Equal(got))
+	_, gotOffset := got.Zone()
+	assert.Equal(t, -7200, gotOffset)
+
+	got, err = ValueWrapper{NewTimeValue(at), tz}.AsGoTime()
+	assert.NoError(t, err)
+	assert.Equal(t, "20:15:30.25", got.Format("15:04:05.999"))
+
+	got, err = ValueWrapper{NewDateValue(at), tz}.AsGoTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), got)
+
+	_, err = ValueWrapper{setIVal(1), tz}.AsGoTime()
+	assert.EqualError(t, err, "failed to convert value int to time.Time, value is not a date, time or datetime")
+}
+
+func TestNewDateTime(t *testing.T) {
+	at := time.Date(2025, 1, 1, 1, 2, 3, 4005000, time.FixedZone("X", 2*3600))
+	assert.Equal(t, &nebula.DateTime{Year: 2024, Month: 12, Day: 31, Hour: 23, Minute: 2, Sec: 3, Microsec: 4005}, NewDateTime(at))
+	assert.Equal(t, &nebula.Date{Year: 2025, Month: 1, Day: 1}, NewDate(at))
+	assert.Equal(t, &nebula.Time{Hour: 23, Minute: 2, Sec: 3, Microsec: 4005}, NewTime(at))
+	assert.Equal(t, &nebula.Duration{Seconds: -1, Microseconds: -500000}, NewDuration(-1500*time.Millisecond))
+}
+
+func TestSession_ResultSetTimezone(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	sessionID, offset := int64(1), int32(28800)
+	graphClient.On("Authenticate", ctx, []byte("root"), []byte("nebula")).Return(&graph.AuthResponse{
+		ErrorCode:             nebula.ErrorCode_SUCCEEDED,
+		SessionID:             &sessionID,
+		TimeZoneOffsetSeconds: &offset,
+		TimeZoneName:          []byte("+08:00"),
+	}, nil).Once()
+	resp := &graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("dt")},
+			Rows:        []*nebula.Row{{Values: []*nebula.Value{{DtVal: &nebula.DateTime{Year: 2025, Month: 1, Day: 1, Hour: 20}}}}},
+		},
+	}
+	graphClient.On("Execute", ctx, int64(1), []byte("RETURN datetime() AS dt;")).Return(resp, nil).Once()
+
+	session, err := NewSession(ctx, graphClient, "root", "nebula")
+	require.NoError(t, err)
+	resultSet, err := session.Execute(ctx, "RETURN datetime() AS dt;")
+	require.NoError(t, err)
+
+	record, err := resultSet.GetRowValuesByIndex(0)
+	require.NoError(t, err)
+	value, err := record.GetValueByIndex(0)
+	require.NoError(t, err)
+	dateTime, err := value.AsDateTime()
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-02T04:00:00+08:00", dateTime.ToTime().Format(time.RFC3339))
+
+	utcResultSet, err := GenResultSet(resp)
+	require.NoError(t, err)
+	record, _ = utcResultSet.GetRowValuesByIndex(0)
+	value, _ = record.GetValueByIndex(0)
+	got, err := value.AsGoTime()
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-01T20:00:00Z", got.Format(time.RFC3339))
+
+	tzResultSet, err := GenResultSetWithTimezone(resp, offset, "+08:00")
+	require.NoError(t, err)
+	record, _ = tzResultSet.GetRowValuesByIndex(0)
+	value, _ = record.GetValueByIndex(0)
+	got, err = value.AsGoTime()
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-02T04:00:00+08:00", got.Format(time.RFC3339))
+}
@@ -9,6 +9,8 @@
 
 package nebula_sirius
 
+import "time"
+
 type HostAddress struct {
 	Host string
 	Port int
@@ -18,3 +20,8 @@ type timezoneInfo struct {
 	offset int32
 	name   []byte
 }
+
+// location returns the fixed zone of the timezone of the server.
+func (tz timezoneInfo) location() *time.Location {
+	return time.FixedZone(string(tz.name), int(tz.offset))
+}
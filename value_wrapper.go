@@ -250,6 +250,14 @@ func (valWrap ValueWrapper) AsGeography() (*nebula.Geography, error) {
 	return nil, fmt.Errorf("failed to convert value %s to nebula.Geography, value is not an geography", valWrap.GetType())
 }
 
+// AsGeometry converts the ValueWrapper to a Geometry, a GeoPoint, a GeoLineString or a GeoPolygon
+func (valWrap ValueWrapper) AsGeometry() (Geometry, error) {
+	if valWrap.value.IsSetGgVal() {
+		return GeometryFromGeography(valWrap.value.GetGgVal())
+	}
+	return nil, fmt.Errorf("failed to convert value %s to Geometry, value is not an geography", valWrap.GetType())
+}
+
 // AsDuration converts the ValueWrapper to a DurationWrapper
 func (valWrap ValueWrapper) AsDuration() (*nebula.Duration, error) {
 	if valWrap.value.IsSetDuVal() {
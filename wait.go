@@ -0,0 +1,306 @@
+package nebula_sirius
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nebula-contrib/nebula-sirius/statement"
+	"strings"
+	"time"
+)
+
+// IExecutor executes nGQL statements, it is implemented by *Session.
+type IExecutor interface {
+	Execute(ctx context.Context, stmt string) (*ResultSet, error)
+}
+
+// ExecuteChecked executes the statement and turns a non-succeeded result set into an *ExecutionError,
+// for executors not doing it themselves.
+func ExecuteChecked(ctx context.Context, executor IExecutor, stmt string) (*ResultSet, error) {
+	rs, err := executor.Execute(ctx, stmt)
+	if err != nil {
+		return rs, err
+	}
+	if !rs.IsSucceed() {
+		return rs, &ExecutionError{
+			Stmt:      stmt,
+			ErrorCode: rs.GetErrorCode(),
+			ErrorMsg:  rs.GetErrorMsg(),
+		}
+	}
+	return rs, nil
+}
+
+// IndexType is the type of schema an index is built on, the statement.SchemaType taken by the index statement builders.
+type IndexType = statement.SchemaType
+
+const (
+	IndexTypeTag  = statement.SchemaTypeTag
+	IndexTypeEdge = statement.SchemaTypeEdge
+)
+
+// Default polling intervals of the WaitFor* helpers
+const (
+	DefaultWaitInitialInterval = 100 * time.Millisecond
+	DefaultWaitMaxInterval     = 2 * time.Second
+)
+
+// WaitOptions configures how the WaitFor* helpers poll the graph services.
+type WaitOptions struct {
+	initialInterval time.Duration // optional
+	maxInterval     time.Duration // optional
+	properties      []string      // optional
+}
+
+// WaitOption is a functional option for configuring WaitOptions.
+type WaitOption func(*WaitOptions)
+
+// WithWaitInterval sets the interval between two polls, doubled after every failed poll up to maxInterval.
+func WithWaitInterval(initialInterval, maxInterval time.Duration) func(*WaitOptions) {
+	return func(opts *WaitOptions) {
+		opts.initialInterval = initialInterval
+		opts.maxInterval = maxInterval
+	}
+}
+
+// WithWaitProperties sets the properties that must be visible on the tag or edge type as well,
+// e.g. the properties just added by an ALTER TAG statement.
+func WithWaitProperties(properties ...string) func(*WaitOptions) {
+	return func(opts *WaitOptions) {
+		opts.properties = properties
+	}
+}
+
+func newWaitOptions(options []WaitOption) WaitOptions {
+	opts := WaitOptions{
+		initialInterval: DefaultWaitInitialInterval,
+		maxInterval:     DefaultWaitMaxInterval,
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.initialInterval <= 0 {
+		opts.initialInterval = DefaultWaitInitialInterval
+	}
+	if opts.maxInterval < opts.initialInterval {
+		opts.maxInterval = opts.initialInterval
+	}
+	return opts
+}
+
+// WaitForSpace waits until the space is visible on the graph services of all the given executors.
+//
+// A schema change is applied by the meta service right away, but each graph service only picks it up
+// on its next heartbeat. Each executor is polled with an exponential backoff until it can switch to the
+// space, or until ctx is done. Executors are usually sessions opened on each of the graph services,
+// they are switched to the space as a side effect.
+func WaitForSpace(ctx context.Context, executors []IExecutor, spaceName string, options ...WaitOption) error {
+	return waitForSpace(ctx, executors, spaceName, newWaitOptions(options))
+}
+
+// WaitForTag waits until the tag, and the properties given with WithWaitProperties, are visible in the
+// space on the graph services of all the given executors. See WaitForSpace.
+func WaitForTag(ctx context.Context, executors []IExecutor, spaceName string, tagName string, options ...WaitOption) error {
+	if tagName == "" {
+		return fmt.Errorf("tag name cannot be empty")
+	}
+
+	opts := newWaitOptions(options)
+	vid, err := waitForSpaceVid(ctx, executors, spaceName, opts)
+	if err != nil {
+		return err
+	}
+
+	// FETCH is validated against the schema cached by the graph service, unlike DESCRIBE which asks the meta service
+	stmt := fmt.Sprintf("EXPLAIN FETCH PROP ON %s %s YIELD %s;", tagName, vid, yieldProperties(tagName, opts.properties, "id(vertex)"))
+	return waitOnEach(ctx, executors, opts, fmt.Sprintf("tag %s", tagName), stmt)
+}
+
+// WaitForEdge waits until the edge type, and the properties given with WithWaitProperties, are visible in
+// the space on the graph services of all the given executors. See WaitForSpace.
+func WaitForEdge(ctx context.Context, executors []IExecutor, spaceName string, edgeName string, options ...WaitOption) error {
+	if edgeName == "" {
+		return fmt.Errorf("edge name cannot be empty")
+	}
+
+	opts := newWaitOptions(options)
+	vid, err := waitForSpaceVid(ctx, executors, spaceName, opts)
+	if err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf("EXPLAIN FETCH PROP ON %s %s -> %s YIELD %s;", edgeName, vid, vid, yieldProperties(edgeName, opts.properties, "src(edge)"))
+	return waitOnEach(ctx, executors, opts, fmt.Sprintf("edge %s", edgeName), stmt)
+}
+
+// WaitForIndex waits until the tag or edge index is visible in the space on the graph services of all
+// the given executors, i.e. until a LOOKUP on the indexed tag or edge type can be planned. It does not wait
+// for the index to be rebuilt. See WaitForSpace.
+func WaitForIndex(ctx context.Context, executors []IExecutor, spaceName string, indexType IndexType, indexName string, options ...WaitOption) error {
+	if indexName == "" {
+		return fmt.Errorf("index name cannot be empty")
+	}
+	if indexType != IndexTypeTag && indexType != IndexTypeEdge {
+		return fmt.Errorf("invalid index type %s", indexType)
+	}
+
+	opts := newWaitOptions(options)
+	if err := waitForSpace(ctx, executors, spaceName, opts); err != nil {
+		return err
+	}
+
+	// The index is known by the meta service once created, read the schema it is built on
+	var schemaName string
+	err := poll(ctx, opts, fmt.Sprintf("%s index %s", indexType, indexName), func() error {
+		rs, err := ExecuteChecked(ctx, executors[0], fmt.Sprintf("SHOW CREATE %s INDEX %s;", indexType, indexName))
+		if err != nil {
+			return err
+		}
+		schemaName, err = indexedSchemaName(rs)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	lookupYield := "id(vertex)"
+	if indexType == IndexTypeEdge {
+		lookupYield = "src(edge)"
+	}
+	stmt := fmt.Sprintf("EXPLAIN LOOKUP ON %s YIELD %s;", schemaName, lookupYield)
+	return waitOnEach(ctx, executors, opts, fmt.Sprintf("%s index %s", indexType, indexName), stmt)
+}
+
+func waitForSpace(ctx context.Context, executors []IExecutor, spaceName string, opts WaitOptions) error {
+	if spaceName == "" {
+		return fmt.Errorf("space name cannot be empty")
+	}
+
+	// USE is validated against the spaces cached by the graph service
+	return waitOnEach(ctx, executors, opts, fmt.Sprintf("space %s", spaceName), fmt.Sprintf("USE %s;", spaceName))
+}
+
+// waitForSpaceVid waits for the space and returns a vertex ID literal of the vid type of the space.
+func waitForSpaceVid(ctx context.Context, executors []IExecutor, spaceName string, opts WaitOptions) (string, error) {
+	if err := waitForSpace(ctx, executors, spaceName, opts); err != nil {
+		return "", err
+	}
+
+	rs, err := ExecuteChecked(ctx, executors[0], fmt.Sprintf("DESCRIBE SPACE %s;", spaceName))
+	if err != nil {
+		return "", err
+	}
+	vidTypes, err := rs.GetValuesByColName("Vid Type")
+	if err != nil {
+		return "", err
+	}
+	if len(vidTypes) == 0 {
+		return "", fmt.Errorf("space %s not found", spaceName)
+	}
+	vidType, err := vidTypes[0].AsString()
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(strings.ToUpper(vidType), "INT") {
+		return "0", nil
+	}
+	return `""`, nil
+}
+
+// waitOnEach polls every executor with the statement until it succeeds on all of them.
+func waitOnEach(ctx context.Context, executors []IExecutor, opts WaitOptions, object string, stmt string) error {
+	if len(executors) == 0 {
+		return fmt.Errorf("at least one executor is required")
+	}
+
+	for _, executor := range executors {
+		err := poll(ctx, opts, object, func() error {
+			_, err := ExecuteChecked(ctx, executor, stmt)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// poll calls probe with an exponential backoff until it succeeds, it fails with an error other than an
+// *ExecutionError, or ctx is done.
+func poll(ctx context.Context, opts WaitOptions, object string, probe func() error) error {
+	interval := opts.initialInterval
+	for {
+		err := probe()
+		if err == nil {
+			return nil
+		}
+
+		var execErr *ExecutionError
+		if !errors.As(err, &execErr) {
+			return err
+		}
+
+		if interval, err = backoff(ctx, opts, interval); err != nil {
+			return fmt.Errorf("%s is not visible: %w, last error: %s", object, err, execErr.ErrorMsg)
+		}
+	}
+}
+
+// backoff waits for the interval, or until ctx is done, and returns the next interval.
+func backoff(ctx context.Context, opts WaitOptions, interval time.Duration) (time.Duration, error) {
+	timer := time.NewTimer(interval)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return interval, ctx.Err()
+	case <-timer.C:
+	}
+
+	interval *= 2
+	if interval > opts.maxInterval {
+		interval = opts.maxInterval
+	}
+	return interval, nil
+}
+
+func yieldProperties(schemaName string, properties []string, fallback string) string {
+	if len(properties) == 0 {
+		return fallback
+	}
+
+	yields := make([]string, 0, len(properties))
+	for _, prop := range properties {
+		yields = append(yields, schemaName+"."+prop)
+	}
+	return strings.Join(yields, ", ")
+}
+
+// indexedSchemaName returns the tag or edge type name from a SHOW CREATE TAG/EDGE INDEX result,
+// e.g. CREATE TAG INDEX `account_name` ON `account` (`name`(10))
+func indexedSchemaName(rs *ResultSet) (string, error) {
+	if rs.GetRowSize() == 0 || rs.GetColSize() < 2 {
+		return "", fmt.Errorf("unexpected empty SHOW CREATE INDEX result")
+	}
+
+	record, err := rs.GetRowValuesByIndex(0)
+	if err != nil {
+		return "", err
+	}
+	value, err := record.GetValueByIndex(1)
+	if err != nil {
+		return "", err
+	}
+	create, err := value.AsString()
+	if err != nil {
+		return "", err
+	}
+
+	_, after, found := strings.Cut(create, " ON ")
+	if !found {
+		return "", fmt.Errorf("unexpected SHOW CREATE INDEX result: %s", create)
+	}
+	name, _, _ := strings.Cut(strings.TrimSpace(after), "(")
+	return strings.Trim(strings.TrimSpace(name), "`"), nil
+}
@@ -0,0 +1,165 @@
+package nebula_sirius
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nebula-contrib/nebula-sirius/mocks"
+	"github.com/nebula-contrib/nebula-sirius/nebula"
+	"github.com/nebula-contrib/nebula-sirius/nebula/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var fastWait = WithWaitInterval(time.Millisecond, 2*time.Millisecond)
+
+func succeededResp() *graph.ExecutionResponse {
+	return &graph.ExecutionResponse{ErrorCode: nebula.ErrorCode_SUCCEEDED}
+}
+
+func failedResp(errorMsg string) *graph.ExecutionResponse {
+	return &graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_E_SEMANTIC_ERROR,
+		ErrorMsg:  []byte(errorMsg),
+	}
+}
+
+func describeSpaceResp(vidType string) *graph.ExecutionResponse {
+	return &graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("ID"), []byte("Name"), []byte("Vid Type")},
+			Rows: []*nebula.Row{
+				{Values: []*nebula.Value{setIVal(1), {SVal: []byte("bank")}, {SVal: []byte(vidType)}}},
+			},
+		},
+	}
+}
+
+func TestWaitForSpace_RetriesUntilVisible(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	graphClient.On("Execute", ctx, int64(1), []byte("USE bank;")).Return(failedResp("SpaceNotFound: "), nil).Twice()
+	graphClient.On("Execute", ctx, int64(1), []byte("USE bank;")).Return(succeededResp(), nil).Once()
+
+	err := WaitForSpace(ctx, []IExecutor{session}, "bank", fastWait)
+	assert.NoError(t, err)
+}
+
+func TestWaitForSpace_ContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	graphClient.On("Execute", ctx, int64(1), []byte("USE bank;")).Return(failedResp("SpaceNotFound: "), nil)
+
+	err := WaitForSpace(ctx, []IExecutor{session}, "bank", fastWait)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "SpaceNotFound")
+}
+
+func TestWaitForSpace_TransportError(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	transportErr := errors.New("connection refused")
+	graphClient.On("Execute", ctx, int64(1), []byte("USE bank;")).Return(nil, transportErr).Once()
+
+	err := WaitForSpace(ctx, []IExecutor{session}, "bank", fastWait)
+	assert.ErrorIs(t, err, transportErr)
+}
+
+func TestWaitForTag_OnEveryGraphService(t *testing.T) {
+	ctx := context.Background()
+	graphClient1 := mocks.NewGraphService(t)
+	graphClient2 := mocks.NewGraphService(t)
+	session1 := &Session{graphClient: graphClient1, sessionID: 1}
+	session2 := &Session{graphClient: graphClient2, sessionID: 2}
+
+	graphClient1.On("Execute", ctx, int64(1), []byte("USE bank;")).Return(succeededResp(), nil).Once()
+	graphClient2.On("Execute", ctx, int64(2), []byte("USE bank;")).Return(succeededResp(), nil).Once()
+	graphClient1.On("Execute", ctx, int64(1), []byte("DESCRIBE SPACE bank;")).Return(describeSpaceResp("INT64"), nil).Once()
+
+	probe := []byte("EXPLAIN FETCH PROP ON account 0 YIELD account.name, account.age;")
+	graphClient1.On("Execute", ctx, int64(1), probe).Return(succeededResp(), nil).Once()
+	graphClient2.On("Execute", ctx, int64(2), probe).Return(failedResp("TagNotFound: "), nil).Once()
+	graphClient2.On("Execute", ctx, int64(2), probe).Return(succeededResp(), nil).Once()
+
+	err := WaitForTag(ctx, []IExecutor{session1, session2}, "bank", "account", fastWait, WithWaitProperties("name", "age"))
+	assert.NoError(t, err)
+}
+
+func TestWaitForEdge(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	graphClient.On("Execute", ctx, int64(1), []byte("USE bank;")).Return(succeededResp(), nil).Once()
+	graphClient.On("Execute", ctx, int64(1), []byte("DESCRIBE SPACE bank;")).Return(describeSpaceResp("FIXED_STRING(32)"), nil).Once()
+	graphClient.On("Execute", ctx, int64(1), []byte(`EXPLAIN FETCH PROP ON transfer "" -> "" YIELD src(edge);`)).Return(succeededResp(), nil).Once()
+
+	err := WaitForEdge(ctx, []IExecutor{session}, "bank", "transfer", fastWait)
+	assert.NoError(t, err)
+}
+
+func TestWaitForIndex(t *testing.T) {
+	ctx := context.Background()
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	graphClient.On("Execute", ctx, int64(1), []byte("USE bank;")).Return(succeededResp(), nil).Once()
+	graphClient.On("Execute", ctx, int64(1), []byte("SHOW CREATE TAG INDEX account_name;")).Return(&graph.ExecutionResponse{
+		ErrorCode: nebula.ErrorCode_SUCCEEDED,
+		Data: &nebula.DataSet{
+			ColumnNames: [][]byte{[]byte("Tag Index Name"), []byte("Create Tag Index")},
+			Rows: []*nebula.Row{
+				{Values: []*nebula.Value{{SVal: []byte("account_name")}, {SVal: []byte("CREATE TAG INDEX `account_name` ON `account` (\n `name`(10)\n)")}}},
+			},
+		},
+	}, nil).Once()
+	graphClient.On("Execute", ctx, int64(1), []byte("EXPLAIN LOOKUP ON account YIELD id(vertex);")).Return(failedResp("IndexNotFound: "), nil).Once()
+	graphClient.On("Execute", ctx, int64(1), []byte("EXPLAIN LOOKUP ON account YIELD id(vertex);")).Return(succeededResp(), nil).Once()
+
+	err := WaitForIndex(ctx, []IExecutor{session}, "bank", IndexTypeTag, "account_name", fastWait)
+	assert.NoError(t, err)
+}
+
+func TestWaitForIndex_InvalidType(t *testing.T) {
+	graphClient := mocks.NewGraphService(t)
+	session := &Session{graphClient: graphClient, sessionID: 1}
+
+	err := WaitForIndex(context.Background(), []IExecutor{session}, "bank", "FULLTEXT", "account_name")
+	assert.EqualError(t, err, "invalid index type FULLTEXT")
+	graphClient.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// uncheckedExecutor returns the result sets of the graph service as is, without an *ExecutionError.
+type uncheckedExecutor struct {
+	resp *graph.ExecutionResponse
+}
+
+func (e uncheckedExecutor) Execute(ctx context.Context, stmt string) (*ResultSet, error) {
+	return GenResultSet(e.resp)
+}
+
+func TestExecuteChecked(t *testing.T) {
+	ctx := context.Background()
+
+	rs, err := ExecuteChecked(ctx, uncheckedExecutor{succeededResp()}, "USE bank;")
+	assert.NoError(t, err)
+	assert.True(t, rs.IsSucceed())
+
+	rs, err = ExecuteChecked(ctx, uncheckedExecutor{failedResp("SpaceNotFound: ")}, "USE bank;")
+	assert.NotNil(t, rs)
+	var execErr *ExecutionError
+	assert.True(t, errors.As(err, &execErr))
+	assert.Equal(t, "USE bank;", execErr.Stmt)
+	assert.Equal(t, "SpaceNotFound: ", execErr.ErrorMsg)
+}